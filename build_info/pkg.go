@@ -107,6 +107,25 @@ func init() {
 	}
 }
 
+// SELF_NAMES are the names JPD is published under.
+// They are used to catch attempts to update JPD through a JavaScript package manager.
+var SELF_NAMES = []string{
+	"jpd",
+	"javascript-package-delegator",
+	"github.com/louiss0/javascript-package-delegator",
+}
+
+// UpgradeInstructions returns the command that upgrades JPD for each supported install channel.
+func UpgradeInstructions() []string {
+	return []string{
+		"Homebrew:   brew upgrade louiss0/tap/jpd",
+		"Scoop:      scoop update jpd",
+		"Winget:     winget upgrade jpd",
+		"Chocolatey: choco upgrade jpd",
+		"Nix:        nix profile upgrade jpd",
+	}
+}
+
 // Version returns the application's CLI version.
 func Version() string {
 	return CLI_VERSION.String()
@@ -0,0 +1,95 @@
+// Package cmd provides command-line interface implementations for the JavaScript package delegator.
+package cmd
+
+import (
+	// standard library
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	// external
+	"github.com/spf13/cobra"
+
+	// internal
+	"github.com/louiss0/javascript-package-delegator/custom_errors"
+	"github.com/louiss0/javascript-package-delegator/detect"
+	"github.com/louiss0/javascript-package-delegator/internal/deps"
+)
+
+const _FORCE_FLAG = "force"
+
+// NewAddScriptCmd creates a new Cobra command that writes a script into the project manifest.
+func NewAddScriptCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add-script <name> <command>",
+		Short: "Add a script to package.json (or a task to deno.json)",
+		Long: `Add a script to the scripts of package.json, or to the tasks of deno.json for deno.
+The key order and indentation of the file are kept.
+An existing script is only replaced when --force is passed.
+
+Examples:
+  jpd add-script test "vitest"
+  jpd add-script build "vite build" --force`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 2 {
+				return custom_errors.CreateInvalidArgumentErrorWithMessage(
+					"add-script requires a script name and a command",
+				)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name, command := args[0], args[1]
+
+			force, err := cmd.Flags().GetBool(_FORCE_FLAG)
+			if err != nil {
+				return err
+			}
+
+			pm, err := cmd.Flags().GetString(AGENT_FLAG)
+			if err != nil {
+				return fmt.Errorf("failed to get agent flag: %w", err)
+			}
+
+			targetDir, err := cmd.Flags().GetString(_CWD_FLAG)
+			if err != nil {
+				return fmt.Errorf("failed to parse --%s flag: %w", _CWD_FLAG, err)
+			}
+			if targetDir == "" {
+				targetDir, err = os.Getwd()
+				if err != nil {
+					return fmt.Errorf("failed to get current working directory: %w", err)
+				}
+			}
+
+			manifest, field := "package.json", "scripts"
+			if pm == detect.DENO {
+				manifest, field = detect.DENO_JSON, "tasks"
+
+				// Rewriting deno.jsonc would drop its comments
+				_, statErr := os.Stat(filepath.Join(targetDir, detect.DENO_JSON))
+				if errors.Is(statErr, fs.ErrNotExist) {
+					if _, err := os.Stat(filepath.Join(targetDir, detect.DENO_JSONC)); err == nil {
+						return fmt.Errorf("add-script can't edit %s without losing its comments, add the task by hand", detect.DENO_JSONC)
+					}
+				}
+			}
+
+			if err := deps.SetScript(filepath.Join(targetDir, manifest), field, name, command, force); err != nil {
+				if errors.Is(err, deps.ErrScriptExists) {
+					return fmt.Errorf("%w, pass --%s to overwrite it", err, _FORCE_FLAG)
+				}
+				return err
+			}
+
+			_, err = fmt.Fprintf(cmd.OutOrStdout(), "Added %q to the %s of %s\n", name, field, manifest)
+			return err
+		},
+	}
+
+	cmd.Flags().BoolP(_FORCE_FLAG, "f", false, "Overwrite the script if it already exists")
+
+	return cmd
+}
@@ -0,0 +1,82 @@
+package cmd_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/louiss0/javascript-package-delegator/mock"
+	"github.com/louiss0/javascript-package-delegator/testutil"
+)
+
+var _ = Describe("Add Script Command", func() {
+	assert := assert.New(GinkgoT())
+
+	var (
+		mockRunner *mock.MockCommandRunner
+		factory    *testutil.RootCommandFactory
+		targetDir  string
+	)
+
+	readManifest := func(name string) string {
+		data, err := os.ReadFile(filepath.Join(targetDir, name))
+		assert.NoError(err)
+		return string(data)
+	}
+
+	BeforeEach(func() {
+		mockRunner = mock.NewMockCommandRunner()
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+
+		targetDir = GinkgoT().TempDir()
+	})
+
+	It("adds the script to package.json", func() {
+		assert.NoError(os.WriteFile(filepath.Join(targetDir, "package.json"), []byte("{\n  \"name\": \"app\",\n  \"scripts\": {\n    \"dev\": \"vite\"\n  }\n}\n"), 0644))
+
+		output, err := executeCmd(factory.CreateNpmAsDefault(nil), "--cwd", targetDir+"/", "add-script", "test", "vitest")
+		assert.NoError(err)
+		assert.Contains(output, `Added "test" to the scripts of package.json`)
+		assert.Equal("{\n  \"name\": \"app\",\n  \"scripts\": {\n    \"dev\": \"vite\",\n    \"test\": \"vitest\"\n  }\n}\n", readManifest("package.json"))
+		assert.False(mockRunner.HasBeenCalled)
+	})
+
+	It("refuses to overwrite an existing script without --force", func() {
+		original := `{"scripts": {"test": "jest"}}`
+		assert.NoError(os.WriteFile(filepath.Join(targetDir, "package.json"), []byte(original), 0644))
+
+		_, err := executeCmd(factory.CreateNpmAsDefault(nil), "--cwd", targetDir+"/", "add-script", "test", "vitest")
+		assert.Error(err)
+		assert.Contains(err.Error(), `"test" is already defined`)
+		assert.Contains(err.Error(), "pass --force to overwrite it")
+		assert.Equal(original, readManifest("package.json"))
+	})
+
+	It("overwrites an existing script with --force", func() {
+		assert.NoError(os.WriteFile(filepath.Join(targetDir, "package.json"), []byte(`{"scripts": {"test": "jest"}}`), 0644))
+
+		_, err := executeCmd(factory.CreateNpmAsDefault(nil), "--cwd", targetDir+"/", "add-script", "test", "vitest", "--force")
+		assert.NoError(err)
+		assert.JSONEq(`{"scripts": {"test": "vitest"}}`, readManifest("package.json"))
+	})
+
+	It("writes deno tasks into deno.json", func() {
+		assert.NoError(os.WriteFile(filepath.Join(targetDir, "deno.json"), []byte(`{"tasks": {"dev": "deno run -A main.ts"}}`), 0644))
+
+		_, err := executeCmd(factory.CreateDenoAsDefault(nil), "--cwd", targetDir+"/", "add-script", "test", "deno test")
+		assert.NoError(err)
+		assert.JSONEq(`{"tasks": {"dev": "deno run -A main.ts", "test": "deno test"}}`, readManifest("deno.json"))
+	})
+
+	It("requires a name and a command", func() {
+		_, err := executeCmd(factory.CreateNpmAsDefault(nil), "--cwd", targetDir+"/", "add-script", "test")
+		assert.Error(err)
+		assert.Contains(err.Error(), "add-script requires a script name and a command")
+	})
+})
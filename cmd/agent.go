@@ -4,6 +4,7 @@ package cmd
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/charmbracelet/log"
 	"github.com/spf13/cobra"
@@ -29,6 +30,8 @@ This command shows which package manager would be used based on lock files in th
 Examples:
   jpd agent    # Show detected package manager
   jpd agent -a yarn # Explicitly show yarn's agent info (e.g., its version or help)
+  jpd agent -- --version # Everything after a leading -- is passed to the package manager as is
+  jpd agent -- run build -- --flag
 `,
 		Aliases: []string{"a"},
 		// Allow passing through unknown flags (e.g., flags intended for the underlying package manager)
@@ -60,6 +63,13 @@ Examples:
 			// Obtain the command runner from the context, which handles external process execution.
 			cmdRunner := getCommandRunnerFromCommandContext(cmd)
 
+			// Cobra drops the first "--" from args. When it separated arguments (agent run build -- --flag)
+			// it is put back so the package manager sees the same separator, a leading "--" only
+			// stops jpd from parsing flags and everything after it is forwarded verbatim.
+			if dash := cmd.ArgsLenAtDash(); dash > 0 {
+				args = slices.Insert(args, dash, "--")
+			}
+
 			// If the user passed --version to this subcommand, Cobra will consume it as a local flag
 			// and it won't appear in args. Re-add it so we pass it through to the underlying tool.
 			if v, _ := cmd.Flags().GetBool("version"); v {
@@ -0,0 +1,76 @@
+// Package cmd provides command-line interface implementations for the JavaScript package delegator.
+package cmd
+
+import (
+	// standard library
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	// external
+	"github.com/samber/lo"
+
+	// internal
+	"github.com/louiss0/javascript-package-delegator/detect"
+)
+
+const (
+	JPD_ALLOWED_AGENTS_ENV_VAR = "JPD_ALLOWED_AGENTS"
+	JPD_DENIED_AGENTS_ENV_VAR  = "JPD_DENIED_AGENTS"
+)
+
+// ErrUnsupportedPackageManager is wrapped by the error of every command given a package manager jpd can't delegate to.
+var ErrUnsupportedPackageManager = errors.New("unsupported package manager")
+
+// unsupportedPackageManagerError names the package managers jpd supports and how to pick one of them.
+// The message is plain, the error handler colors it the way --color says.
+func unsupportedPackageManagerError(pm string) error {
+	return fmt.Errorf(
+		"%w: %s, jpd supports %s, pass --%s with one of them",
+		ErrUnsupportedPackageManager, pm, strings.Join(detect.SupportedJSPackageManagers[:], ", "), AGENT_FLAG,
+	)
+}
+
+// readAgentList reads a comma separated list of package managers from the environment variable name.
+func readAgentList(name string) ([]string, error) {
+	agents := lo.Compact(lo.Map(strings.Split(os.Getenv(name), ","), func(agent string, _ int) string {
+		return strings.TrimSpace(agent)
+	}))
+
+	for _, agent := range agents {
+		if !lo.Contains(detect.SupportedJSPackageManagers[:], agent) {
+			return nil, fmt.Errorf(
+				"the %s variable contains %q, use these values instead %v",
+				name, agent, detect.SupportedJSPackageManagers,
+			)
+		}
+	}
+
+	return agents, nil
+}
+
+// checkAgentPolicy returns an error when agent is denied by JPD_DENIED_AGENTS
+// or JPD_ALLOWED_AGENTS is set without it. The deny list wins over the allow list.
+func checkAgentPolicy(agent string) error {
+	denied, err := readAgentList(JPD_DENIED_AGENTS_ENV_VAR)
+	if err != nil {
+		return err
+	}
+	if lo.Contains(denied, agent) {
+		return fmt.Errorf("the %s package manager is denied by %s", agent, JPD_DENIED_AGENTS_ENV_VAR)
+	}
+
+	allowed, err := readAgentList(JPD_ALLOWED_AGENTS_ENV_VAR)
+	if err != nil {
+		return err
+	}
+	if len(allowed) > 0 && !lo.Contains(allowed, agent) {
+		return fmt.Errorf(
+			"the %s package manager is not allowed by %s, use one of %v instead",
+			agent, JPD_ALLOWED_AGENTS_ENV_VAR, allowed,
+		)
+	}
+
+	return nil
+}
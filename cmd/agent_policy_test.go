@@ -0,0 +1,122 @@
+package cmd_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/louiss0/javascript-package-delegator/cmd"
+	"github.com/louiss0/javascript-package-delegator/detect"
+	"github.com/louiss0/javascript-package-delegator/mock"
+	"github.com/louiss0/javascript-package-delegator/testutil"
+)
+
+var _ = Describe("Agent allow and deny lists", func() {
+	assert := assert.New(GinkgoT())
+
+	var (
+		mockRunner *mock.MockCommandRunner
+		factory    *testutil.RootCommandFactory
+	)
+
+	BeforeEach(func() {
+		mockRunner = mock.NewMockCommandRunner()
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+
+		// Setenv restores JPD_AGENT afterwards, the detection must not see it at all
+		GinkgoT().Setenv(cmd.JPD_AGENT_ENV_VAR, "")
+		assert.NoError(os.Unsetenv(cmd.JPD_AGENT_ENV_VAR))
+		GinkgoT().Setenv(cmd.JPD_ALLOWED_AGENTS_ENV_VAR, "")
+		GinkgoT().Setenv(cmd.JPD_DENIED_AGENTS_ENV_VAR, "")
+	})
+
+	It("rejects a denied detected agent", func() {
+		GinkgoT().Setenv(cmd.JPD_DENIED_AGENTS_ENV_VAR, "yarn, bun")
+		root := factory.CreateRootCmdWithLockfileDetected(detect.YARN, detect.YARN_LOCK, nil, false)
+
+		_, err := executeCmd(root, "install")
+		assert.ErrorContains(err, "the yarn package manager is denied by JPD_DENIED_AGENTS")
+		assert.False(mockRunner.HasBeenCalled)
+	})
+
+	It("rejects a denied explicit --agent", func() {
+		GinkgoT().Setenv(cmd.JPD_DENIED_AGENTS_ENV_VAR, "bun")
+		root := factory.CreateRootCmdWithLockfileDetected(detect.NPM, detect.PACKAGE_LOCK_JSON, nil, false)
+
+		_, err := executeCmd(root, "install", "--agent", "bun")
+		assert.ErrorContains(err, "the bun package manager is denied by JPD_DENIED_AGENTS")
+		assert.False(mockRunner.HasBeenCalled)
+	})
+
+	It("rejects a detected agent that the allow list excludes", func() {
+		GinkgoT().Setenv(cmd.JPD_ALLOWED_AGENTS_ENV_VAR, "pnpm,npm")
+		root := factory.CreateRootCmdWithLockfileDetected(detect.YARN, detect.YARN_LOCK, nil, false)
+
+		_, err := executeCmd(root, "install")
+		assert.ErrorContains(err, "the yarn package manager is not allowed by JPD_ALLOWED_AGENTS, use one of [pnpm npm] instead")
+		assert.False(mockRunner.HasBeenCalled)
+	})
+
+	It("runs an allowed agent", func() {
+		GinkgoT().Setenv(cmd.JPD_ALLOWED_AGENTS_ENV_VAR, "pnpm")
+		root := factory.CreateRootCmdWithLockfileDetected(detect.PNPM, detect.PNPM_LOCK_YAML, nil, false)
+
+		_, err := executeCmd(root, "install")
+		assert.NoError(err)
+		assert.True(mockRunner.HasCommand("pnpm", "install"))
+	})
+
+	It("rejects unknown package managers in the lists", func() {
+		GinkgoT().Setenv(cmd.JPD_DENIED_AGENTS_ENV_VAR, "yran")
+		root := factory.CreateRootCmdWithLockfileDetected(detect.NPM, detect.PACKAGE_LOCK_JSON, nil, false)
+
+		_, err := executeCmd(root, "install")
+		assert.ErrorContains(err, `the JPD_DENIED_AGENTS variable contains "yran"`)
+	})
+})
+
+var _ = Describe("Unsupported package managers", func() {
+	assert := assert.New(GinkgoT())
+
+	var (
+		mockRunner *mock.MockCommandRunner
+		factory    *testutil.RootCommandFactory
+		dir        string
+	)
+
+	BeforeEach(func() {
+		mockRunner = mock.NewMockCommandRunner()
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+
+		dir = GinkgoT().TempDir()
+		assert.NoError(os.WriteFile(filepath.Join(dir, "package.json"), []byte(`{"scripts": {"dev": "vite"}}`), 0o644))
+	})
+
+	DescribeTable("lists the supported package managers and suggests --agent",
+		func(args ...string) {
+			root := factory.GenerateWithPackageManagerDetector("foo", nil)
+
+			_, err := executeCmd(root, append([]string{"--cwd", dir + "/"}, args...)...)
+			assert.ErrorContains(err, "unsupported package manager: foo, jpd supports deno, bun, pnpm, yarn, npm, pass --agent with one of them")
+			assert.False(mockRunner.HasBeenCalled)
+		},
+		Entry("install", "install", "react"),
+		Entry("run", "run", "dev"),
+		Entry("exec", "exec", "vitest"),
+		Entry("dlx", "dlx", "cowsay"),
+		Entry("create", "create", "vite", "my-app"),
+		Entry("update", "update", "react"),
+		Entry("uninstall", "uninstall", "react"),
+		Entry("clean-install", "clean-install"),
+	)
+})
@@ -0,0 +1,244 @@
+// Package cmd provides command-line interface implementations for the JavaScript package delegator.
+package cmd
+
+import (
+	// standard library
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	// external
+	"github.com/charmbracelet/log"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	// internal
+	"github.com/louiss0/javascript-package-delegator/custom_errors"
+)
+
+// ErrRecursiveAlias is returned when an alias expands back into itself.
+var ErrRecursiveAlias = errors.New("recursive alias")
+
+// NewAliasCmd creates the alias command group that manages the aliases of the jpd config file.
+func NewAliasCmd() *cobra.Command {
+	aliasCmd := &cobra.Command{
+		Use:   "alias",
+		Short: "Manage custom command aliases",
+		Long: `Manage custom command aliases stored in the jpd config file.
+
+An alias is replaced by its command before jpd picks the subcommand, the arguments after it are kept.
+The config file is jpd/config.yaml in the user config directory, JPD_CONFIG points at another one.
+Aliases can't shadow the commands of jpd or expand back into themselves.
+
+Examples:
+  jpd alias set d "run dev"   # jpd d runs jpd run dev
+  jpd alias set t run test    # jpd t --watch runs jpd run test --watch
+  jpd alias list
+  jpd alias unset d`,
+		DisableFlagsInUseLine: true,
+	}
+
+	aliasCmd.AddCommand(NewAliasSetCmd())
+	aliasCmd.AddCommand(NewAliasListCmd())
+	aliasCmd.AddCommand(NewAliasUnsetCmd())
+
+	return aliasCmd
+}
+
+// NewAliasSetCmd creates the "alias set" subcommand.
+func NewAliasSetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "set <name> <command...>",
+		Short: "Add or replace an alias",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 2 {
+				return custom_errors.CreateInvalidArgumentErrorWithMessage(
+					"alias set requires a name and the command it runs",
+				)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name, command := args[0], strings.Join(args[1:], " ")
+
+			if name == "" || strings.HasPrefix(name, "-") || strings.ContainsAny(name, " \t\n") {
+				return fmt.Errorf("the alias name %q must be one word that doesn't start with -", name)
+			}
+			if isJPDCommand(cmd.Root(), name) {
+				return fmt.Errorf("the alias %s would shadow the %s command of jpd", name, name)
+			}
+			if len(strings.Fields(command)) == 0 {
+				return fmt.Errorf("the alias %s needs a command to run", name)
+			}
+
+			config, err := LoadJPDConfig()
+			if err != nil {
+				return err
+			}
+			if config.Aliases == nil {
+				config.Aliases = map[string]string{}
+			}
+			config.Aliases[name] = command
+
+			if _, err := ExpandAliases(cmd.Root(), []string{name}, config.Aliases); err != nil {
+				return err
+			}
+
+			if err := SaveJPDConfig(config); err != nil {
+				return err
+			}
+
+			_, err = fmt.Fprintf(cmd.OutOrStdout(), "Set alias %s to %q\n", name, command)
+			return err
+		},
+	}
+}
+
+// NewAliasListCmd creates the "alias list" subcommand.
+func NewAliasListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "Print the aliases",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := LoadJPDConfig()
+			if err != nil {
+				return err
+			}
+
+			if len(config.Aliases) == 0 {
+				return printNote(cmd, "no aliases are set, add one with jpd alias set")
+			}
+
+			names := lo.Keys(config.Aliases)
+			sort.Strings(names)
+			for _, name := range names {
+				if _, err := fmt.Fprintf(cmd.OutOrStdout(), "%s = %s\n", name, config.Aliases[name]); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// NewAliasUnsetCmd creates the "alias unset" subcommand.
+func NewAliasUnsetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "unset <name>",
+		Short: "Remove an alias",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return custom_errors.CreateInvalidArgumentErrorWithMessage(
+					"alias unset requires exactly one name",
+				)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+
+			config, err := LoadJPDConfig()
+			if err != nil {
+				return err
+			}
+			if _, ok := config.Aliases[name]; !ok {
+				return fmt.Errorf("no alias is named %s", name)
+			}
+			delete(config.Aliases, name)
+
+			if err := SaveJPDConfig(config); err != nil {
+				return err
+			}
+
+			_, err = fmt.Fprintf(cmd.OutOrStdout(), "Removed alias %s\n", name)
+			return err
+		},
+	}
+}
+
+// ExpandAliases replaces the alias in args that takes the place of the subcommand with the command it runs.
+// The global flags before it and the arguments after it are kept, commands of jpd always win over aliases.
+func ExpandAliases(root *cobra.Command, args []string, aliases map[string]string) ([]string, error) {
+	i := subcommandIndex(root, args)
+	if i < 0 {
+		return args, nil
+	}
+
+	name := args[i]
+	if _, ok := aliases[name]; !ok || isJPDCommand(root, name) {
+		return args, nil
+	}
+
+	seen := []string{name}
+	words := strings.Fields(aliases[name])
+	for len(words) > 0 {
+		next := words[0]
+		if _, ok := aliases[next]; !ok || isJPDCommand(root, next) {
+			break
+		}
+		if lo.Contains(seen, next) {
+			return nil, fmt.Errorf("%w: %s", ErrRecursiveAlias, strings.Join(append(seen, next), " -> "))
+		}
+		seen = append(seen, next)
+		words = append(strings.Fields(aliases[next]), words[1:]...)
+	}
+	if len(words) == 0 {
+		return nil, fmt.Errorf("the alias %s has no command to run", name)
+	}
+
+	return lo.Flatten([][]string{args[:i], words, args[i+1:]}), nil
+}
+
+// ExpandConfigAliases expands args with the aliases of the jpd config file.
+// A config file that can't be read only costs the aliases, args are returned as they are with a warning.
+func ExpandConfigAliases(root *cobra.Command, args []string) ([]string, error) {
+	config, err := LoadJPDConfig()
+	if err != nil {
+		log.Warn("Aliases are not expanded", "error", err)
+		return args, nil
+	}
+	if len(config.Aliases) == 0 {
+		return args, nil
+	}
+
+	return ExpandAliases(root, args, config.Aliases)
+}
+
+// subcommandIndex returns the index of the first argument that isn't a global flag or its value, -1 when there is none.
+func subcommandIndex(root *cobra.Command, args []string) int {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			return -1
+		}
+		if !strings.HasPrefix(arg, "-") {
+			return i
+		}
+		if strings.Contains(arg, "=") {
+			continue
+		}
+
+		// Flags that need a value take the next argument, unless the value is attached like -C/dir
+		var flag *pflag.Flag
+		switch {
+		case strings.HasPrefix(arg, "--"):
+			flag = root.PersistentFlags().Lookup(strings.TrimPrefix(arg, "--"))
+		case len(arg) == 2:
+			flag = root.PersistentFlags().ShorthandLookup(strings.TrimPrefix(arg, "-"))
+		}
+		if flag != nil && flag.NoOptDefVal == "" {
+			i++
+		}
+	}
+	return -1
+}
+
+// isJPDCommand reports whether name is a subcommand of root or one of its aliases.
+func isJPDCommand(root *cobra.Command, name string) bool {
+	return lo.ContainsBy(root.Commands(), func(c *cobra.Command) bool {
+		return c.Name() == name || c.HasAlias(name)
+	})
+}
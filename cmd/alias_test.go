@@ -0,0 +1,176 @@
+package cmd_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/louiss0/javascript-package-delegator/cmd"
+	"github.com/louiss0/javascript-package-delegator/mock"
+	"github.com/louiss0/javascript-package-delegator/testutil"
+)
+
+var _ = Describe("Alias Command", func() {
+	assert := assert.New(GinkgoT())
+
+	var (
+		mockRunner *mock.MockCommandRunner
+		factory    *testutil.RootCommandFactory
+		configPath string
+		dir        string
+	)
+
+	BeforeEach(func() {
+		mockRunner = mock.NewMockCommandRunner()
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+
+		configPath = filepath.Join(GinkgoT().TempDir(), "jpd", "config.yaml")
+		GinkgoT().Setenv(cmd.JPD_CONFIG_ENV_VAR, configPath)
+
+		dir = GinkgoT().TempDir()
+		assert.NoError(os.WriteFile(filepath.Join(dir, "package.json"), []byte(`{"scripts": {"dev": "vite", "test": "vitest"}}`), 0o644))
+	})
+
+	It("stores the alias in the config file and lists it", func() {
+		output, err := executeCmd(factory.CreateNpmAsDefault(nil), "alias", "set", "d", "run dev")
+		assert.NoError(err)
+		assert.Contains(output, `Set alias d to "run dev"`)
+
+		config, err := cmd.LoadJPDConfig()
+		assert.NoError(err)
+		assert.Equal(map[string]string{"d": "run dev"}, config.Aliases)
+
+		output, err = executeCmd(factory.CreateNpmAsDefault(nil), "alias", "list")
+		assert.NoError(err)
+		assert.Contains(output, "d = run dev")
+		assert.False(mockRunner.HasBeenCalled)
+	})
+
+	It("joins the words of the command", func() {
+		_, err := executeCmd(factory.CreateNpmAsDefault(nil), "alias", "set", "t", "run", "test")
+		assert.NoError(err)
+
+		config, err := cmd.LoadJPDConfig()
+		assert.NoError(err)
+		assert.Equal("run test", config.Aliases["t"])
+	})
+
+	It("notes that there are no aliases", func() {
+		output, err := executeCmd(factory.CreateNpmAsDefault(nil), "alias", "list")
+		assert.NoError(err)
+		assert.Contains(output, "Note: no aliases are set")
+	})
+
+	It("removes the alias with unset", func() {
+		assert.NoError(cmd.SaveJPDConfig(cmd.JPDConfig{Aliases: map[string]string{"d": "run dev", "t": "run test"}}))
+
+		_, err := executeCmd(factory.CreateNpmAsDefault(nil), "alias", "unset", "d")
+		assert.NoError(err)
+
+		config, err := cmd.LoadJPDConfig()
+		assert.NoError(err)
+		assert.Equal(map[string]string{"t": "run test"}, config.Aliases)
+	})
+
+	It("returns an error when unsetting an alias that isn't set", func() {
+		_, err := executeCmd(factory.CreateNpmAsDefault(nil), "alias", "unset", "d")
+		assert.ErrorContains(err, "no alias is named d")
+	})
+
+	It("refuses an alias that shadows a command of jpd", func() {
+		_, err := executeCmd(factory.CreateNpmAsDefault(nil), "alias", "set", "install", "run dev")
+		assert.ErrorContains(err, "the alias install would shadow the install command of jpd")
+		assert.NoFileExists(configPath)
+	})
+
+	It("refuses an alias that expands back into itself", func() {
+		assert.NoError(cmd.SaveJPDConfig(cmd.JPDConfig{Aliases: map[string]string{"w": "v --watch"}}))
+
+		_, err := executeCmd(factory.CreateNpmAsDefault(nil), "alias", "set", "v", "w")
+		assert.ErrorContains(err, "recursive alias: v -> w -> v")
+
+		config, err := cmd.LoadJPDConfig()
+		assert.NoError(err)
+		assert.Equal(map[string]string{"w": "v --watch"}, config.Aliases)
+	})
+
+	Context("ExpandAliases", func() {
+		aliases := map[string]string{"d": "run dev", "t": "run test", "tw": "t --watch"}
+
+		It("expands an alias to the args it stands for", func() {
+			root := factory.CreateNpmAsDefault(nil)
+
+			args, err := cmd.ExpandAliases(root, []string{"--cwd", dir + "/", "d"}, aliases)
+			assert.NoError(err)
+			assert.Equal([]string{"--cwd", dir + "/", "run", "dev"}, args)
+
+			_, err = executeCmd(root, args...)
+			assert.NoError(err)
+			assert.True(mockRunner.HasCommand("npm", "run", "dev"), "got %v", mockRunner.CommandCall)
+		})
+
+		It("keeps the arguments after the alias", func() {
+			args, err := cmd.ExpandAliases(factory.CreateNpmAsDefault(nil), []string{"-d", "t", "--", "--coverage"}, aliases)
+			assert.NoError(err)
+			assert.Equal([]string{"-d", "run", "test", "--", "--coverage"}, args)
+		})
+
+		It("expands aliases that use other aliases", func() {
+			args, err := cmd.ExpandAliases(factory.CreateNpmAsDefault(nil), []string{"tw"}, aliases)
+			assert.NoError(err)
+			assert.Equal([]string{"run", "test", "--watch"}, args)
+		})
+
+		It("leaves the commands of jpd and unknown words alone", func() {
+			root := factory.CreateNpmAsDefault(nil)
+
+			args, err := cmd.ExpandAliases(root, []string{"run", "d"}, map[string]string{"run": "install", "d": "run dev"})
+			assert.NoError(err)
+			assert.Equal([]string{"run", "d"}, args)
+
+			args, err = cmd.ExpandAliases(root, []string{"--cwd", "d", "dev"}, aliases)
+			assert.NoError(err)
+			assert.Equal([]string{"--cwd", "d", "dev"}, args)
+		})
+
+		It("returns an error for a recursive alias", func() {
+			_, err := cmd.ExpandAliases(factory.CreateNpmAsDefault(nil), []string{"v"}, map[string]string{"v": "w", "w": "v dev"})
+			assert.ErrorIs(err, cmd.ErrRecursiveAlias)
+			assert.ErrorContains(err, "v -> w -> v")
+		})
+	})
+
+	Context("ExpandConfigAliases", func() {
+		It("expands the aliases of the config file", func() {
+			_, err := executeCmd(factory.CreateNpmAsDefault(nil), "alias", "set", "d", "run dev")
+			assert.NoError(err)
+
+			args, err := cmd.ExpandConfigAliases(factory.CreateNpmAsDefault(nil), []string{"d", "--watch"})
+			assert.NoError(err)
+			assert.Equal([]string{"run", "dev", "--watch"}, args)
+		})
+
+		It("keeps the args when the config file can't be parsed", func() {
+			assert.NoError(os.MkdirAll(filepath.Dir(configPath), 0o755))
+			assert.NoError(os.WriteFile(configPath, []byte("aliases: [d"), 0o644))
+
+			args, err := cmd.ExpandConfigAliases(factory.CreateNpmAsDefault(nil), []string{"d"})
+			assert.NoError(err)
+			assert.Equal([]string{"d"}, args)
+		})
+
+		It("returns the error of a recursive alias", func() {
+			assert.NoError(os.MkdirAll(filepath.Dir(configPath), 0o755))
+			assert.NoError(os.WriteFile(configPath, []byte("aliases:\n  v: w\n  w: v dev\n"), 0o644))
+
+			_, err := cmd.ExpandConfigAliases(factory.CreateNpmAsDefault(nil), []string{"v"})
+			assert.ErrorIs(err, cmd.ErrRecursiveAlias)
+		})
+	})
+})
@@ -0,0 +1,119 @@
+// Package cmd provides command-line interface implementations for the JavaScript package delegator.
+package cmd
+
+import (
+	// standard library
+	"fmt"
+	"strings"
+
+	// external
+	"github.com/charmbracelet/log"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+
+	// internal
+	"github.com/louiss0/javascript-package-delegator/detect"
+)
+
+const _AUDIT_LEVEL_FLAG = "audit-level"
+
+// auditLevels are the severities --audit-level accepts, from the least to the most serious.
+var auditLevels = []string{"low", "moderate", "high", "critical"}
+
+// BuildAuditCommand builds the command line that checks the dependencies for known vulnerabilities.
+// With a level only vulnerabilities of that severity or higher fail the command:
+//
+// | Package Manager | Command                                 |
+// |-----------------|-----------------------------------------|
+// | npm             | npm audit [--audit-level <level>]       |
+// | pnpm            | pnpm audit [--audit-level <level>]      |
+// | yarn v1         | yarn audit [--level <level>]            |
+// | yarn v2+        | yarn npm audit [--severity <level>]     |
+// | bun             | bun audit [--audit-level <level>]       |
+func BuildAuditCommand(pm, yarnVersion, level string) (program string, args []string, err error) {
+	if level != "" && !lo.Contains(auditLevels, level) {
+		return "", nil, fmt.Errorf("the --%s flag must be one of %v, got: %s", _AUDIT_LEVEL_FLAG, auditLevels, level)
+	}
+
+	levelFlag := "--audit-level"
+
+	switch pm {
+	case "npm", "pnpm", "bun":
+		args = []string{"audit"}
+
+	case "yarn":
+		if ParseYarnMajor(yarnVersion) >= 2 {
+			args, levelFlag = []string{"npm", "audit"}, "--severity"
+			break
+		}
+		args, levelFlag = []string{"audit"}, "--level"
+
+	case "deno":
+		return "", nil, fmt.Errorf("deno does not support the audit command")
+
+	default:
+		return "", nil, unsupportedPackageManagerError(pm)
+	}
+
+	if level != "" {
+		args = append(args, levelFlag, level)
+	}
+
+	return pm, args, nil
+}
+
+// NewAuditCmd creates a new Cobra command that checks the dependencies for known vulnerabilities.
+func NewAuditCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Check the dependencies for known vulnerabilities",
+		Long: `Check the dependencies for known vulnerabilities
+using the audit command of the detected package manager.
+
+--audit-level makes the command fail only for vulnerabilities of that severity or higher,
+yarn calls it --level on v1 and --severity on v2+.
+
+Examples:
+  jpd audit                     # npm audit, yarn npm audit on yarn v2+
+  jpd audit --audit-level high  # Fail only on high and critical vulnerabilities`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pm, _ := cmd.Flags().GetString(AGENT_FLAG)
+			goEnv := getGoEnvFromCommandContext(cmd)
+			de := getDebugExecutorFromCommandContext(cmd)
+			cmdRunner := getCommandRunnerFromCommandContext(cmd)
+
+			level, err := cmd.Flags().GetString(_AUDIT_LEVEL_FLAG)
+			if err != nil {
+				return err
+			}
+
+			yarnVersion := ""
+			if pm == detect.YARN {
+				if version, err := detect.DetectYarnVersion(
+					getYarnVersionRunnerCommandContext(cmd),
+				); err == nil {
+					yarnVersion = version
+				}
+			}
+
+			program, cmdArgs, err := BuildAuditCommand(pm, yarnVersion, level)
+			if err != nil {
+				return err
+			}
+
+			de.LogJSCommandIfDebugIsTrue(program, cmdArgs...)
+			cmdRunner.Command(program, cmdArgs...)
+
+			goEnv.ExecuteIfModeIsProduction(func() {
+				log.Info("Running command", "cmd", program, "args", strings.Join(cmdArgs, " "))
+			})
+
+			return cmdRunner.Run()
+		},
+	}
+
+	cmd.Flags().String(_AUDIT_LEVEL_FLAG, "", "Fail only for vulnerabilities of this severity or higher: low, moderate, high or critical")
+
+	return cmd
+}
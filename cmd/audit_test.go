@@ -0,0 +1,55 @@
+package cmd_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/louiss0/javascript-package-delegator/mock"
+	"github.com/louiss0/javascript-package-delegator/testutil"
+)
+
+var _ = Describe("Audit Command", func() {
+	assert := assert.New(GinkgoT())
+
+	var (
+		mockRunner *mock.MockCommandRunner
+		factory    *testutil.RootCommandFactory
+	)
+
+	BeforeEach(func() {
+		mockRunner = mock.NewMockCommandRunner()
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+	})
+
+	DescribeTable("runs the audit command of each package manager",
+		func(newRootCmd func(*testutil.RootCommandFactory, error) *cobra.Command, args []string, expected []string) {
+			_, err := executeCmd(newRootCmd(factory, nil), append([]string{"audit"}, args...)...)
+			assert.NoError(err)
+			assert.True(mockRunner.HasCommand(expected[0], expected[1:]...), "got %v", mockRunner.CommandCall)
+		},
+		Entry("npm", (*testutil.RootCommandFactory).CreateNpmAsDefault, nil, []string{"npm", "audit"}),
+		Entry("npm --audit-level", (*testutil.RootCommandFactory).CreateNpmAsDefault, []string{"--audit-level", "high"}, []string{"npm", "audit", "--audit-level", "high"}),
+		Entry("pnpm --audit-level", (*testutil.RootCommandFactory).CreatePnpmAsDefault, []string{"--audit-level=critical"}, []string{"pnpm", "audit", "--audit-level", "critical"}),
+		Entry("yarn v1 --audit-level", (*testutil.RootCommandFactory).CreateYarnOneAsDefault, []string{"--audit-level", "moderate"}, []string{"yarn", "audit", "--level", "moderate"}),
+		Entry("yarn v2+", (*testutil.RootCommandFactory).CreateYarnTwoAsDefault, nil, []string{"yarn", "npm", "audit"}),
+		Entry("yarn v2+ --audit-level", (*testutil.RootCommandFactory).CreateYarnTwoAsDefault, []string{"--audit-level", "high"}, []string{"yarn", "npm", "audit", "--severity", "high"}),
+		Entry("bun --audit-level", (*testutil.RootCommandFactory).CreateBunAsDefault, []string{"--audit-level", "low"}, []string{"bun", "audit", "--audit-level", "low"}),
+	)
+
+	It("returns an error for deno", func() {
+		_, err := executeCmd(factory.CreateDenoAsDefault(nil), "audit", "--audit-level", "high")
+		assert.ErrorContains(err, "deno does not support the audit command")
+		assert.False(mockRunner.HasBeenCalled)
+	})
+
+	It("rejects an unknown level", func() {
+		_, err := executeCmd(factory.CreateNpmAsDefault(nil), "audit", "--audit-level", "severe")
+		assert.ErrorContains(err, "the --audit-level flag must be one of [low moderate high critical], got: severe")
+		assert.False(mockRunner.HasBeenCalled)
+	})
+})
@@ -0,0 +1,132 @@
+// Package cmd provides command-line interface implementations for the JavaScript package delegator.
+package cmd
+
+import (
+	// standard library
+	"fmt"
+	"strings"
+
+	// external
+	"github.com/charmbracelet/log"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+
+	// internal
+	"github.com/louiss0/javascript-package-delegator/detect"
+)
+
+const _NO_GIT_TAG_FLAG = "no-git-tag"
+
+// bumpTypes are the parts of the version bump can increase.
+var bumpTypes = []string{"major", "minor", "patch"}
+
+// BuildBumpCommand builds the command line that increases the bump part of the package version.
+// With noGitTag the version is changed without committing and tagging it:
+//
+// | Package Manager | Command                                          |
+// |-----------------|--------------------------------------------------|
+// | npm             | npm version <bump> [--no-git-tag-version]        |
+// | pnpm            | pnpm version <bump> [--no-git-tag-version]       |
+// | yarn v1         | yarn version --<bump> [--no-git-tag-version]     |
+// | yarn v2+        | yarn version <bump> (never tags)                 |
+// | bun             | bun pm version <bump> [--no-git-tag-version]     |
+func BuildBumpCommand(pm, yarnVersion, bump string, noGitTag bool) (program string, args []string, err error) {
+	if !lo.Contains(bumpTypes, bump) {
+		return "", nil, fmt.Errorf("the version bump must be one of %v, got: %s", bumpTypes, bump)
+	}
+
+	switch pm {
+	case "npm", "pnpm":
+		args = []string{"version", bump}
+
+	case "yarn":
+		if ParseYarnMajor(yarnVersion) >= 2 {
+			// The version plugin of yarn v2+ only writes package.json, there is no tag to skip
+			return "yarn", []string{"version", bump}, nil
+		}
+		args = []string{"version", "--" + bump}
+
+	case "bun":
+		args = []string{"pm", "version", bump}
+
+	case "deno":
+		return "", nil, fmt.Errorf("deno does not support the bump command")
+
+	default:
+		return "", nil, unsupportedPackageManagerError(pm)
+	}
+
+	if noGitTag {
+		args = append(args, "--no-git-tag-version")
+	}
+
+	return pm, args, nil
+}
+
+// NewBumpCmd creates a new Cobra command that increases the version of the package.
+func NewBumpCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bump <major|minor|patch>",
+		Short: "Increase the version of the package",
+		Long: `Increase the major, minor or patch part of the version in package.json
+using the version command of the detected package manager.
+
+npm, pnpm, yarn v1 and bun commit and tag the new version in git,
+--no-git-tag only changes package.json. Yarn v2+ never creates a tag.
+
+Examples:
+  jpd bump patch               # npm version patch, yarn version --patch on yarn v1
+  jpd bump minor --no-git-tag  # Change the version without a commit and tag`,
+		Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		ValidArgs: bumpTypes,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pm, _ := cmd.Flags().GetString(AGENT_FLAG)
+			goEnv := getGoEnvFromCommandContext(cmd)
+			de := getDebugExecutorFromCommandContext(cmd)
+			cmdRunner := getCommandRunnerFromCommandContext(cmd)
+
+			noGitTag, err := cmd.Flags().GetBool(_NO_GIT_TAG_FLAG)
+			if err != nil {
+				return err
+			}
+
+			yarnVersion := ""
+			if pm == detect.YARN {
+				if version, err := detect.DetectYarnVersion(
+					getYarnVersionRunnerCommandContext(cmd),
+				); err == nil {
+					yarnVersion = version
+				}
+			}
+
+			program, cmdArgs, err := BuildBumpCommand(pm, yarnVersion, args[0], noGitTag)
+			if err != nil {
+				return err
+			}
+
+			if noGitTag && pm == detect.YARN && ParseYarnMajor(yarnVersion) >= 2 {
+				err := printNote(
+					cmd,
+					"yarn %s never tags the new version, --%s is ignored",
+					strings.TrimSpace(yarnVersion), _NO_GIT_TAG_FLAG,
+				)
+				if err != nil {
+					return err
+				}
+			}
+
+			de.LogJSCommandIfDebugIsTrue(program, cmdArgs...)
+			cmdRunner.Command(program, cmdArgs...)
+
+			goEnv.ExecuteIfModeIsProduction(func() {
+				log.Info("Running command", "cmd", program, "args", strings.Join(cmdArgs, " "))
+			})
+
+			return cmdRunner.Run()
+		},
+	}
+
+	cmd.Flags().Bool(_NO_GIT_TAG_FLAG, false, "Change the version without committing and tagging it (npm --no-git-tag-version)")
+
+	return cmd
+}
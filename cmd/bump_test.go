@@ -0,0 +1,65 @@
+package cmd_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/louiss0/javascript-package-delegator/mock"
+	"github.com/louiss0/javascript-package-delegator/testutil"
+)
+
+var _ = Describe("Bump Command", func() {
+	assert := assert.New(GinkgoT())
+
+	var (
+		mockRunner *mock.MockCommandRunner
+		factory    *testutil.RootCommandFactory
+	)
+
+	BeforeEach(func() {
+		mockRunner = mock.NewMockCommandRunner()
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+	})
+
+	DescribeTable("runs the version command of each package manager",
+		func(newRootCmd func(*testutil.RootCommandFactory, error) *cobra.Command, args []string, expected []string) {
+			_, err := executeCmd(newRootCmd(factory, nil), append([]string{"bump"}, args...)...)
+			assert.NoError(err)
+			assert.True(mockRunner.HasCommand(expected[0], expected[1:]...), "got %v", mockRunner.CommandCall)
+		},
+		Entry("npm patch", (*testutil.RootCommandFactory).CreateNpmAsDefault, []string{"patch"}, []string{"npm", "version", "patch"}),
+		Entry("npm major", (*testutil.RootCommandFactory).CreateNpmAsDefault, []string{"major"}, []string{"npm", "version", "major"}),
+		Entry("npm --no-git-tag", (*testutil.RootCommandFactory).CreateNpmAsDefault, []string{"minor", "--no-git-tag"}, []string{"npm", "version", "minor", "--no-git-tag-version"}),
+		Entry("pnpm minor", (*testutil.RootCommandFactory).CreatePnpmAsDefault, []string{"minor"}, []string{"pnpm", "version", "minor"}),
+		Entry("pnpm --no-git-tag", (*testutil.RootCommandFactory).CreatePnpmAsDefault, []string{"patch", "--no-git-tag"}, []string{"pnpm", "version", "patch", "--no-git-tag-version"}),
+		Entry("yarn v1 patch", (*testutil.RootCommandFactory).CreateYarnOneAsDefault, []string{"patch"}, []string{"yarn", "version", "--patch"}),
+		Entry("yarn v1 --no-git-tag", (*testutil.RootCommandFactory).CreateYarnOneAsDefault, []string{"major", "--no-git-tag"}, []string{"yarn", "version", "--major", "--no-git-tag-version"}),
+		Entry("yarn v2+ minor", (*testutil.RootCommandFactory).CreateYarnTwoAsDefault, []string{"minor"}, []string{"yarn", "version", "minor"}),
+		Entry("bun patch", (*testutil.RootCommandFactory).CreateBunAsDefault, []string{"patch"}, []string{"bun", "pm", "version", "patch"}),
+		Entry("bun --no-git-tag", (*testutil.RootCommandFactory).CreateBunAsDefault, []string{"patch", "--no-git-tag"}, []string{"bun", "pm", "version", "patch", "--no-git-tag-version"}),
+	)
+
+	It("prints a note for --no-git-tag on yarn v2+", func() {
+		output, err := executeCmd(factory.CreateYarnTwoAsDefault(nil), "bump", "patch", "--no-git-tag")
+		assert.NoError(err)
+		assert.Contains(output, "never tags the new version, --no-git-tag is ignored")
+		assert.True(mockRunner.HasCommand("yarn", "version", "patch"))
+	})
+
+	It("returns an error for deno", func() {
+		_, err := executeCmd(factory.CreateDenoAsDefault(nil), "bump", "patch")
+		assert.ErrorContains(err, "deno does not support the bump command")
+		assert.False(mockRunner.HasBeenCalled)
+	})
+
+	It("rejects an unknown version part", func() {
+		_, err := executeCmd(factory.CreateNpmAsDefault(nil), "bump", "prerelease")
+		assert.ErrorContains(err, `invalid argument "prerelease"`)
+		assert.False(mockRunner.HasBeenCalled)
+	})
+})
@@ -37,6 +37,12 @@ import (
 	"github.com/louiss0/javascript-package-delegator/detect"
 )
 
+// Clean install flags
+const (
+	_NO_OPTIONAL_FLAG    = "no-optional"
+	_IGNORE_SCRIPTS_FLAG = "ignore-scripts"
+)
+
 func NewCleanInstallCmd(detectVolta func() bool) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "clean-install",
@@ -45,10 +51,18 @@ func NewCleanInstallCmd(detectVolta func() bool) *cobra.Command {
 Equivalent to 'nci' command - detects npm, yarn, pnpm, or bun and runs clean install.
 
 This command is designed for CI environments and production builds where you want to install
-exactly what's in the lockfile without updating it.
+exactly what's in the lockfile without updating it. The strictest mode of each package manager
+is always used, in CI and locally alike:
+
+  npm       ci
+  pnpm      install --frozen-lockfile
+  yarn v1   install --frozen-lockfile
+  yarn v2+  install --immutable
+  bun       install --frozen-lockfile
 
 Examples:
-  javascript-package-delegator clean-install     # Clean install all dependencies`,
+  javascript-package-delegator clean-install     # Clean install all dependencies
+  javascript-package-delegator clean-install --no-optional --ignore-scripts # Skip optional dependencies and lifecycle scripts`,
 		Aliases: []string{"ci"},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			pm, _ := cmd.Flags().GetString(AGENT_FLAG)
@@ -56,11 +70,27 @@ Examples:
 			cmdRunner := getCommandRunnerFromCommandContext(cmd)
 			de := getDebugExecutorFromCommandContext(cmd)
 
+			noOptional, err := cmd.Flags().GetBool(_NO_OPTIONAL_FLAG)
+			if err != nil {
+				return err
+			}
+
+			ignoreScripts, err := cmd.Flags().GetBool(_IGNORE_SCRIPTS_FLAG)
+			if err != nil {
+				return err
+			}
+
 			// Build command based on package manager
 			var cmdArgs []string
 			switch pm {
 			case "npm":
 				cmdArgs = []string{"ci"}
+				if noOptional {
+					cmdArgs = append(cmdArgs, "--omit=optional")
+				}
+				if ignoreScripts {
+					cmdArgs = append(cmdArgs, "--ignore-scripts")
+				}
 
 			case "yarn":
 				// Yarn v1 uses install --frozen-lockfile, v2+ uses install --immutable
@@ -71,22 +101,54 @@ Examples:
 				if err != nil || strings.HasPrefix(yarnVersion, "1.") {
 					// Yarn v1 or unknown version
 					cmdArgs = []string{"install", "--frozen-lockfile"}
+					if noOptional {
+						cmdArgs = append(cmdArgs, "--ignore-optional")
+					}
+					if ignoreScripts {
+						cmdArgs = append(cmdArgs, "--ignore-scripts")
+					}
 				} else {
 					// Yarn v2+
 					cmdArgs = []string{"install", "--immutable"}
+					if noOptional {
+						// Yarn v2+ always installs optional dependencies that match the platform
+						err := printNote(
+							cmd,
+							"yarn %s has no option to skip optional dependencies, --%s is ignored",
+							strings.TrimSpace(yarnVersion), _NO_OPTIONAL_FLAG,
+						)
+						if err != nil {
+							return err
+						}
+					}
+					if ignoreScripts {
+						cmdArgs = append(cmdArgs, "--mode=skip-build")
+					}
 				}
 
 			case "pnpm":
 				cmdArgs = []string{"install", "--frozen-lockfile"}
+				if noOptional {
+					cmdArgs = append(cmdArgs, "--no-optional")
+				}
+				if ignoreScripts {
+					cmdArgs = append(cmdArgs, "--ignore-scripts")
+				}
 
 			case "bun":
 				cmdArgs = []string{"install", "--frozen-lockfile"}
+				if noOptional {
+					cmdArgs = append(cmdArgs, "--omit=optional")
+				}
+				if ignoreScripts {
+					cmdArgs = append(cmdArgs, "--ignore-scripts")
+				}
 
 			case "deno":
 				return fmt.Errorf("deno does not support this command")
 
 			default:
-				return fmt.Errorf("unsupported package manager: %s", pm)
+				return unsupportedPackageManagerError(pm)
 			}
 
 			noVolta, err := cmd.Flags().GetBool(_NO_VOLTA_FLAG)
@@ -94,6 +156,14 @@ Examples:
 				return err
 			}
 
+			retries, err := cmd.Flags().GetInt(_RETRIES_FLAG)
+			if err != nil {
+				return err
+			}
+			if retries, err = parseRetries(retries); err != nil {
+				return err
+			}
+
 			// shouldUseVoltaWithPackageManager is true if:
 			// 1. Volta is detected on the system (detectVolta())
 			// 2. The detected package manager (pm) is one of npm, pnpm, or yarn (lo.Contains checks this)
@@ -124,11 +194,14 @@ Examples:
 				de.LogJSCommandIfDebugIsTrue(pm, cmdArgs...)
 			}
 
-			return cmdRunner.Run()
+			return runWithRetries(cmd, cmdRunner, retries)
 		},
 	}
 
 	cmd.Flags().Bool(_NO_VOLTA_FLAG, false, "Disable Volta integration for this command") // New flag for Volta opt-out
+	addRetriesFlag(cmd)
+	cmd.Flags().Bool(_NO_OPTIONAL_FLAG, false, "Skip optional dependencies (where supported)")
+	cmd.Flags().Bool(_IGNORE_SCRIPTS_FLAG, false, "Do not run lifecycle scripts")
 
 	return cmd
 }
@@ -829,6 +829,40 @@ var _ = Describe("JPD Commands", func() {
 			assert.Equal("npm", agentFlag) // Should still be npm from PATH detection
 		})
 
+		It("should resolve a relative --cwd against the process directory", func() {
+			originalDir, err := os.Getwd()
+			assert.NoError(err)
+			assert.NoError(os.Chdir(tempDir))
+			DeferCleanup(func() {
+				_ = os.Chdir(originalDir)
+			})
+
+			processDir, err := os.Getwd()
+			assert.NoError(err)
+			absSubDir := filepath.Join(processDir, "project")
+
+			// Setup: the lockfile can only be found through the absolute path
+			mockFS.files[filepath.Join(absSubDir, "package-lock.json")] = true
+			var detectedIn string
+			deps.DetectLockfile = func(targetDir string) (string, error) {
+				detectedIn = targetDir
+				return detect.DetectLockfileIn(targetDir, mockFS)
+			}
+
+			rootCmd := cmd.NewRootCmd(deps)
+			rootCmd.SetArgs([]string{"--cwd", "project/", "agent"})
+
+			err = rootCmd.Execute()
+			assert.NoError(err)
+
+			assert.Equal(absSubDir+"/", detectedIn)
+			assert.Equal(absSubDir+"/", fakeRunner.lastWorkDir)
+
+			cwdFlag, err := rootCmd.PersistentFlags().GetString("cwd")
+			assert.NoError(err)
+			assert.Equal(absSubDir+"/", cwdFlag)
+		})
+
 		It("should fallback to current directory when --cwd is not provided", func() {
 			// Setup: place yarn.lock in tempDir (current directory)
 			yarnLockPath := filepath.Join(tempDir, "yarn.lock")
@@ -961,6 +995,20 @@ var _ = Describe("JPD Commands", func() {
 			})
 		})
 
+		DescribeTable("places the quiet option of each package manager before the package with --quiet",
+			func(newRootCmd func(*testutil.RootCommandFactory, error) *cobra.Command, pkg string, expected []string) {
+				_, err := executeCmd(newRootCmd(factory, nil), "--quiet", "dlx", pkg, "--", "--version")
+				assert.NoError(err)
+				assert.True(mockCommandRunner.HasCommand(expected[0], expected[1:]...), "got %v", mockCommandRunner.CommandCall)
+			},
+			Entry("npm", (*testutil.RootCommandFactory).CreateNpmAsDefault, "cowsay", []string{"npx", "--quiet", "cowsay", "--version"}),
+			Entry("pnpm", (*testutil.RootCommandFactory).CreatePnpmAsDefault, "cowsay", []string{"pnpm", "dlx", "--silent", "cowsay", "--version"}),
+			Entry("yarn v1", (*testutil.RootCommandFactory).CreateYarnOneAsDefault, "cowsay", []string{"yarn", "--silent", "cowsay", "--version"}),
+			Entry("yarn v2+", (*testutil.RootCommandFactory).CreateYarnTwoAsDefault, "cowsay", []string{"yarn", "dlx", "--quiet", "cowsay", "--version"}),
+			Entry("bun", (*testutil.RootCommandFactory).CreateBunAsDefault, "cowsay", []string{"bunx", "--silent", "cowsay", "--version"}),
+			Entry("deno", (*testutil.RootCommandFactory).CreateDenoAsDefault, "https://deno.land/std/examples/welcome.ts", []string{"deno", "run", "--quiet", "https://deno.land/std/examples/welcome.ts", "--version"}),
+		)
+
 		Context("Error Handling", func() {
 			It("should return error when command runner fails", func() {
 				// Set up expectations for npm lockfile detection
@@ -1101,6 +1149,211 @@ var _ = Describe("JPD Commands", func() {
 			assert.NotNil(flag)
 		})
 
+		It("should have exact flag", func() {
+			flag := installCmd.Flag("exact")
+			assert.NotNil(flag)
+			assert.Equal("E", flag.Shorthand)
+		})
+
+		// BuildInstallCommand Unit Tests
+		Describe("BuildInstallCommand function tests", func() {
+			Describe("npm install command", func() {
+				It("should build npm install without packages", func() {
+					program, args, err := cmd.BuildInstallCommand("npm", "", nil, cmd.InstallOptions{})
+					assert.NoError(err)
+					assert.Equal("npm", program)
+					assert.Equal([]string{"install"}, args)
+				})
+
+				It("should build npm install with dev and exact packages", func() {
+					program, args, err := cmd.BuildInstallCommand("npm", "", []string{"vitest"}, cmd.InstallOptions{Dev: true, SaveExact: true})
+					assert.NoError(err)
+					assert.Equal("npm", program)
+					assert.Equal([]string{"install", "vitest", "--save-dev", "--save-exact"}, args)
+				})
+
+				It("should build npm install with global, production and frozen", func() {
+					_, args, err := cmd.BuildInstallCommand("npm", "", nil, cmd.InstallOptions{Global: true, Production: true, Frozen: true})
+					assert.NoError(err)
+					assert.Equal([]string{"install", "--global", "--omit=dev", "--package-lock-only"}, args)
+				})
+			})
+
+			Describe("pnpm install command", func() {
+				It("should build pnpm install without packages", func() {
+					program, args, err := cmd.BuildInstallCommand("pnpm", "", nil, cmd.InstallOptions{Production: true, Frozen: true})
+					assert.NoError(err)
+					assert.Equal("pnpm", program)
+					assert.Equal([]string{"install", "--prod", "--frozen-lockfile"}, args)
+				})
+
+				It("should build pnpm add with dev and exact packages", func() {
+					_, args, err := cmd.BuildInstallCommand("pnpm", "", []string{"vitest"}, cmd.InstallOptions{Dev: true, SaveExact: true})
+					assert.NoError(err)
+					assert.Equal([]string{"add", "vitest", "--save-dev", "--save-exact"}, args)
+				})
+
+				It("should build pnpm add globally", func() {
+					_, args, err := cmd.BuildInstallCommand("pnpm", "", []string{"typescript"}, cmd.InstallOptions{Global: true})
+					assert.NoError(err)
+					assert.Equal([]string{"add", "typescript", "--global"}, args)
+				})
+			})
+
+			Describe("yarn install command", func() {
+				It("should build yarn install with production and frozen", func() {
+					program, args, err := cmd.BuildInstallCommand("yarn", "1.22.19", nil, cmd.InstallOptions{Production: true, Frozen: true})
+					assert.NoError(err)
+					assert.Equal("yarn", program)
+					assert.Equal([]string{"install", "--production", "--frozen-lockfile"}, args)
+				})
+
+				It("should build yarn add with dev and exact packages", func() {
+					_, args, err := cmd.BuildInstallCommand("yarn", "4.1.0", []string{"vitest"}, cmd.InstallOptions{Dev: true, SaveExact: true})
+					assert.NoError(err)
+					assert.Equal([]string{"add", "vitest", "--dev", "--exact"}, args)
+				})
+
+				It("should build yarn add globally", func() {
+					_, args, err := cmd.BuildInstallCommand("yarn", "1.22.19", []string{"typescript"}, cmd.InstallOptions{Global: true})
+					assert.NoError(err)
+					assert.Equal([]string{"add", "typescript", "--global"}, args)
+				})
+			})
+
+			Describe("bun install command", func() {
+				It("should build bun install with production", func() {
+					program, args, err := cmd.BuildInstallCommand("bun", "", nil, cmd.InstallOptions{Production: true})
+					assert.NoError(err)
+					assert.Equal("bun", program)
+					assert.Equal([]string{"install", "--production"}, args)
+				})
+
+				It("should build bun add with dev and exact packages", func() {
+					_, args, err := cmd.BuildInstallCommand("bun", "", []string{"vitest"}, cmd.InstallOptions{Dev: true, SaveExact: true})
+					assert.NoError(err)
+					assert.Equal([]string{"add", "vitest", "--development", "--exact"}, args)
+				})
+
+				It("should ignore frozen since bun install has no equivalent", func() {
+					_, args, err := cmd.BuildInstallCommand("bun", "", []string{"vitest"}, cmd.InstallOptions{Frozen: true})
+					assert.NoError(err)
+					assert.Equal([]string{"add", "vitest"}, args)
+				})
+			})
+
+			Describe("deno install command", func() {
+				It("should build deno add with dev packages", func() {
+					program, args, err := cmd.BuildInstallCommand("deno", "", []string{"npm:vitest"}, cmd.InstallOptions{Dev: true})
+					assert.NoError(err)
+					assert.Equal("deno", program)
+					assert.Equal([]string{"add", "npm:vitest", "--dev"}, args)
+				})
+
+				It("should build deno install for global packages", func() {
+					_, args, err := cmd.BuildInstallCommand("deno", "", []string{"npm:cowsay"}, cmd.InstallOptions{Global: true})
+					assert.NoError(err)
+					assert.Equal([]string{"install", "--global", "npm:cowsay"}, args)
+				})
+
+				It("should put -n and the name before the module of a global install", func() {
+					_, args, err := cmd.BuildInstallCommand("deno", "", []string{"https://deno.land/std/http/file_server.ts"}, cmd.InstallOptions{Global: true, Name: "mytool"})
+					assert.NoError(err)
+					assert.Equal([]string{"install", "--global", "-n", "mytool", "https://deno.land/std/http/file_server.ts"}, args)
+				})
+
+				It("should return an error when a name is given to more than one module", func() {
+					_, _, err := cmd.BuildInstallCommand("deno", "", []string{"npm:cowsay", "npm:lolcatjs"}, cmd.InstallOptions{Global: true, Name: "mytool"})
+					assert.ErrorContains(err, "deno names one global install at a time, got 2 packages")
+				})
+
+				It("should return an error when a name is given to the other package managers", func() {
+					_, _, err := cmd.BuildInstallCommand("npm", "", []string{"cowsay"}, cmd.InstallOptions{Global: true, Name: "mytool"})
+					assert.ErrorContains(err, "only deno install --global takes a name, npm can't use one")
+				})
+
+				It("should return error when no packages are provided", func() {
+					_, _, err := cmd.BuildInstallCommand("deno", "", nil, cmd.InstallOptions{})
+					assert.Error(err)
+					assert.Contains(err.Error(), "for deno one or more packages is required")
+				})
+
+				It("should return error for production", func() {
+					_, _, err := cmd.BuildInstallCommand("deno", "", []string{"npm:vitest"}, cmd.InstallOptions{Production: true})
+					assert.Error(err)
+					assert.Contains(err.Error(), "deno doesn't support prod")
+				})
+
+				It("should return error for exact", func() {
+					_, _, err := cmd.BuildInstallCommand("deno", "", []string{"npm:vitest"}, cmd.InstallOptions{SaveExact: true})
+					assert.Error(err)
+					assert.Contains(err.Error(), "deno doesn't support exact installs")
+				})
+			})
+
+			Describe("lockfile only", func() {
+				DescribeTable("should update only the lockfile",
+					func(pm, yarnVersion string, expected []string) {
+						_, args, err := cmd.BuildInstallCommand(pm, yarnVersion, nil, cmd.InstallOptions{LockfileOnly: true})
+						assert.NoError(err)
+						assert.Equal(expected, args)
+					},
+					Entry("npm", "npm", "", []string{"install", "--package-lock-only"}),
+					Entry("pnpm", "pnpm", "", []string{"install", "--lockfile-only"}),
+					Entry("yarn v2+", "yarn", "4.1.0", []string{"install", "--mode=update-lockfile"}),
+					Entry("bun", "bun", "", []string{"install", "--lockfile-only"}),
+				)
+
+				It("should return error for yarn v1", func() {
+					_, _, err := cmd.BuildInstallCommand("yarn", "1.22.19", nil, cmd.InstallOptions{LockfileOnly: true})
+					assert.ErrorContains(err, "yarn v1 doesn't support lockfile only installs")
+				})
+
+				It("should return error for deno", func() {
+					_, _, err := cmd.BuildInstallCommand("deno", "", []string{"npm:vitest"}, cmd.InstallOptions{LockfileOnly: true})
+					assert.ErrorContains(err, "deno doesn't support lockfile only installs")
+				})
+			})
+
+			Describe("workspace root", func() {
+				It("should pass -w to pnpm", func() {
+					_, args, err := cmd.BuildInstallCommand("pnpm", "", []string{"lodash"}, cmd.InstallOptions{WorkspaceRoot: true})
+					assert.NoError(err)
+					assert.Equal([]string{"add", "lodash", "-w"}, args)
+				})
+
+				It("should pass -W to yarn v1", func() {
+					_, args, err := cmd.BuildInstallCommand("yarn", "1.22.19", []string{"lodash"}, cmd.InstallOptions{WorkspaceRoot: true})
+					assert.NoError(err)
+					assert.Equal([]string{"add", "lodash", "-W"}, args)
+				})
+
+				It("should leave yarn v2+ untouched", func() {
+					_, args, err := cmd.BuildInstallCommand("yarn", "4.1.0", []string{"lodash"}, cmd.InstallOptions{WorkspaceRoot: true})
+					assert.NoError(err)
+					assert.Equal([]string{"add", "lodash"}, args)
+				})
+
+				It("should leave npm untouched since it installs at the root by default", func() {
+					_, args, err := cmd.BuildInstallCommand("npm", "", []string{"lodash"}, cmd.InstallOptions{WorkspaceRoot: true})
+					assert.NoError(err)
+					assert.Equal([]string{"install", "lodash"}, args)
+				})
+
+				It("should return error for deno", func() {
+					_, _, err := cmd.BuildInstallCommand("deno", "", []string{"npm:lodash"}, cmd.InstallOptions{WorkspaceRoot: true})
+					assert.Error(err)
+					assert.Contains(err.Error(), "deno doesn't support workspace root installs")
+				})
+			})
+
+			It("should return error for unsupported package manager", func() {
+				_, _, err := cmd.BuildInstallCommand("unknown", "", nil, cmd.InstallOptions{})
+				assert.Error(err)
+				assert.Contains(err.Error(), "unsupported package manager: unknown")
+			})
+		})
+
 		Context("Volta", func() {
 			DescribeTable(
 				"Appends volta run when a node package manager is the agent",
@@ -1408,6 +1661,21 @@ var _ = Describe("JPD Commands", func() {
 				assert.Contains(mockCommandRunner.CommandCall.Args, "--frozen-lockfile")
 			})
 
+			It("should handle lockfile-only flag with pnpm", func() {
+				DebugExecutorExpectationManager.ExpectLockfileDetected(detect.PNPM_LOCK_YAML)
+				DebugExecutorExpectationManager.ExpectPMDetectedFromLockfile(detect.PNPM)
+				DebugExecutorExpectationManager.ExpectJSCommandLog("pnpm", "install", "--lockfile-only")
+				_, err := executeCmd(pnpmRootCmd, "install", "--lockfile-only")
+				assert.NoError(err)
+				assert.True(mockCommandRunner.HasCommand("pnpm", "install", "--lockfile-only"))
+			})
+
+			It("should not combine --lockfile-only with --frozen", func() {
+				_, err := executeCmd(pnpmRootCmd, "install", "--lockfile-only", "--frozen")
+				assert.ErrorContains(err, "none of the others can be")
+				assert.False(mockCommandRunner.HasBeenCalled)
+			})
+
 			It("should handle pnpm with dev dependencies", func() {
 				DebugExecutorExpectationManager.ExpectLockfileDetected(detect.PNPM_LOCK_YAML)
 				DebugExecutorExpectationManager.ExpectPMDetectedFromLockfile(detect.PNPM)
@@ -1504,10 +1772,50 @@ var _ = Describe("JPD Commands", func() {
 			It("should execute deno install with --global flag and packages", func() {
 				DebugExecutorExpectationManager.ExpectLockfileDetected(detect.DENO_JSON)
 				DebugExecutorExpectationManager.ExpectPMDetectedFromLockfile(detect.DENO)
-				DebugExecutorExpectationManager.ExpectJSCommandLog("deno", "install", "my-global-tool")
+				DebugExecutorExpectationManager.ExpectJSCommandLog("deno", "install", "--global", "my-global-tool")
 				_, err := executeCmd(denoRootCmd, "install", "--global", "my-global-tool")
 				assert.NoError(err)
-				assert.True(mockCommandRunner.HasCommand("deno", "install", "my-global-tool"))
+				assert.True(mockCommandRunner.HasCommand("deno", "install", "--global", "my-global-tool"))
+			})
+
+			It("should forward the permission flags after the package of a global install", func() {
+				DebugExecutorExpectationManager.ExpectLockfileDetected(detect.DENO_JSON)
+				DebugExecutorExpectationManager.ExpectPMDetectedFromLockfile(detect.DENO)
+				_, err := executeCmd(denoRootCmd, "install", "--global", "--agent", "deno", "my-tool", "--allow-net")
+				assert.NoError(err)
+				assert.True(mockCommandRunner.HasCommand("deno", "install", "--global", "my-tool", "--allow-net"), "got %v", mockCommandRunner.CommandCall)
+			})
+
+			It("should keep the lists of the permission flags and name the executable", func() {
+				DebugExecutorExpectationManager.ExpectLockfileDetected(detect.DENO_JSON)
+				DebugExecutorExpectationManager.ExpectPMDetectedFromLockfile(detect.DENO)
+				_, err := executeCmd(denoRootCmd, "install", "-g", "-n", "serve", "jsr:@std/http/file-server", "--allow-read=.", "-A")
+				assert.NoError(err)
+				assert.True(mockCommandRunner.HasCommand("deno", "install", "--global", "-n", "serve", "jsr:@std/http/file-server", "--allow-all", "--allow-read=."), "got %v", mockCommandRunner.CommandCall)
+			})
+
+			It("should pass -n for a named global install", func() {
+				DebugExecutorExpectationManager.ExpectLockfileDetected(detect.DENO_JSON)
+				DebugExecutorExpectationManager.ExpectPMDetectedFromLockfile(detect.DENO)
+				_, err := executeCmd(denoRootCmd, "install", "--global", "--name", "mytool", "https://deno.land/std/http/file_server.ts")
+				assert.NoError(err)
+				assert.True(mockCommandRunner.HasCommand("deno", "install", "--global", "-n", "mytool", "https://deno.land/std/http/file_server.ts"), "got %v", mockCommandRunner.CommandCall)
+			})
+
+			It("should return an error when --name is used without --global", func() {
+				DebugExecutorExpectationManager.ExpectLockfileDetected(detect.DENO_JSON)
+				DebugExecutorExpectationManager.ExpectPMDetectedFromLockfile(detect.DENO)
+				_, err := executeCmd(denoRootCmd, "install", "--name", "mytool", "npm:cowsay")
+				assert.ErrorContains(err, "the --name flag names a global install, it requires --global")
+				assert.False(mockCommandRunner.HasBeenCalled)
+			})
+
+			It("should return an error when the permission flags are used without --global", func() {
+				DebugExecutorExpectationManager.ExpectLockfileDetected(detect.DENO_JSON)
+				DebugExecutorExpectationManager.ExpectPMDetectedFromLockfile(detect.DENO)
+				_, err := executeCmd(denoRootCmd, "install", "my-package", "--allow-net")
+				assert.ErrorContains(err, "--allow-net only works with --global on deno")
+				assert.False(mockCommandRunner.HasBeenCalled)
 			})
 
 			It("should return an error when --production flag is used", func() {
@@ -1588,6 +1896,379 @@ var _ = Describe("JPD Commands", func() {
 			assert.True(factory.MockCommandRunner().HasCommand("npm", "install", "tsup", "--save-dev"))
 			assert.Equal(tmpDir, factory.MockCommandRunner().WorkingDir)
 		})
+
+		Context("--registry", func() {
+			const registry = "https://npm.example.com"
+
+			It("appends --registry for npm alongside --dev", func() {
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.NPM, detect.PACKAGE_LOCK_JSON)
+				DebugExecutorExpectationManager.ExpectJSCommandLog("npm", "install", "@acme/ui", "--save-dev", "--registry", registry)
+				_, err := executeCmd(rootCmd, "install", "--dev", "--registry", registry, "@acme/ui")
+				assert.NoError(err)
+				assert.True(mockCommandRunner.HasCommand("npm", "install", "@acme/ui", "--save-dev", "--registry", registry))
+			})
+
+			It("appends --registry for pnpm alongside --dev", func() {
+				pnpmRootCmd := factory.CreatePnpmAsDefault(nil)
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.PNPM, detect.PNPM_LOCK_YAML)
+				DebugExecutorExpectationManager.ExpectJSCommandLog("pnpm", "add", "@acme/ui", "--save-dev", "--registry", registry)
+				_, err := executeCmd(pnpmRootCmd, "install", "--dev", "--registry", registry, "@acme/ui")
+				assert.NoError(err)
+				assert.True(mockCommandRunner.HasCommand("pnpm", "add", "@acme/ui", "--save-dev", "--registry", registry))
+			})
+
+			It("appends --registry for yarn v1 alongside --dev", func() {
+				yarnRootCmd := factory.CreateYarnOneAsDefault(nil)
+				DebugExecutorExpectationManager.ExpectCommonPathDetectionFlow(detect.YARN)
+				DebugExecutorExpectationManager.ExpectJSCommandLog("yarn", "add", "@acme/ui", "--dev", "--registry", registry)
+				_, err := executeCmd(yarnRootCmd, "install", "--dev", "--registry", registry, "@acme/ui")
+				assert.NoError(err)
+				assert.True(mockCommandRunner.HasCommand("yarn", "add", "@acme/ui", "--dev", "--registry", registry))
+			})
+
+			It("prints an npmRegistryServer note for yarn v2+ instead of passing --registry", func() {
+				yarnRootCmd := factory.CreateYarnTwoAsDefault(nil)
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.YARN, detect.YARN_LOCK)
+				DebugExecutorExpectationManager.ExpectJSCommandLog("yarn", "add", "@acme/ui", "--dev")
+				output, err := executeCmd(yarnRootCmd, "install", "--dev", "--registry", registry, "@acme/ui")
+				assert.NoError(err)
+				assert.Contains(output, "npmRegistryServer")
+				assert.True(mockCommandRunner.HasCommand("yarn", "add", "@acme/ui", "--dev"))
+			})
+
+			It("appends --registry for bun alongside --dev", func() {
+				bunRootCmd := factory.CreateBunAsDefault(nil)
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.BUN, detect.BUN_LOCKB)
+				DebugExecutorExpectationManager.ExpectJSCommandLog("bun", "add", "@acme/ui", "--development", "--registry", registry)
+				_, err := executeCmd(bunRootCmd, "install", "--dev", "--registry", registry, "@acme/ui")
+				assert.NoError(err)
+				assert.True(mockCommandRunner.HasCommand("bun", "add", "@acme/ui", "--development", "--registry", registry))
+			})
+
+			It("returns an error for deno", func() {
+				denoRootCmd := factory.CreateDenoAsDefault(nil)
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.DENO, detect.DENO_JSON)
+				_, err := executeCmd(denoRootCmd, "install", "--registry", registry, "@std/path")
+				assert.Error(err)
+				assert.Contains(err.Error(), "deno does not support the --registry flag")
+				assert.False(mockCommandRunner.HasBeenCalled)
+			})
+
+			It("rejects a registry that is not a URL", func() {
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.NPM, detect.PACKAGE_LOCK_JSON)
+				_, err := executeCmd(rootCmd, "install", "--registry", "npm.example.com", "@acme/ui")
+				assert.Error(err)
+				assert.Contains(err.Error(), "must be an http or https URL")
+			})
+		})
+
+		Context("--node-linker", func() {
+			DescribeTable("translates the linker for pnpm",
+				func(linker string) {
+					pnpmRootCmd := factory.CreatePnpmAsDefault(nil)
+					DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.PNPM, detect.PNPM_LOCK_YAML)
+					DebugExecutorExpectationManager.ExpectJSCommandLog("pnpm", "install", "--config.node-linker="+linker)
+					_, err := executeCmd(pnpmRootCmd, "install", "--node-linker", linker)
+					assert.NoError(err)
+					assert.True(mockCommandRunner.HasCommand("pnpm", "install", "--config.node-linker="+linker))
+				},
+				Entry("isolated", "isolated"),
+				Entry("hoisted", "hoisted"),
+				Entry("pnp", "pnp"),
+			)
+
+			DescribeTable("sets YARN_NODE_LINKER with a note for yarn v2+",
+				func(linker, yarnLinker string) {
+					yarnRootCmd := factory.CreateYarnTwoAsDefault(nil)
+					DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.YARN, detect.YARN_LOCK)
+					DebugExecutorExpectationManager.ExpectJSCommandLog("yarn", "install")
+					output, err := executeCmd(yarnRootCmd, "install", "--node-linker", linker)
+					assert.NoError(err)
+					assert.Contains(output, "--node-linker is passed as YARN_NODE_LINKER")
+					assert.True(mockCommandRunner.HasCommand("yarn", "install"))
+					assert.Equal([]string{"YARN_NODE_LINKER=" + yarnLinker}, mockCommandRunner.Env)
+				},
+				Entry("isolated", "isolated", "pnpm"),
+				Entry("hoisted", "hoisted", "node-modules"),
+				Entry("pnp", "pnp", "pnp"),
+			)
+
+			It("returns an error for yarn v1", func() {
+				yarnRootCmd := factory.CreateYarnOneAsDefault(nil)
+				DebugExecutorExpectationManager.ExpectCommonPathDetectionFlow(detect.YARN)
+				_, err := executeCmd(yarnRootCmd, "install", "--node-linker", "hoisted")
+				assert.Error(err)
+				assert.Contains(err.Error(), "requires yarn v2 or newer")
+				assert.False(mockCommandRunner.HasBeenCalled)
+			})
+
+			It("returns an error for npm", func() {
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.NPM, detect.PACKAGE_LOCK_JSON)
+				_, err := executeCmd(rootCmd, "install", "--node-linker", "hoisted")
+				assert.Error(err)
+				assert.Contains(err.Error(), "npm does not support the --node-linker flag")
+				assert.False(mockCommandRunner.HasBeenCalled)
+			})
+
+			It("rejects an unknown linker", func() {
+				pnpmRootCmd := factory.CreatePnpmAsDefault(nil)
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.PNPM, detect.PNPM_LOCK_YAML)
+				_, err := executeCmd(pnpmRootCmd, "install", "--node-linker", "flat")
+				assert.Error(err)
+				assert.Contains(err.Error(), "the --node-linker flag must be one of")
+				assert.False(mockCommandRunner.HasBeenCalled)
+			})
+		})
+
+		Context("--hoist", func() {
+			DescribeTable("appends --shamefully-hoist for pnpm",
+				func(flag string) {
+					pnpmRootCmd := factory.CreatePnpmAsDefault(nil)
+					DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.PNPM, detect.PNPM_LOCK_YAML)
+					DebugExecutorExpectationManager.ExpectJSCommandLog("pnpm", "install", "--shamefully-hoist")
+					_, err := executeCmd(pnpmRootCmd, "install", flag)
+					assert.NoError(err)
+					assert.True(mockCommandRunner.HasCommand("pnpm", "install", "--shamefully-hoist"))
+				},
+				Entry("--hoist", "--hoist"),
+				Entry("--shamefully-hoist", "--shamefully-hoist"),
+			)
+
+			It("prints a note and installs as usual for npm", func() {
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.NPM, detect.PACKAGE_LOCK_JSON)
+				DebugExecutorExpectationManager.ExpectJSCommandLog("npm", "install", "lodash")
+				output, err := executeCmd(rootCmd, "install", "lodash", "--hoist")
+				assert.NoError(err)
+				assert.Contains(output, "Note: npm hoists dependencies by default, --hoist is ignored")
+				assert.True(mockCommandRunner.HasCommand("npm", "install", "lodash"))
+			})
+
+			It("prints a note and installs as usual for bun", func() {
+				bunRootCmd := factory.CreateBunAsDefault(nil)
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.BUN, detect.BUN_LOCKB)
+				DebugExecutorExpectationManager.ExpectJSCommandLog("bun", "install")
+				output, err := executeCmd(bunRootCmd, "install", "--hoist")
+				assert.NoError(err)
+				assert.Contains(output, "Note: bun hoists dependencies by default, --hoist is ignored")
+				assert.True(mockCommandRunner.HasCommand("bun", "install"))
+			})
+
+			It("returns an error for deno", func() {
+				denoRootCmd := factory.CreateDenoAsDefault(nil)
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.DENO, detect.DENO_JSON)
+				_, err := executeCmd(denoRootCmd, "install", "--hoist")
+				assert.Error(err)
+				assert.Contains(err.Error(), "deno does not support the --hoist flag")
+				assert.False(mockCommandRunner.HasBeenCalled)
+			})
+		})
+
+		Context("--max-sockets", func() {
+			It("appends --maxsockets for npm", func() {
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.NPM, detect.PACKAGE_LOCK_JSON)
+				DebugExecutorExpectationManager.ExpectJSCommandLog("npm", "install", "lodash", "--maxsockets", "5")
+				_, err := executeCmd(rootCmd, "install", "lodash", "--max-sockets", "5")
+				assert.NoError(err)
+				assert.True(mockCommandRunner.HasCommand("npm", "install", "lodash", "--maxsockets", "5"))
+			})
+
+			It("appends --network-concurrency for pnpm", func() {
+				pnpmRootCmd := factory.CreatePnpmAsDefault(nil)
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.PNPM, detect.PNPM_LOCK_YAML)
+				DebugExecutorExpectationManager.ExpectJSCommandLog("pnpm", "install", "--network-concurrency", "5")
+				_, err := executeCmd(pnpmRootCmd, "install", "--max-sockets", "5")
+				assert.NoError(err)
+				assert.True(mockCommandRunner.HasCommand("pnpm", "install", "--network-concurrency", "5"))
+			})
+
+			It("returns an error for yarn v2+", func() {
+				yarnRootCmd := factory.CreateYarnTwoAsDefault(nil)
+				_, err := executeCmd(yarnRootCmd, "install", "--max-sockets", "5")
+				assert.ErrorContains(err, "yarn v2+ reads networkConcurrency from .yarnrc.yml")
+				assert.False(mockCommandRunner.HasBeenCalled)
+			})
+
+			It("rejects a value that is not an integer", func() {
+				_, err := executeCmd(rootCmd, "install", "--max-sockets", "five")
+				assert.ErrorContains(err, `invalid argument "five" for "--max-sockets"`)
+				assert.False(mockCommandRunner.HasBeenCalled)
+			})
+
+			It("rejects a value below 1", func() {
+				_, err := executeCmd(rootCmd, "install", "--max-sockets", "0")
+				assert.ErrorContains(err, "the --max-sockets flag must be greater than 0, got: 0")
+				assert.False(mockCommandRunner.HasBeenCalled)
+			})
+		})
+
+		Context("--store-dir", func() {
+			var storeDir string
+
+			BeforeEach(func() {
+				storeDir = filepath.Join(GinkgoT().TempDir(), "store")
+			})
+
+			It("appends --cache for npm", func() {
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.NPM, detect.PACKAGE_LOCK_JSON)
+				DebugExecutorExpectationManager.ExpectJSCommandLog("npm", "install", "--cache", storeDir)
+				_, err := executeCmd(rootCmd, "install", "--store-dir", storeDir)
+				assert.NoError(err)
+				assert.True(mockCommandRunner.HasCommand("npm", "install", "--cache", storeDir))
+			})
+
+			It("appends --store-dir for pnpm", func() {
+				pnpmRootCmd := factory.CreatePnpmAsDefault(nil)
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.PNPM, detect.PNPM_LOCK_YAML)
+				DebugExecutorExpectationManager.ExpectJSCommandLog("pnpm", "install", "--store-dir", storeDir)
+				_, err := executeCmd(pnpmRootCmd, "install", "--store-dir", storeDir)
+				assert.NoError(err)
+				assert.True(mockCommandRunner.HasCommand("pnpm", "install", "--store-dir", storeDir))
+			})
+
+			It("appends --cache-dir for bun and accepts --cache-dir", func() {
+				bunRootCmd := factory.CreateBunAsDefault(nil)
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.BUN, detect.BUN_LOCKB)
+				DebugExecutorExpectationManager.ExpectJSCommandLog("bun", "install", "--cache-dir", storeDir)
+				_, err := executeCmd(bunRootCmd, "install", "--cache-dir", storeDir)
+				assert.NoError(err)
+				assert.True(mockCommandRunner.HasCommand("bun", "install", "--cache-dir", storeDir))
+			})
+
+			It("appends --cache-folder for yarn v1", func() {
+				yarnRootCmd := factory.CreateYarnOneAsDefault(nil)
+				DebugExecutorExpectationManager.ExpectCommonPathDetectionFlow(detect.YARN)
+				DebugExecutorExpectationManager.ExpectJSCommandLog("yarn", "install", "--cache-folder", storeDir)
+				_, err := executeCmd(yarnRootCmd, "install", "--store-dir", storeDir)
+				assert.NoError(err)
+				assert.True(mockCommandRunner.HasCommand("yarn", "install", "--cache-folder", storeDir))
+			})
+
+			It("sets YARN_CACHE_FOLDER with a note for yarn v2+", func() {
+				yarnRootCmd := factory.CreateYarnTwoAsDefault(nil)
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.YARN, detect.YARN_LOCK)
+				DebugExecutorExpectationManager.ExpectJSCommandLog("yarn", "install")
+				output, err := executeCmd(yarnRootCmd, "install", "--store-dir", storeDir)
+				assert.NoError(err)
+				assert.Contains(output, "--store-dir is passed as YARN_CACHE_FOLDER")
+				assert.True(mockCommandRunner.HasCommand("yarn", "install"))
+				assert.Equal([]string{"YARN_CACHE_FOLDER=" + storeDir}, mockCommandRunner.Env)
+			})
+
+			It("sets YARN_CACHE_FOLDER next to YARN_NODE_LINKER for yarn v2+", func() {
+				yarnRootCmd := factory.CreateYarnTwoAsDefault(nil)
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.YARN, detect.YARN_LOCK)
+				DebugExecutorExpectationManager.ExpectJSCommandLog("yarn", "install")
+				_, err := executeCmd(yarnRootCmd, "install", "--store-dir", storeDir, "--node-linker", "hoisted")
+				assert.NoError(err)
+				assert.Equal([]string{"YARN_NODE_LINKER=node-modules", "YARN_CACHE_FOLDER=" + storeDir}, mockCommandRunner.Env)
+			})
+
+			It("resolves a relative path from --cwd", func() {
+				projectDir := GinkgoT().TempDir()
+				pnpmRootCmd := factory.CreatePnpmAsDefault(nil)
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.PNPM, detect.PNPM_LOCK_YAML)
+				DebugExecutorExpectationManager.ExpectJSCommandLog("pnpm", "install", "--store-dir", filepath.Join(projectDir, ".pnpm-store"))
+				_, err := executeCmd(pnpmRootCmd, "--cwd", projectDir+"/", "install", "--store-dir", ".pnpm-store")
+				assert.NoError(err)
+				assert.True(mockCommandRunner.HasCommand("pnpm", "install", "--store-dir", filepath.Join(projectDir, ".pnpm-store")))
+			})
+
+			It("rejects a path that is a file", func() {
+				file := filepath.Join(GinkgoT().TempDir(), "store")
+				assert.NoError(os.WriteFile(file, []byte(""), 0o644))
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.NPM, detect.PACKAGE_LOCK_JSON)
+				_, err := executeCmd(rootCmd, "install", "--store-dir", file)
+				assert.ErrorContains(err, "the --store-dir flag must be a directory, got a file")
+				assert.False(mockCommandRunner.HasBeenCalled)
+			})
+
+			It("rejects an empty path", func() {
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.NPM, detect.PACKAGE_LOCK_JSON)
+				_, err := executeCmd(rootCmd, "install", "--store-dir", "")
+				assert.ErrorContains(err, "the --store-dir flag requires a path")
+				assert.False(mockCommandRunner.HasBeenCalled)
+			})
+
+			It("returns an error for deno", func() {
+				denoRootCmd := factory.CreateDenoAsDefault(nil)
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.DENO, detect.DENO_JSON)
+				_, err := executeCmd(denoRootCmd, "install", "--store-dir", storeDir)
+				assert.ErrorContains(err, "deno does not support the --store-dir flag")
+				assert.False(mockCommandRunner.HasBeenCalled)
+			})
+		})
+
+		Context("--save-prefix", func() {
+			It("forwards the prefix to npm", func() {
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.NPM, detect.PACKAGE_LOCK_JSON)
+				DebugExecutorExpectationManager.ExpectJSCommandLog("npm", "install", "lodash", "--save-prefix", "~")
+				_, err := executeCmd(rootCmd, "install", "lodash", "--save-prefix=~")
+				assert.NoError(err)
+				assert.True(mockCommandRunner.HasCommand("npm", "install", "lodash", "--save-prefix", "~"))
+			})
+
+			It("forwards the prefix to pnpm", func() {
+				pnpmRootCmd := factory.CreatePnpmAsDefault(nil)
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.PNPM, detect.PNPM_LOCK_YAML)
+				DebugExecutorExpectationManager.ExpectJSCommandLog("pnpm", "add", "lodash", "--save-prefix", "^")
+				_, err := executeCmd(pnpmRootCmd, "install", "lodash", "--save-prefix", "^")
+				assert.NoError(err)
+				assert.True(mockCommandRunner.HasCommand("pnpm", "add", "lodash", "--save-prefix", "^"))
+			})
+
+			It("saves exact versions with an empty prefix on npm", func() {
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.NPM, detect.PACKAGE_LOCK_JSON)
+				DebugExecutorExpectationManager.ExpectJSCommandLog("npm", "install", "lodash", "--save-exact")
+				_, err := executeCmd(rootCmd, "install", "lodash", "--save-prefix=")
+				assert.NoError(err)
+				assert.True(mockCommandRunner.HasCommand("npm", "install", "lodash", "--save-exact"))
+			})
+
+			It("saves exact versions with an empty prefix on pnpm", func() {
+				pnpmRootCmd := factory.CreatePnpmAsDefault(nil)
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.PNPM, detect.PNPM_LOCK_YAML)
+				DebugExecutorExpectationManager.ExpectJSCommandLog("pnpm", "add", "lodash", "--save-exact")
+				_, err := executeCmd(pnpmRootCmd, "install", "lodash", "--save-prefix", "")
+				assert.NoError(err)
+				assert.True(mockCommandRunner.HasCommand("pnpm", "add", "lodash", "--save-exact"))
+			})
+
+			It("uses --tilde on yarn v1", func() {
+				yarnRootCmd := factory.CreateYarnOneAsDefault(nil)
+				DebugExecutorExpectationManager.ExpectCommonPathDetectionFlow(detect.YARN)
+				DebugExecutorExpectationManager.ExpectJSCommandLog("yarn", "add", "lodash", "--tilde")
+				_, err := executeCmd(yarnRootCmd, "install", "lodash", "--save-prefix", "~")
+				assert.NoError(err)
+				assert.True(mockCommandRunner.HasCommand("yarn", "add", "lodash", "--tilde"))
+			})
+
+			It("prints a note on yarn v2+", func() {
+				yarnRootCmd := factory.CreateYarnTwoAsDefault(nil)
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.YARN, detect.YARN_LOCK)
+				DebugExecutorExpectationManager.ExpectJSCommandLog("yarn", "add", "lodash")
+				output, err := executeCmd(yarnRootCmd, "install", "lodash", "--save-prefix", "~")
+				assert.NoError(err)
+				assert.Contains(output, "defaultSemverRangePrefix")
+				assert.True(mockCommandRunner.HasCommand("yarn", "add", "lodash"))
+			})
+
+			It("returns an error for bun", func() {
+				bunRootCmd := factory.CreateBunAsDefault(nil)
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.BUN, detect.BUN_LOCKB)
+				_, err := executeCmd(bunRootCmd, "install", "lodash", "--save-prefix", "~")
+				assert.Error(err)
+				assert.Contains(err.Error(), "bun does not support the --save-prefix flag")
+				assert.False(mockCommandRunner.HasBeenCalled)
+			})
+
+			It("rejects an unknown prefix", func() {
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.NPM, detect.PACKAGE_LOCK_JSON)
+				_, err := executeCmd(rootCmd, "install", "lodash", "--save-prefix", ">=")
+				assert.Error(err)
+				assert.Contains(err.Error(), "the --save-prefix flag must be one of")
+				assert.False(mockCommandRunner.HasBeenCalled)
+			})
+		})
 	})
 
 	const CreateCommand = "Create Command"
@@ -1659,6 +2340,20 @@ var _ = Describe("JPD Commands", func() {
 					assert.Equal([]string{"create", "@org/starter", "--", "my-app"}, args)
 				})
 
+				It("should collapse user-provided -- separators for npm", func() {
+					program, args, err := cmd.BuildCreateCommand("npm", "", "vite@latest", []string{"my-app", "--", "--", "--template", "react"})
+					assert.NoError(err)
+					assert.Equal("npm", program)
+					assert.Equal([]string{"create", "vite@latest", "--", "my-app", "--template", "react"}, args)
+				})
+
+				It("should forward npm arguments untouched when built verbatim", func() {
+					program, args, err := cmd.BuildCreateCommandVerbatim("npm", "", "vite@latest", []string{"my-app", "--", "--", "--template", "react"})
+					assert.NoError(err)
+					assert.Equal("npm", program)
+					assert.Equal([]string{"create", "vite@latest", "my-app", "--", "--", "--template", "react"}, args)
+				})
+
 				It("should return error when no name provided", func() {
 					_, _, err := cmd.BuildCreateCommand("npm", "", "", []string{})
 					assert.Error(err)
@@ -1750,6 +2445,192 @@ var _ = Describe("JPD Commands", func() {
 			})
 		})
 
+		Context("--cache-template", func() {
+			DescribeTable("ApplyCreateTemplateCache maps to the cache option of each package manager",
+				func(pm, yarnVersion string, argv, expected []string, supported bool) {
+					out, ok := cmd.ApplyCreateTemplateCache(pm, yarnVersion, argv)
+					assert.Equal(supported, ok)
+					assert.Equal(expected, out)
+				},
+				Entry("npm", "npm", "", []string{"create", "vite", "--", "app"}, []string{"create", "--prefer-offline", "vite", "--", "app"}, true),
+				Entry("pnpm", "pnpm", "", []string{"create", "vite", "app"}, []string{"create", "--prefer-offline", "vite", "app"}, true),
+				Entry("yarn v1", "yarn", "1.22.19", []string{"create", "vite", "app"}, []string{"create", "--prefer-offline", "vite", "app"}, true),
+				Entry("yarn v2+", "yarn", "4.1.0", []string{"create", "vite", "app"}, []string{"create", "vite", "app"}, false),
+				Entry("bun", "bun", "", []string{"create", "vite", "app"}, []string{"create", "vite", "app"}, false),
+				Entry("deno", "deno", "", []string{"run", "https://deno.land/x/fresh/init.ts"}, []string{"run", "https://deno.land/x/fresh/init.ts"}, false),
+			)
+
+			It("forwards the cache option to npm", func() {
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.NPM, detect.PACKAGE_LOCK_JSON)
+				DebugExecutorExpectationManager.ExpectJSCommandLog("npm", "create", "--prefer-offline", "vite", "--", "my-app")
+				_, err := executeCmd(rootCmd, "create", "--cache-template", "vite", "my-app")
+				assert.NoError(err)
+				assert.True(mockCommandRunner.HasCommand("npm", "create", "--prefer-offline", "vite", "--", "my-app"))
+			})
+
+			It("prints a note for package managers without a template cache", func() {
+				bunRootCmd := factory.CreateBunAsDefault(nil)
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.BUN, detect.BUN_LOCKB)
+				DebugExecutorExpectationManager.ExpectJSCommandLog("bun", "create", "vite", "my-app")
+				output, err := executeCmd(bunRootCmd, "create", "vite", "my-app", "--cache-template")
+				assert.NoError(err)
+				assert.Contains(output, "bun has no cache option for create templates")
+				assert.True(mockCommandRunner.HasCommand("bun", "create", "vite", "my-app"))
+			})
+		})
+
+		Context("--list-templates", func() {
+			DescribeTable("LookupStarterTemplates finds the starter however it is written",
+				func(starter, flag string) {
+					templates, found := cmd.LookupStarterTemplates(starter)
+					assert.True(found)
+					assert.Equal(flag, templates.Flag)
+				},
+				Entry("vite", "vite", "--template"),
+				Entry("vite with a version", "vite@latest", "--template"),
+				Entry("create-next-app", "create-next-app", "--example"),
+				Entry("create-astro with a version", "create-astro@4.8.0", "--template"),
+			)
+
+			It("prints the templates of vite without scaffolding", func() {
+				output, err := executeCmd(rootCmd, "create", "vite@latest", "--list-templates")
+				assert.NoError(err)
+				assert.Contains(output, "Templates of vite@latest, pick one with --template:")
+				assert.Contains(output, "  react-ts\n")
+				assert.Contains(output, "  svelte-ts\n")
+				assert.False(mockCommandRunner.HasBeenCalled)
+			})
+
+			It("prints that the templates of an unknown starter are not known", func() {
+				output, err := executeCmd(rootCmd, "create", "--list-templates", "react-router")
+				assert.NoError(err)
+				assert.Contains(output, "templates not known for this starter: react-router")
+				assert.False(mockCommandRunner.HasBeenCalled)
+			})
+
+			It("requires a starter name", func() {
+				_, err := executeCmd(rootCmd, "create", "--list-templates")
+				assert.ErrorContains(err, "the --list-templates flag requires a starter name")
+			})
+		})
+
+		Context("target directory guard", func() {
+			var (
+				guardRunner  *mock.MockCommandRunner
+				guardFactory *testutil.RootCommandFactory
+				asked        []string
+			)
+
+			answering := func(answer bool) func(string) cmd.UIConfirmer {
+				return func(title string) cmd.UIConfirmer {
+					asked = append(asked, title)
+					return fakeConfirmUI{answer: answer}
+				}
+			}
+
+			BeforeEach(func() {
+				guardRunner = mock.NewMockCommandRunner()
+				guardFactory = testutil.NewRootCommandFactory(guardRunner)
+				guardFactory.SetupBasicCommandRunnerExpectations()
+				guardFactory.ResetDebugExecutor()
+				testutil.DebugExecutorExpectationManager.DebugExecutor = guardFactory.DebugExecutor()
+				guardFactory.SetupBasicDebugExecutorExpectations()
+				asked = nil
+
+				// Without --cwd the target directory is relative to the process
+				dir := GinkgoT().TempDir()
+				wd, err := os.Getwd()
+				assert.NoError(err)
+				assert.NoError(os.Chdir(dir))
+				GinkgoT().Cleanup(func() { _ = os.Chdir(wd) })
+
+				assert.NoError(os.MkdirAll(filepath.Join(dir, "my-app"), 0o755))
+				assert.NoError(os.WriteFile(filepath.Join(dir, "my-app", "index.html"), []byte("<h1>keep me</h1>"), 0o644))
+				assert.NoError(os.MkdirAll(filepath.Join(dir, "empty-app"), 0o755))
+			})
+
+			It("refuses a directory that isn't empty under --quiet without --force", func() {
+				_, err := executeCmd(guardFactory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+					deps.NewConfirmUI = answering(true)
+				}), "create", "--quiet", "vite", "my-app")
+				assert.ErrorContains(err, "my-app is not empty")
+				assert.ErrorContains(err, "pass --force to scaffold into it anyway")
+				assert.False(guardRunner.HasBeenCalled)
+				assert.Empty(asked)
+			})
+
+			It("scaffolds into a directory that isn't empty with --force", func() {
+				_, err := executeCmd(guardFactory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+					deps.NewConfirmUI = answering(false)
+				}), "create", "vite", "my-app", "--force")
+				assert.NoError(err)
+				assert.True(guardRunner.HasCommand("npm", "create", "vite", "--", "my-app"), "got %v", guardRunner.CommandCall)
+				assert.Empty(asked)
+			})
+
+			It("asks before scaffolding into a directory that isn't empty", func() {
+				_, err := executeCmd(guardFactory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+					deps.NewConfirmUI = answering(true)
+				}), "create", "vite", "my-app")
+				assert.NoError(err)
+				assert.Equal([]string{"my-app is not empty, scaffold into it anyway?"}, asked)
+				assert.True(guardRunner.HasCommand("npm", "create", "vite", "--", "my-app"))
+			})
+
+			It("does nothing when the question is declined", func() {
+				output, err := executeCmd(guardFactory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+					deps.NewConfirmUI = answering(false)
+				}), "create", "vite", "my-app")
+				assert.NoError(err)
+				assert.Contains(output, "Create cancelled, nothing was changed")
+				assert.False(guardRunner.HasBeenCalled)
+			})
+
+			It("doesn't ask about an empty or a new directory", func() {
+				root := guardFactory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+					deps.NewConfirmUI = answering(false)
+				})
+
+				_, err := executeCmd(root, "create", "vite", "empty-app")
+				assert.NoError(err)
+				_, err = executeCmd(guardFactory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+					deps.NewConfirmUI = answering(false)
+				}), "create", "vite", "new-app")
+				assert.NoError(err)
+				assert.Empty(asked)
+				assert.True(guardRunner.HasCommand("npm", "create", "vite", "--", "new-app"))
+			})
+
+			It("checks the target directory inside --cwd", func() {
+				projectDir := GinkgoT().TempDir()
+				assert.NoError(os.MkdirAll(filepath.Join(projectDir, "other-app"), 0o755))
+				assert.NoError(os.WriteFile(filepath.Join(projectDir, "other-app", "index.html"), []byte("<h1>keep me</h1>"), 0o644))
+
+				_, err := executeCmd(guardFactory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+					deps.NewConfirmUI = answering(true)
+				}), "--cwd", projectDir+"/", "create", "vite", "other-app")
+				assert.NoError(err)
+				assert.Equal([]string{filepath.Join(projectDir, "other-app") + " is not empty, scaffold into it anyway?"}, asked)
+				assert.Equal(projectDir+"/", guardRunner.WorkingDir)
+
+				asked = nil
+				_, err = executeCmd(guardFactory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+					deps.NewConfirmUI = answering(true)
+				}), "-C", projectDir+"/", "create", "vite", "my-app")
+				assert.NoError(err)
+				assert.Empty(asked)
+			})
+
+			It("forwards --force after the separator to the scaffolder", func() {
+				_, err := executeCmd(guardFactory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+					deps.NewConfirmUI = answering(true)
+				}), "create", "vite", "my-app", "--", "--force")
+				assert.NoError(err)
+				assert.Len(asked, 1)
+				assert.True(guardRunner.HasCommand("npm", "create", "vite", "--", "my-app", "--force"), "got %v", guardRunner.CommandCall)
+			})
+		})
+
 		Context("npm", func() {
 			It("should execute npm create react-app", func() {
 				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.NPM, detect.PACKAGE_LOCK_JSON)
@@ -1793,6 +2674,22 @@ var _ = Describe("JPD Commands", func() {
 				assert.True(mockCommandRunner.HasCommand("npm", "create", "vite@latest", "--", "my-app", "--template", "react"))
 			})
 
+			It("collapses a user-provided double -- for npm", func() {
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.NPM, detect.PACKAGE_LOCK_JSON)
+				DebugExecutorExpectationManager.ExpectJSCommandRandomLog()
+				_, err := executeCmd(rootCmd, "create", "vite@latest", "my-app", "--", "--", "--template", "react")
+				assert.NoError(err)
+				assert.True(mockCommandRunner.HasCommand("npm", "create", "vite@latest", "--", "my-app", "--template", "react"))
+			})
+
+			It("preserves a user-provided double -- for npm with --no-separator-normalize", func() {
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.NPM, detect.PACKAGE_LOCK_JSON)
+				DebugExecutorExpectationManager.ExpectJSCommandRandomLog()
+				_, err := executeCmd(rootCmd, "create", "--no-separator-normalize", "vite@latest", "my-app", "--", "--", "--template", "react")
+				assert.NoError(err)
+				assert.True(mockCommandRunner.HasCommand("npm", "create", "vite@latest", "my-app", "--", "--", "--template", "react"))
+			})
+
 			DescribeTable(
 				"smoke-maps common framework initializers",
 				func(args []string, expectedCommandArgs []string) {
@@ -2044,6 +2941,38 @@ var _ = Describe("JPD Commands", func() {
 				assert.NoError(err)
 				assert.True(mockCommandRunner.HasCommand("npm"))
 			})
+
+			It("forwards a flag after -- without reading it as a jpd flag", func() {
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.NPM, detect.PACKAGE_LOCK_JSON)
+				DebugExecutorExpectationManager.ExpectJSCommandLog("npm", "--version")
+				_, err := executeCmd(rootCmd, "agent", "--", "--version")
+				assert.NoError(err)
+				assert.True(mockCommandRunner.HasCommand("npm", "--version"))
+			})
+
+			It("forwards a nested -- verbatim", func() {
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.NPM, detect.PACKAGE_LOCK_JSON)
+				DebugExecutorExpectationManager.ExpectJSCommandLog("npm", "run", "build", "--", "--flag")
+				_, err := executeCmd(rootCmd, "agent", "--", "run", "build", "--", "--flag")
+				assert.NoError(err)
+				assert.True(mockCommandRunner.HasCommand("npm", "run", "build", "--", "--flag"))
+			})
+
+			It("keeps a -- that separates arguments", func() {
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.NPM, detect.PACKAGE_LOCK_JSON)
+				DebugExecutorExpectationManager.ExpectJSCommandLog("npm", "run", "build", "--", "--flag")
+				_, err := executeCmd(rootCmd, "agent", "run", "build", "--", "--flag")
+				assert.NoError(err)
+				assert.True(mockCommandRunner.HasCommand("npm", "run", "build", "--", "--flag"))
+			})
+
+			It("forwards jpd flag names after -- to npm", func() {
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.NPM, detect.PACKAGE_LOCK_JSON)
+				DebugExecutorExpectationManager.ExpectJSCommandLog("npm", "install", "foo", "--save-dev", "-d", "--agent", "yarn")
+				_, err := executeCmd(rootCmd, "agent", "--", "install", "foo", "--save-dev", "-d", "--agent", "yarn")
+				assert.NoError(err)
+				assert.True(mockCommandRunner.HasCommand("npm", "install", "foo", "--save-dev", "-d", "--agent", "yarn"))
+			})
 		})
 
 		Context("yarn", func() {
@@ -2066,6 +2995,24 @@ var _ = Describe("JPD Commands", func() {
 				assert.NoError(err)
 				assert.True(mockCommandRunner.HasCommand("pnpm"))
 			})
+
+			It("forwards a nested -- verbatim", func() {
+				pnpmRootCmd := factory.CreatePnpmAsDefault(nil)
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.PNPM, detect.PNPM_LOCK_YAML)
+				DebugExecutorExpectationManager.ExpectJSCommandLog("pnpm", "run", "build", "--", "--flag")
+				_, err := executeCmd(pnpmRootCmd, "agent", "--", "run", "build", "--", "--flag")
+				assert.NoError(err)
+				assert.True(mockCommandRunner.HasCommand("pnpm", "run", "build", "--", "--flag"))
+			})
+
+			It("forwards a flag after -- without reading it as a jpd flag", func() {
+				pnpmRootCmd := factory.CreatePnpmAsDefault(nil)
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.PNPM, detect.PNPM_LOCK_YAML)
+				DebugExecutorExpectationManager.ExpectJSCommandLog("pnpm", "--version")
+				_, err := executeCmd(pnpmRootCmd, "agent", "--", "--version")
+				assert.NoError(err)
+				assert.True(mockCommandRunner.HasCommand("pnpm", "--version"))
+			})
 		})
 
 		Context("bun", func() {
@@ -2293,6 +3240,79 @@ var _ = Describe("JPD Commands", func() {
 				assert.True(mockCommandRunner.HasCommand("deno", "run", "test.ts"))
 			})
 		})
+
+		Context("--package", func() {
+			It("runs the binary from the package with npm", func() {
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.NPM, detect.PACKAGE_LOCK_JSON)
+				DebugExecutorExpectationManager.ExpectJSCommandLog("npm", "exec", "--package=foo", "--", "bar", "--baz")
+				_, err := executeCmd(rootCmd, "exec", "--package", "foo", "bar", "--", "--baz")
+				assert.NoError(err)
+				assert.True(mockCommandRunner.HasCommand("npm", "exec", "--package=foo", "--", "bar", "--baz"))
+			})
+
+			It("runs pnpm dlx and prints a note", func() {
+				pnpmRootCmd := factory.CreatePnpmAsDefault(nil)
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.PNPM, detect.PNPM_LOCK_YAML)
+				DebugExecutorExpectationManager.ExpectJSCommandLog("pnpm", "dlx", "--package=@angular/cli", "ng", "new")
+				output, err := executeCmd(pnpmRootCmd, "exec", "--package", "@angular/cli", "ng", "new")
+				assert.NoError(err)
+				assert.Contains(output, "Note: pnpm exec cannot run binaries from other packages")
+				assert.True(mockCommandRunner.HasCommand("pnpm", "dlx", "--package=@angular/cli", "ng", "new"))
+			})
+
+			DescribeTable("BuildExecPackageCommand maps --package for each package manager",
+				func(pm, yarnVersion string, expectedProgram string, expectedArgv []string) {
+					program, argv, err := cmd.BuildExecPackageCommand(pm, yarnVersion, "foo", "bar", []string{"baz"})
+					assert.NoError(err)
+					assert.Equal(expectedProgram, program)
+					assert.Equal(expectedArgv, argv)
+				},
+				Entry("npm", "npm", "", "npm", []string{"exec", "--package=foo", "--", "bar", "baz"}),
+				Entry("pnpm", "pnpm", "", "pnpm", []string{"dlx", "--package=foo", "bar", "baz"}),
+				Entry("yarn v2+", "yarn", "4.1.0", "yarn", []string{"dlx", "--package", "foo", "bar", "baz"}),
+				Entry("bun", "bun", "", "bun", []string{"x", "--package", "foo", "bar", "baz"}),
+			)
+
+			DescribeTable("BuildExecPackageCommand rejects package managers without a --package equivalent",
+				func(pm, yarnVersion, expectedError string) {
+					_, _, err := cmd.BuildExecPackageCommand(pm, yarnVersion, "foo", "bar", nil)
+					assert.ErrorContains(err, expectedError)
+				},
+				Entry("yarn v1", "yarn", "1.22.19", "yarn v1 does not support the --package flag"),
+				Entry("deno", "deno", "", "deno does not support the --package flag"),
+			)
+		})
+
+		Context("--no-install", func() {
+			It("stops npm from fetching a missing binary", func() {
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.NPM, detect.PACKAGE_LOCK_JSON)
+				DebugExecutorExpectationManager.ExpectJSCommandLog("npm", "exec", "--no-install", "--", "foo", "--bar")
+				_, err := executeCmd(rootCmd, "exec", "--no-install", "foo", "--", "--bar")
+				assert.NoError(err)
+				assert.True(mockCommandRunner.HasCommand("npm", "exec", "--no-install", "--", "foo", "--bar"))
+			})
+
+			It("cannot be combined with --package", func() {
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.NPM, detect.PACKAGE_LOCK_JSON)
+				_, err := executeCmd(rootCmd, "exec", "--no-install", "--package", "foo", "bar")
+				assert.ErrorContains(err, "the --no-install flag cannot be combined with --package")
+				assert.False(mockCommandRunner.HasBeenCalled)
+			})
+
+			DescribeTable("BuildExecCommandNoInstall maps --no-install for each package manager",
+				func(pm, yarnVersion string, expectedProgram string, expectedArgv []string) {
+					program, argv, err := cmd.BuildExecCommandNoInstall(pm, yarnVersion, "foo", []string{"baz"})
+					assert.NoError(err)
+					assert.Equal(expectedProgram, program)
+					assert.Equal(expectedArgv, argv)
+				},
+				Entry("npm", "npm", "", "npm", []string{"exec", "--no-install", "--", "foo", "baz"}),
+				Entry("pnpm never fetches", "pnpm", "", "pnpm", []string{"exec", "foo", "baz"}),
+				Entry("yarn never fetches", "yarn", "4.1.0", "yarn", []string{"foo", "baz"}),
+				Entry("bun", "bun", "", "bun", []string{"x", "--no-install", "foo", "baz"}),
+				Entry("deno", "deno", "", "deno", []string{"run", "--cached-only", "foo", "baz"}),
+			)
+		})
 	})
 
 	const UpdateCommand = "Update Command"
@@ -2331,12 +3351,32 @@ var _ = Describe("JPD Commands", func() {
 				assert.True(mockCommandRunner.HasCommand("npm", "update"))
 			})
 
-			It("should execute npm update with --latest flag", func() {
+			It("should install every package.json dependency at @latest with --latest", func() {
+				tempDir := GinkgoT().TempDir()
+				packageJSON := `{"dependencies": {"b": "^1.0.0"}, "devDependencies": {"a": "~2.0.0"}}`
+				err := os.WriteFile(filepath.Join(tempDir, "package.json"), []byte(packageJSON), 0644)
+				assert.NoError(err)
+
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.NPM, detect.PACKAGE_LOCK_JSON)
+				DebugExecutorExpectationManager.ExpectJSCommandLog("npm", "install", "a@latest", "b@latest")
+				_, err = executeCmd(rootCmd, "update", "--latest", "--cwd", tempDir+"/")
+				assert.NoError(err)
+				assert.True(mockCommandRunner.HasCommand("npm", "install", "a@latest", "b@latest"))
+			})
+
+			It("should only install the named packages at @latest with --latest", func() {
 				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.NPM, detect.PACKAGE_LOCK_JSON)
-				DebugExecutorExpectationManager.ExpectJSCommandLog("npm", "update", "--latest")
-				_, err := executeCmd(rootCmd, "update", "--latest")
+				DebugExecutorExpectationManager.ExpectJSCommandLog("npm", "install", "react@latest", "@types/node@latest")
+				_, err := executeCmd(rootCmd, "update", "--latest", "react", "@types/node@20")
 				assert.NoError(err)
-				assert.True(mockCommandRunner.HasCommand("npm", "update", "--latest"))
+				assert.True(mockCommandRunner.HasCommand("npm", "install", "react@latest", "@types/node@latest"))
+			})
+
+			It("should require package names for global updates with --latest", func() {
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.NPM, detect.PACKAGE_LOCK_JSON)
+				_, err := executeCmd(rootCmd, "update", "--latest", "--global")
+				assert.ErrorContains(err, "npm needs the names of the global packages")
+				assert.False(mockCommandRunner.HasBeenCalled)
 			})
 		})
 
@@ -2360,6 +3400,69 @@ var _ = Describe("JPD Commands", func() {
 				assert.NoError(err)
 				assert.True(mockCommandRunner.HasCommand("yarn", "upgrade"))
 			})
+
+			It("should execute yarn upgrade --latest for v1", func() {
+				yarnRootCmd := factory.CreateYarnOneAsDefault(nil)
+				DebugExecutorExpectationManager.ExpectCommonPathDetectionFlow(detect.YARN)
+				DebugExecutorExpectationManager.ExpectJSCommandLog("yarn", "upgrade", "lodash", "--latest")
+				_, err := executeCmd(yarnRootCmd, "update", "lodash", "--latest")
+				assert.NoError(err)
+				assert.True(mockCommandRunner.HasCommand("yarn", "upgrade", "lodash", "--latest"))
+			})
+
+			It("should execute yarn up for v2+", func() {
+				yarnRootCmd := factory.CreateYarnTwoAsDefault(nil)
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.YARN, detect.YARN_LOCK)
+				DebugExecutorExpectationManager.ExpectJSCommandLog("yarn", "up", "lodash")
+				_, err := executeCmd(yarnRootCmd, "update", "lodash")
+				assert.NoError(err)
+				assert.True(mockCommandRunner.HasCommand("yarn", "up", "lodash"))
+			})
+
+			It("should execute yarn up -i for v2+ with --interactive", func() {
+				yarnRootCmd := factory.CreateYarnTwoAsDefault(nil)
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.YARN, detect.YARN_LOCK)
+				DebugExecutorExpectationManager.ExpectJSCommandLog("yarn", "up", "-i", "lodash")
+				_, err := executeCmd(yarnRootCmd, "update", "--interactive", "lodash")
+				assert.NoError(err)
+				assert.True(mockCommandRunner.HasCommand("yarn", "up", "-i", "lodash"))
+			})
+
+			It("should execute yarn up without --latest for v2+ since up ignores ranges", func() {
+				yarnRootCmd := factory.CreateYarnTwoAsDefault(nil)
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.YARN, detect.YARN_LOCK)
+				DebugExecutorExpectationManager.ExpectJSCommandLog("yarn", "up", "lodash")
+				output, err := executeCmd(yarnRootCmd, "update", "lodash", "--latest")
+				assert.NoError(err)
+				assert.Contains(output, "up ignores version ranges already, --latest is ignored")
+				assert.True(mockCommandRunner.HasCommand("yarn", "up", "lodash"))
+			})
+
+			It("should execute yarn up with every dependency for v2+ when no package is named", func() {
+				yarnRootCmd := factory.CreateYarnTwoAsDefault(nil)
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.YARN, detect.YARN_LOCK)
+				DebugExecutorExpectationManager.ExpectJSCommandLog("yarn", "up", "*")
+				_, err := executeCmd(yarnRootCmd, "update")
+				assert.NoError(err)
+				assert.True(mockCommandRunner.HasCommand("yarn", "up", "*"))
+			})
+
+			It("should execute yarn up -i with every dependency for v2+ when no package is named", func() {
+				yarnRootCmd := factory.CreateYarnTwoAsDefault(nil)
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.YARN, detect.YARN_LOCK)
+				DebugExecutorExpectationManager.ExpectJSCommandLog("yarn", "up", "-i", "*")
+				_, err := executeCmd(yarnRootCmd, "update", "--interactive")
+				assert.NoError(err)
+				assert.True(mockCommandRunner.HasCommand("yarn", "up", "-i", "*"))
+			})
+
+			It("should reject global updates for v2+", func() {
+				yarnRootCmd := factory.CreateYarnTwoAsDefault(nil)
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.YARN, detect.YARN_LOCK)
+				_, err := executeCmd(yarnRootCmd, "update", "--global", "typescript")
+				assert.Error(err)
+				assert.Contains(err.Error(), "does not support global updates")
+			})
 		})
 
 		Context("bun", func() {
@@ -2371,6 +3474,15 @@ var _ = Describe("JPD Commands", func() {
 				assert.NoError(err)
 				assert.True(mockCommandRunner.HasCommand("bun", "update"))
 			})
+
+			It("should execute bun update --latest", func() {
+				bunRootCmd := factory.CreateBunAsDefault(nil)
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.BUN, detect.BUN_LOCKB)
+				DebugExecutorExpectationManager.ExpectJSCommandLog("bun", "update", "--latest")
+				_, err := executeCmd(bunRootCmd, "update", "--latest")
+				assert.NoError(err)
+				assert.True(mockCommandRunner.HasCommand("bun", "update", "--latest"))
+			})
 		})
 
 		Context("deno", func() {
@@ -2470,16 +3582,135 @@ var _ = Describe("JPD Commands", func() {
 				assert.NoError(err)
 				assert.True(mockCommandRunner.HasCommand("bun", "remove", "lodash"))
 			})
-		})
+		})
+
+		Context("deno", func() {
+			It("should execute deno uninstall with package name", func() {
+				denoRootCmd := factory.CreateDenoAsDefault(nil)
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.DENO, detect.DENO_JSON)
+				DebugExecutorExpectationManager.ExpectJSCommandLog("deno", "remove", "lodash")
+				_, err := executeCmd(denoRootCmd, "uninstall", "lodash")
+				assert.NoError(err)
+				assert.True(mockCommandRunner.HasCommand("deno", "remove", "lodash"))
+			})
+		})
+
+		Context("--all-groups", func() {
+			It("removes the package from dependencies and peerDependencies", func() {
+				tempDir := GinkgoT().TempDir()
+				packageJSON := `{
+  "name": "app",
+  "dependencies": {
+    "react": "18.2.0",
+    "lodash": "4.17.21"
+  },
+  "peerDependencies": {
+    "react": ">=18"
+  }
+}
+`
+				err := os.WriteFile(filepath.Join(tempDir, "package.json"), []byte(packageJSON), 0644)
+				assert.NoError(err)
+
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.NPM, detect.PACKAGE_LOCK_JSON)
+				DebugExecutorExpectationManager.ExpectJSCommandLog("npm", "uninstall", "react")
+				_, err = executeCmd(rootCmd, "uninstall", "react", "--all-groups", "--cwd", tempDir+"/")
+				assert.NoError(err)
+				assert.True(mockCommandRunner.HasCommand("npm", "uninstall", "react"))
+
+				var pkg struct {
+					Dependencies     map[string]string `json:"dependencies"`
+					PeerDependencies map[string]string `json:"peerDependencies"`
+				}
+				data, err := os.ReadFile(filepath.Join(tempDir, "package.json"))
+				assert.NoError(err)
+				assert.NoError(json.Unmarshal(data, &pkg))
+				assert.Equal(map[string]string{"lodash": "4.17.21"}, pkg.Dependencies)
+				assert.Empty(pkg.PeerDependencies)
+			})
+
+			It("leaves other groups untouched without the flag", func() {
+				tempDir := GinkgoT().TempDir()
+				packageJSON := `{"dependencies": {"react": "18.2.0"}, "peerDependencies": {"react": ">=18"}}`
+				err := os.WriteFile(filepath.Join(tempDir, "package.json"), []byte(packageJSON), 0644)
+				assert.NoError(err)
+
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.NPM, detect.PACKAGE_LOCK_JSON)
+				DebugExecutorExpectationManager.ExpectJSCommandLog("npm", "uninstall", "react")
+				_, err = executeCmd(rootCmd, "uninstall", "react", "--cwd", tempDir+"/")
+				assert.NoError(err)
+
+				data, err := os.ReadFile(filepath.Join(tempDir, "package.json"))
+				assert.NoError(err)
+				assert.Equal(packageJSON, string(data))
+			})
+
+			It("is rejected for deno", func() {
+				denoRootCmd := factory.CreateDenoAsDefault(nil)
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.DENO, detect.DENO_JSON)
+				_, err := executeCmd(denoRootCmd, "uninstall", "lodash", "--all-groups")
+				assert.Error(err)
+				assert.Contains(err.Error(), "--all-groups")
+				assert.False(mockCommandRunner.HasBeenCalled)
+			})
+		})
+
+		Context("--prune-lockfile", func() {
+			var (
+				pruneRunner  *mock.MockCommandRunner
+				pruneFactory *testutil.RootCommandFactory
+			)
+
+			BeforeEach(func() {
+				pruneRunner = mock.NewMockCommandRunner()
+				pruneFactory = testutil.NewRootCommandFactory(pruneRunner)
+				pruneFactory.SetupBasicCommandRunnerExpectations()
+				pruneFactory.ResetDebugExecutor()
+				testutil.DebugExecutorExpectationManager.DebugExecutor = pruneFactory.DebugExecutor()
+				pruneFactory.SetupBasicDebugExecutorExpectations()
+			})
+
+			DescribeTable("refreshes the lock file without installing after the removal",
+				func(newRootCmd func(*testutil.RootCommandFactory, error) *cobra.Command, remove, refresh []string) {
+					_, err := executeCmd(newRootCmd(pruneFactory, nil), "uninstall", "lodash", "--prune-lockfile")
+					assert.NoError(err)
+					assert.Equal([]mock.CommandCall{
+						{Name: remove[0], Args: remove[1:]},
+						{Name: refresh[0], Args: refresh[1:]},
+					}, pruneRunner.CommandHistory())
+				},
+				Entry("npm", (*testutil.RootCommandFactory).CreateNpmAsDefault, []string{"npm", "uninstall", "lodash"}, []string{"npm", "install", "--package-lock-only"}),
+				Entry("pnpm", (*testutil.RootCommandFactory).CreatePnpmAsDefault, []string{"pnpm", "remove", "lodash"}, []string{"pnpm", "install", "--lockfile-only"}),
+				Entry("yarn v2+", (*testutil.RootCommandFactory).CreateYarnTwoAsDefault, []string{"yarn", "remove", "lodash"}, []string{"yarn", "install", "--mode=update-lockfile"}),
+				Entry("bun", (*testutil.RootCommandFactory).CreateBunAsDefault, []string{"bun", "remove", "lodash"}, []string{"bun", "install", "--lockfile-only"}),
+			)
+
+			It("only removes the package with yarn v1, which rewrites yarn.lock itself", func() {
+				output, err := executeCmd(pruneFactory.CreateYarnOneAsDefault(nil), "uninstall", "lodash", "--prune-lockfile")
+				assert.NoError(err)
+				assert.Equal([]mock.CommandCall{{Name: "yarn", Args: []string{"remove", "lodash"}}}, pruneRunner.CommandHistory())
+				assert.Contains(output, "Note: yarn updates its lock file when it removes packages")
+			})
 
-		Context("deno", func() {
-			It("should execute deno uninstall with package name", func() {
-				denoRootCmd := factory.CreateDenoAsDefault(nil)
-				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.DENO, detect.DENO_JSON)
-				DebugExecutorExpectationManager.ExpectJSCommandLog("deno", "remove", "lodash")
-				_, err := executeCmd(denoRootCmd, "uninstall", "lodash")
+			It("only removes the package with deno, which edits deno.json and deno.lock itself", func() {
+				output, err := executeCmd(pruneFactory.CreateDenoAsDefault(nil), "uninstall", "lodash", "--prune-lockfile")
 				assert.NoError(err)
-				assert.True(mockCommandRunner.HasCommand("deno", "remove", "lodash"))
+				assert.Equal([]mock.CommandCall{{Name: "deno", Args: []string{"remove", "lodash"}}}, pruneRunner.CommandHistory())
+				assert.Contains(output, "Note: deno remove edits deno.json and deno.lock itself")
+			})
+
+			It("doesn't refresh the lock file when the removal fails", func() {
+				pruneRunner.InvalidCommands = []string{"npm"}
+
+				_, err := executeCmd(pruneFactory.CreateNpmAsDefault(nil), "uninstall", "lodash", "--prune-lockfile")
+				assert.Error(err)
+				assert.Len(pruneRunner.CommandHistory(), 1)
+			})
+
+			It("is rejected together with --global", func() {
+				_, err := executeCmd(pruneFactory.CreateNpmAsDefault(nil), "uninstall", "typescript", "--global", "--prune-lockfile")
+				assert.Error(err)
+				assert.False(pruneRunner.HasBeenCalled)
 			})
 		})
 
@@ -2566,6 +3797,84 @@ var _ = Describe("JPD Commands", func() {
 			})
 		})
 
+		Context("--no-optional and --ignore-scripts", func() {
+			// rootFor creates the root command for a package manager and expects its detection flow
+			rootFor := func(variant string) (*cobra.Command, string) {
+				switch variant {
+				case "pnpm":
+					DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.PNPM, detect.PNPM_LOCK_YAML)
+					return factory.CreatePnpmAsDefault(nil), detect.PNPM
+				case "yarn v1":
+					DebugExecutorExpectationManager.ExpectCommonPathDetectionFlow(detect.YARN)
+					return factory.CreateYarnOneAsDefault(nil), detect.YARN
+				case "yarn v2+":
+					DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.YARN, detect.YARN_LOCK)
+					return factory.CreateYarnTwoAsDefault(nil), detect.YARN
+				case "bun":
+					DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.BUN, detect.BUN_LOCKB)
+					return factory.CreateBunAsDefault(nil), detect.BUN
+				default:
+					DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.NPM, detect.PACKAGE_LOCK_JSON)
+					return factory.CreateNpmAsDefault(nil), detect.NPM
+				}
+			}
+
+			DescribeTable("--no-optional composes with the frozen install",
+				func(variant string, expected []string) {
+					root, pm := rootFor(variant)
+					DebugExecutorExpectationManager.ExpectJSCommandLog(pm, expected...)
+					_, err := executeCmd(root, "clean-install", "--no-optional")
+					assert.NoError(err)
+					assert.True(mockCommandRunner.HasCommand(pm, expected...))
+				},
+				Entry("npm", "npm", []string{"ci", "--omit=optional"}),
+				Entry("pnpm", "pnpm", []string{"install", "--frozen-lockfile", "--no-optional"}),
+				Entry("yarn v1", "yarn v1", []string{"install", "--frozen-lockfile", "--ignore-optional"}),
+				Entry("bun", "bun", []string{"install", "--frozen-lockfile", "--omit=optional"}),
+			)
+
+			DescribeTable("--ignore-scripts composes with the frozen install",
+				func(variant string, expected []string) {
+					root, pm := rootFor(variant)
+					DebugExecutorExpectationManager.ExpectJSCommandLog(pm, expected...)
+					_, err := executeCmd(root, "clean-install", "--ignore-scripts")
+					assert.NoError(err)
+					assert.True(mockCommandRunner.HasCommand(pm, expected...))
+				},
+				Entry("npm", "npm", []string{"ci", "--ignore-scripts"}),
+				Entry("pnpm", "pnpm", []string{"install", "--frozen-lockfile", "--ignore-scripts"}),
+				Entry("yarn v1", "yarn v1", []string{"install", "--frozen-lockfile", "--ignore-scripts"}),
+				Entry("yarn v2+", "yarn v2+", []string{"install", "--immutable", "--mode=skip-build"}),
+				Entry("bun", "bun", []string{"install", "--frozen-lockfile", "--ignore-scripts"}),
+			)
+
+			It("combines both flags for npm", func() {
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.NPM, detect.PACKAGE_LOCK_JSON)
+				DebugExecutorExpectationManager.ExpectJSCommandLog("npm", "ci", "--omit=optional", "--ignore-scripts")
+				_, err := executeCmd(rootCmd, "clean-install", "--no-optional", "--ignore-scripts")
+				assert.NoError(err)
+				assert.True(mockCommandRunner.HasCommand("npm", "ci", "--omit=optional", "--ignore-scripts"))
+			})
+
+			It("prints a note for --no-optional on yarn v2+", func() {
+				yarnRootCmd := factory.CreateYarnTwoAsDefault(nil)
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.YARN, detect.YARN_LOCK)
+				DebugExecutorExpectationManager.ExpectJSCommandLog("yarn", "install", "--immutable")
+				output, err := executeCmd(yarnRootCmd, "clean-install", "--no-optional")
+				assert.NoError(err)
+				assert.Contains(output, "no option to skip optional dependencies")
+				assert.True(mockCommandRunner.HasCommand("yarn", "install", "--immutable"))
+			})
+
+			It("still rejects deno", func() {
+				denoRootCmd := factory.CreateDenoAsDefault(nil)
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.DENO, detect.DENO_JSON)
+				_, err := executeCmd(denoRootCmd, "clean-install", "--ignore-scripts")
+				assert.Error(err)
+				assert.Contains(err.Error(), "deno does not support this command")
+			})
+		})
+
 	})
 
 	const CompletionCommand = "Completion Command"
@@ -2805,6 +4114,27 @@ var _ = Describe("JPD Commands", func() {
 				assert.NoError(err)
 				assert.True(mockCommandRunner.HasCommand("deno", "task", "test"))
 			})
+
+			DescribeTable("should forward the arguments after the task name",
+				func(args ...string) {
+					DebugExecutorExpectationManager.ExpectLockfileDetected(detect.DENO_JSON)
+					DebugExecutorExpectationManager.ExpectPMDetectedFromLockfile(detect.DENO)
+					DebugExecutorExpectationManager.ExpectJSCommandLog("deno", "task", "test", "--filter", "foo")
+					_, err := executeCmd(denoRootCmd, args...)
+					assert.NoError(err)
+					assert.True(mockCommandRunner.HasCommand("deno", "task", "test", "--filter", "foo"))
+				},
+				Entry("after a separator", "run", "test", "--", "--filter", "foo"),
+				Entry("after a second separator", "run", "--", "test", "--", "--filter", "foo"),
+			)
+
+			It("should return an error if the eval flag is passed with a value", func() {
+				DebugExecutorExpectationManager.ExpectLockfileDetected(detect.DENO_JSON)
+				DebugExecutorExpectationManager.ExpectPMDetectedFromLockfile(detect.DENO)
+				_, err := executeCmd(denoRootCmd, "run", "test", "--", "--filter", "foo", "--eval=console.log(1)")
+				assert.ErrorContains(err, "don't pass --eval here use the exec command instead")
+				assert.False(mockCommandRunner.HasBeenCalled)
+			})
 		})
 
 		// CWD Integration Tests (moved from run_cwd_integration_test.go)
@@ -2912,6 +4242,150 @@ var _ = Describe("JPD Commands", func() {
 					assert.True(mockCommandRunner.HasCommand("deno", "task", "from-target"))
 					assert.Equal(targetDir+"/", mockCommandRunner.WorkingDir)
 				})
+
+				It("skips a task missing from deno.json with --if-present", func() {
+					targetDir := GinkgoT().TempDir()
+					err := os.WriteFile(filepath.Join(targetDir, "deno.json"), []byte(`{"tasks":{"other":"deno run other.ts"}}`), 0644)
+					assert.NoError(err)
+
+					DebugExecutorExpectationManager.ExpectAgentFlagSet("deno")
+					_, err = executeCmd(factory.CreateDenoAsDefault(nil), "--agent", "deno", "--cwd", targetDir+"/", "run", "--if-present", "sometask")
+
+					assert.NoError(err)
+					assert.False(mockCommandRunner.HasBeenCalled)
+				})
+
+				It("runs a task found in deno.json with --if-present", func() {
+					targetDir := GinkgoT().TempDir()
+					err := os.WriteFile(filepath.Join(targetDir, "deno.json"), []byte(`{"tasks":{"sometask":"deno run mod.ts"}}`), 0644)
+					assert.NoError(err)
+
+					DebugExecutorExpectationManager.ExpectAgentFlagSet("deno")
+					DebugExecutorExpectationManager.ExpectJSCommandLog("deno", "task", "sometask")
+					_, err = executeCmd(factory.CreateDenoAsDefault(nil), "--agent", "deno", "--cwd", targetDir+"/", "run", "--if-present", "sometask")
+
+					assert.NoError(err)
+					assert.True(mockCommandRunner.HasCommand("deno", "task", "sometask"))
+				})
+			})
+		})
+
+		Context("--list", func() {
+			It("prints package.json scripts sorted by name without running anything", func() {
+				targetDir := GinkgoT().TempDir()
+				err := os.WriteFile(filepath.Join(targetDir, "package.json"), []byte(`{"scripts":{"test":"vitest","build":"vite build"}}`), 0644)
+				assert.NoError(err)
+
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.NPM, detect.PACKAGE_LOCK_JSON)
+				output, err := executeCmd(rootCmd, "--cwd", targetDir+"/", "run", "--list")
+				assert.NoError(err)
+				assert.Equal("build: vite build\ntest: vitest\n", output)
+				assert.False(mockCommandRunner.HasBeenCalled)
+			})
+
+			It("prints deno.json tasks as JSON", func() {
+				targetDir := GinkgoT().TempDir()
+				err := os.WriteFile(filepath.Join(targetDir, "deno.json"), []byte(`{"tasks":{"dev":"deno run -A main.ts"}}`), 0644)
+				assert.NoError(err)
+
+				denoRootCmd := factory.CreateDenoAsDefault(nil)
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.DENO, detect.DENO_JSON)
+				output, err := executeCmd(denoRootCmd, "--cwd", targetDir+"/", "run", "--list", "--json")
+				assert.NoError(err)
+
+				var tasks map[string]string
+				assert.NoError(json.Unmarshal([]byte(output), &tasks))
+				assert.Equal(map[string]string{"dev": "deno run -A main.ts"}, tasks)
+				assert.False(mockCommandRunner.HasBeenCalled)
+			})
+
+			It("prints deno.jsonc tasks when the manifest has comments", func() {
+				targetDir := GinkgoT().TempDir()
+				err := os.WriteFile(filepath.Join(targetDir, "deno.jsonc"), []byte(`{
+					// Tasks used during development
+					"tasks": {
+						"dev": "deno run -A https://deno.land/std/http/file_server.ts", // serve files
+						/* "lint": "deno lint", */
+						"test": "deno test",
+					},
+				}`), 0644)
+				assert.NoError(err)
+
+				denoRootCmd := factory.CreateDenoAsDefault(nil)
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.DENO, detect.DENO_JSON)
+				output, err := executeCmd(denoRootCmd, "--cwd", targetDir+"/", "run", "--list")
+				assert.NoError(err)
+				assert.Equal("dev: deno run -A https://deno.land/std/http/file_server.ts\ntest: deno test\n", output)
+				assert.False(mockCommandRunner.HasBeenCalled)
+			})
+
+			It("prints a message when there are no scripts", func() {
+				targetDir := GinkgoT().TempDir()
+				err := os.WriteFile(filepath.Join(targetDir, "package.json"), []byte(`{"name":"app"}`), 0644)
+				assert.NoError(err)
+
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.NPM, detect.PACKAGE_LOCK_JSON)
+				output, err := executeCmd(rootCmd, "--cwd", targetDir+"/", "run", "--list")
+				assert.NoError(err)
+				assert.Contains(output, "No scripts found in package.json")
+				assert.False(mockCommandRunner.HasBeenCalled)
+			})
+
+			It("returns an error when the manifest is missing", func() {
+				targetDir := GinkgoT().TempDir()
+
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.NPM, detect.PACKAGE_LOCK_JSON)
+				_, err := executeCmd(rootCmd, "--cwd", targetDir+"/", "run", "--list")
+				assert.Error(err)
+				assert.Contains(err.Error(), "failed to read package.json")
+				assert.False(mockCommandRunner.HasBeenCalled)
+			})
+		})
+
+		Context("--list-json", func() {
+			It("prints each npm script with the command jpd runs for it", func() {
+				targetDir := GinkgoT().TempDir()
+				err := os.WriteFile(filepath.Join(targetDir, "package.json"), []byte(`{"scripts":{"dev":"vite","build":"vite build"}}`), 0644)
+				assert.NoError(err)
+
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.NPM, detect.PACKAGE_LOCK_JSON)
+				output, err := executeCmd(rootCmd, "--cwd", targetDir+"/", "run", "--list-json")
+				assert.NoError(err)
+
+				var scripts []cmd.RunScriptCommand
+				assert.NoError(json.Unmarshal([]byte(output), &scripts))
+				assert.Equal([]cmd.RunScriptCommand{
+					{Name: "build", Script: "vite build", Command: "npm run build"},
+					{Name: "dev", Script: "vite", Command: "npm run dev"},
+				}, scripts)
+				assert.False(mockCommandRunner.HasBeenCalled)
+			})
+
+			It("prints each deno task with deno task as its command", func() {
+				targetDir := GinkgoT().TempDir()
+				err := os.WriteFile(filepath.Join(targetDir, "deno.json"), []byte(`{"tasks":{"dev":"deno run -A main.ts"}}`), 0644)
+				assert.NoError(err)
+
+				denoRootCmd := factory.CreateDenoAsDefault(nil)
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.DENO, detect.DENO_JSON)
+				output, err := executeCmd(denoRootCmd, "--cwd", targetDir+"/", "run", "--list-json")
+				assert.NoError(err)
+
+				var scripts []cmd.RunScriptCommand
+				assert.NoError(json.Unmarshal([]byte(output), &scripts))
+				assert.Equal([]cmd.RunScriptCommand{{Name: "dev", Script: "deno run -A main.ts", Command: "deno task dev"}}, scripts)
+				assert.False(mockCommandRunner.HasBeenCalled)
+			})
+
+			It("prints an empty array when there are no scripts", func() {
+				targetDir := GinkgoT().TempDir()
+				err := os.WriteFile(filepath.Join(targetDir, "package.json"), []byte(`{"name":"app"}`), 0644)
+				assert.NoError(err)
+
+				DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.NPM, detect.PACKAGE_LOCK_JSON)
+				output, err := executeCmd(rootCmd, "--cwd", targetDir+"/", "run", "--list-json")
+				assert.NoError(err)
+				assert.Equal("[]\n", output)
 			})
 		})
 
@@ -2928,6 +4402,45 @@ var _ = Describe("JPD Commands", func() {
 				assert.Contains(err.Error(), "mock error: command 'npm' is configured to fail")
 			})
 
+			It("hints at jpd exec when the script fails without a package.json", func() {
+				targetDir := GinkgoT().TempDir()
+				rootCmd := factory.CreateNpmAsDefault(nil)
+				mockCommandRunner.InvalidCommands = []string{"npm"}
+
+				_, err := executeCmd(rootCmd, "--cwd", targetDir+"/", "run", "eslint")
+				assert.ErrorContains(err, "no package.json found; did you mean `jpd exec eslint`?")
+				assert.ErrorContains(err, "mock error: command 'npm' is configured to fail")
+			})
+
+			It("hints at jpd exec for --if-present without a package.json", func() {
+				targetDir := GinkgoT().TempDir()
+
+				_, err := executeCmd(factory.CreateNpmAsDefault(nil), "--cwd", targetDir+"/", "run", "--if-present", "eslint")
+				assert.ErrorContains(err, "no package.json found; did you mean `jpd exec eslint`?")
+				assert.False(mockCommandRunner.HasBeenCalled)
+			})
+
+			It("hints at jpd exec when the task fails without a deno.json", func() {
+				targetDir := GinkgoT().TempDir()
+				rootCmd := factory.CreateDenoAsDefault(nil)
+				mockCommandRunner.InvalidCommands = []string{"deno"}
+
+				_, err := executeCmd(rootCmd, "--cwd", targetDir+"/", "run", "eslint")
+				assert.ErrorContains(err, "no deno.json found; did you mean `jpd exec eslint`?")
+			})
+
+			It("keeps the error as is when the package.json exists", func() {
+				targetDir := GinkgoT().TempDir()
+				err := os.WriteFile(filepath.Join(targetDir, "package.json"), []byte(`{"scripts":{"lint":"eslint ."}}`), 0644)
+				assert.NoError(err)
+				rootCmd := factory.CreateNpmAsDefault(nil)
+				mockCommandRunner.InvalidCommands = []string{"npm"}
+
+				_, err = executeCmd(rootCmd, "--cwd", targetDir+"/", "run", "lint")
+				assert.ErrorContains(err, "mock error: command 'npm' is configured to fail")
+				assert.NotContains(err.Error(), "jpd exec")
+			})
+
 			It("should handle package.json reading error", func() {
 				testDir := GinkgoT().TempDir()
 				originalDir, _ := os.Getwd()
@@ -3186,12 +4699,38 @@ var _ = Describe("JPD Commands", func() {
 			assert.Error(err)
 		})
 
-		It("should handle --help in arguments", func() {
-			output, err := executeCmd(rootCmd, "exec", "some-package", "--help")
+		It("should handle --help in arguments without strict passthrough", func() {
+			output, err := executeCmd(rootCmd, "exec", "--strict-passthrough=false", "some-package", "--help")
 			assert.NoError(err)
 			assert.Contains(output, "Execute local dependencies")
 		})
 
+		Context("strict passthrough", func() {
+			It("forwards flags after the binary name to the binary", func() {
+				DebugExecutorExpectationManager.ExpectLockfileDetected(detect.PACKAGE_LOCK_JSON)
+				DebugExecutorExpectationManager.ExpectPMDetectedFromLockfile(detect.NPM)
+				DebugExecutorExpectationManager.ExpectJSCommandLog("npm", "exec", "eslint", "--", "--fix", ".")
+				_, err := executeCmd(rootCmd, "exec", "eslint", "--fix", ".")
+				assert.NoError(err)
+				assert.True(mockCommandRunner.HasCommand("npm", "exec", "eslint", "--", "--fix", "."))
+			})
+
+			It("forwards jpd flags after the binary name to the binary", func() {
+				DebugExecutorExpectationManager.ExpectLockfileDetected(detect.PACKAGE_LOCK_JSON)
+				DebugExecutorExpectationManager.ExpectPMDetectedFromLockfile(detect.NPM)
+				DebugExecutorExpectationManager.ExpectJSCommandLog("npm", "exec", "vite", "--", "--help", "--which")
+				_, err := executeCmd(rootCmd, "exec", "vite", "--help", "--which")
+				assert.NoError(err)
+				assert.True(mockCommandRunner.HasCommand("npm", "exec", "vite", "--", "--help", "--which"))
+			})
+
+			It("parses jpd flags after the binary name with --strict-passthrough=false", func() {
+				_, err := executeCmd(rootCmd, "exec", "--strict-passthrough=false", "eslint", "--fix")
+				assert.ErrorContains(err, "unknown flag: --fix")
+				assert.False(mockCommandRunner.HasBeenCalled)
+			})
+		})
+
 		Context("npm", func() {
 			It("should execute npx with package name", func() {
 				DebugExecutorExpectationManager.ExpectLockfileDetected(detect.PACKAGE_LOCK_JSON)
@@ -3370,13 +4909,38 @@ var _ = Describe("JPD Commands", func() {
 			assert.NotNil(flag)
 		})
 
+		Context("updating jpd itself", func() {
+			It("prints upgrade guidance instead of running the package manager", func() {
+				DebugExecutorExpectationManager.ExpectLockfileDetected(detect.PACKAGE_LOCK_JSON)
+				DebugExecutorExpectationManager.ExpectPMDetectedFromLockfile(detect.NPM)
+				output, err := executeCmd(rootCmd, "update", "jpd")
+				assert.NoError(err)
+				assert.Contains(output, "is not managed by your package manager")
+				assert.Contains(output, "brew upgrade")
+				assert.False(mockCommandRunner.HasBeenCalled)
+			})
+
+			It("updates the remaining packages when jpd is listed with others", func() {
+				DebugExecutorExpectationManager.ExpectLockfileDetected(detect.PACKAGE_LOCK_JSON)
+				DebugExecutorExpectationManager.ExpectPMDetectedFromLockfile(detect.NPM)
+				DebugExecutorExpectationManager.ExpectJSCommandLog("npm", "update", "lodash")
+				output, err := executeCmd(rootCmd, "update", "javascript-package-delegator@latest", "lodash")
+				assert.NoError(err)
+				assert.Contains(output, "is not managed by your package manager")
+				assert.True(mockCommandRunner.HasCommand("npm", "update", "lodash"))
+			})
+		})
+
 		Context("npm", func() {
-			It("should error on npm with interactive flag", func() {
+			It("should report nothing to update on npm with interactive flag when nothing is outdated", func() {
 				DebugExecutorExpectationManager.ExpectLockfileDetected(detect.PACKAGE_LOCK_JSON)
 				DebugExecutorExpectationManager.ExpectPMDetectedFromLockfile(detect.NPM)
-				_, err := executeCmd(rootCmd, "update", "--interactive")
-				assert.Error(err)
-				assert.Contains(err.Error(), "npm does not support interactive updates")
+				output, err := executeCmd(factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+					deps.NewParallelCommandRunner = (&testutil.MockScriptRunners{Setup: testutil.ScriptOutput(nil, "{}")}).NewRunner
+				}), "update", "--interactive")
+				assert.NoError(err)
+				assert.Contains(output, "All packages are up to date")
+				assert.False(mockCommandRunner.HasBeenCalled)
 			})
 
 			It("should run npm update with no args", func() {
@@ -3409,20 +4973,19 @@ var _ = Describe("JPD Commands", func() {
 			It("should handle latest flag for npm", func() {
 				DebugExecutorExpectationManager.ExpectLockfileDetected(detect.PACKAGE_LOCK_JSON)
 				DebugExecutorExpectationManager.ExpectPMDetectedFromLockfile(detect.NPM)
-				DebugExecutorExpectationManager.ExpectJSCommandLog("npm", "update", "--latest")
-				_, err := executeCmd(rootCmd, "update", "--latest")
+				DebugExecutorExpectationManager.ExpectJSCommandLog("npm", "install", "typescript@latest")
+				_, err := executeCmd(rootCmd, "update", "--latest", "typescript")
 				assert.NoError(err)
-				assert.True(mockCommandRunner.HasCommand("npm", "update", "--latest"))
+				assert.True(mockCommandRunner.HasCommand("npm", "install", "typescript@latest"))
 			})
 
 			It("should handle latest flag with global for npm", func() {
 				DebugExecutorExpectationManager.ExpectLockfileDetected(detect.PACKAGE_LOCK_JSON)
 				DebugExecutorExpectationManager.ExpectPMDetectedFromLockfile(detect.NPM)
-				// npm update appends --global first, then --latest
-				DebugExecutorExpectationManager.ExpectJSCommandLog("npm", "update", "--global", "--latest")
-				_, err := executeCmd(rootCmd, "update", "--latest", "--global")
+				DebugExecutorExpectationManager.ExpectJSCommandLog("npm", "install", "typescript@latest", "--global")
+				_, err := executeCmd(rootCmd, "update", "--latest", "--global", "typescript")
 				assert.NoError(err)
-				assert.True(mockCommandRunner.HasCommand("npm", "update", "--global", "--latest"))
+				assert.True(mockCommandRunner.HasCommand("npm", "install", "typescript@latest", "--global"))
 			})
 		})
 
@@ -3492,12 +5055,11 @@ var _ = Describe("JPD Commands", func() {
 			var yarnRootCmd *cobra.Command
 
 			BeforeEach(func() {
-				yarnRootCmd = factory.CreateYarnTwoAsDefault(nil)
+				yarnRootCmd = factory.CreateYarnOneAsDefault(nil)
 			})
 
 			It("should handle yarn with specific packages", func() {
-				DebugExecutorExpectationManager.ExpectLockfileDetected(detect.YARN_LOCK)
-				DebugExecutorExpectationManager.ExpectPMDetectedFromLockfile(detect.YARN)
+				DebugExecutorExpectationManager.ExpectCommonPathDetectionFlow(detect.YARN)
 				DebugExecutorExpectationManager.ExpectJSCommandLog("yarn", "upgrade", "lodash")
 				_, err := executeCmd(yarnRootCmd, "update", "lodash")
 				assert.NoError(err)
@@ -3505,8 +5067,7 @@ var _ = Describe("JPD Commands", func() {
 			})
 
 			It("should handle interactive flag with yarn", func() {
-				DebugExecutorExpectationManager.ExpectLockfileDetected(detect.YARN_LOCK)
-				DebugExecutorExpectationManager.ExpectPMDetectedFromLockfile(detect.YARN)
+				DebugExecutorExpectationManager.ExpectCommonPathDetectionFlow(detect.YARN)
 				DebugExecutorExpectationManager.ExpectJSCommandLog("yarn", "upgrade-interactive")
 				_, err := executeCmd(yarnRootCmd, "update", "--interactive")
 				assert.NoError(err)
@@ -3514,8 +5075,7 @@ var _ = Describe("JPD Commands", func() {
 			})
 
 			It("should handle interactive flag with yarn with args", func() {
-				DebugExecutorExpectationManager.ExpectLockfileDetected(detect.YARN_LOCK)
-				DebugExecutorExpectationManager.ExpectPMDetectedFromLockfile(detect.YARN)
+				DebugExecutorExpectationManager.ExpectCommonPathDetectionFlow(detect.YARN)
 				DebugExecutorExpectationManager.ExpectJSCommandLog("yarn", "upgrade-interactive", "test")
 				_, err := executeCmd(yarnRootCmd, "update", "--interactive", "test")
 				assert.NoError(err)
@@ -3523,8 +5083,7 @@ var _ = Describe("JPD Commands", func() {
 			})
 
 			It("should handle latest flag with yarn", func() {
-				DebugExecutorExpectationManager.ExpectLockfileDetected(detect.YARN_LOCK)
-				DebugExecutorExpectationManager.ExpectPMDetectedFromLockfile(detect.YARN)
+				DebugExecutorExpectationManager.ExpectCommonPathDetectionFlow(detect.YARN)
 				DebugExecutorExpectationManager.ExpectJSCommandLog("yarn", "upgrade", "--latest")
 				_, err := executeCmd(yarnRootCmd, "update", "--latest")
 				assert.NoError(err)
@@ -3532,8 +5091,7 @@ var _ = Describe("JPD Commands", func() {
 			})
 
 			It("should handle yarn with global flag", func() {
-				DebugExecutorExpectationManager.ExpectLockfileDetected(detect.YARN_LOCK)
-				DebugExecutorExpectationManager.ExpectPMDetectedFromLockfile(detect.YARN)
+				DebugExecutorExpectationManager.ExpectCommonPathDetectionFlow(detect.YARN)
 				DebugExecutorExpectationManager.ExpectJSCommandLog("yarn", "upgrade", "--global")
 				_, err := executeCmd(yarnRootCmd, "update", "--global")
 				assert.NoError(err)
@@ -3541,8 +5099,7 @@ var _ = Describe("JPD Commands", func() {
 			})
 
 			It("should handle yarn with both interactive and latest flags", func() {
-				DebugExecutorExpectationManager.ExpectLockfileDetected(detect.YARN_LOCK)
-				DebugExecutorExpectationManager.ExpectPMDetectedFromLockfile(detect.YARN)
+				DebugExecutorExpectationManager.ExpectCommonPathDetectionFlow(detect.YARN)
 				DebugExecutorExpectationManager.ExpectJSCommandLog("yarn", "upgrade-interactive", "--latest")
 				_, err := executeCmd(yarnRootCmd, "update", "--interactive", "--latest")
 				assert.NoError(err)
@@ -3940,6 +5497,62 @@ var _ = Describe("JPD Commands", func() {
 
 					})
 
+				It(
+					"should uninstall selected packages from a deno.jsonc with comments",
+					func() {
+						err := os.WriteFile("deno.jsonc", []byte(`{
+						// Dependencies imported by the app
+						"imports": {
+							"hono": "jsr:@hono/hono@^3.12.0", // web framework
+							/* "lodash": "npm:lodash@4.17.21", */
+							"std/": "https://deno.land/std@0.200.0/",
+						},
+					}`), os.ModePerm)
+						assert.NoError(err)
+						DeferCleanup(func() {
+							_ = os.Remove("deno.jsonc")
+						})
+
+						DebugExecutorExpectationManager.ExpectLockfileDetected(detect.DENO_JSONC)
+						DebugExecutorExpectationManager.ExpectPMDetectedFromLockfile(detect.DENO)
+						rootCmdForSelection := cmd.NewRootCmdForTesting(
+							cmd.Dependencies{
+								CommandRunnerGetter: func() cmd.CommandRunner {
+									return mockCommandRunner
+								},
+								DetectLockfile: func(targetDir string) (lockfile string, err error) {
+									return detect.DENO_JSONC, nil
+								},
+								NewDebugExecutor: func(bool) cmd.DebugExecutor {
+									return factory.DebugExecutor()
+								},
+								DetectJSPackageManagerBasedOnLockFile: func(detectedLockFile string) (string, error) {
+									return detect.DENO, nil
+								},
+								DetectVolta: func() bool {
+									return false
+								},
+								YarnCommandVersionOutputter: mock.NewMockYarnCommandVersionOutputer("1.0.0"),
+								NewPackageMultiSelectUI:     mock.NewMockPackageMultiSelectUI,
+								NewTaskSelectorUI:           mock.NewMockTaskSelectUI,
+								NewDependencyMultiSelectUI:  mock.NewMockDependencySelectUI,
+							},
+						)
+
+						DebugExecutorExpectationManager.ExpectJSCommandRandomLog()
+						_, cmdErr := executeCmd(rootCmdForSelection, "uninstall", "--interactive")
+
+						assert.NoError(cmdErr)
+						assert.True(mockCommandRunner.HasBeenCalled)
+						assert.True(
+							lo.Some(
+								[]string{"jsr:@hono/hono@^3.12.0", "https://deno.land/std@0.200.0/"},
+								mockCommandRunner.CommandCall.Args,
+							),
+						)
+						assert.NotContains(mockCommandRunner.CommandCall.Args, "npm:lodash@4.17.21")
+					})
+
 			},
 		)
 
@@ -4659,6 +6272,41 @@ var _ = Describe("JPD Commands", func() {
 				assert.Equal([]string{"--version"}, mockCommandRunner.CommandCall.Args)
 			})
 		})
+
+		Context("When only an ancestor directory has a lock file", func() {
+			var nestedDir string
+
+			BeforeEach(func() {
+				workspaceRoot := GinkgoT().TempDir()
+				nestedDir = filepath.Join(workspaceRoot, "packages", "web")
+				assert.NoError(os.MkdirAll(nestedDir, 0o755))
+				assert.NoError(os.WriteFile(filepath.Join(workspaceRoot, detect.PNPM_LOCK_YAML), []byte(""), 0o644))
+			})
+
+			It("detects pnpm from the ancestor lock file", func() {
+				currentRootCmd := factory.CreateRootCmdWith(testutil.AncestorLockfileDetection(detect.NPM))
+				DebugExecutorExpectationManager.ExpectJSCommandLog("pnpm", "install")
+
+				_, err := executeCmd(currentRootCmd, "--cwd", nestedDir+"/", "install")
+				assert.NoError(err)
+				assert.True(mockCommandRunner.HasCommand("pnpm", "install"))
+				factory.DebugExecutor().AssertCalled(
+					GinkgoT(),
+					"LogDebugMessageIfDebugIsTrue",
+					"Lock file is detected in an ancestor directory",
+					"path", filepath.Join(filepath.Dir(filepath.Dir(nestedDir)), detect.PNPM_LOCK_YAML),
+				)
+			})
+
+			It("keeps the strict behavior with --no-ancestor-search", func() {
+				currentRootCmd := factory.CreateRootCmdWith(testutil.AncestorLockfileDetection(detect.NPM))
+				DebugExecutorExpectationManager.ExpectJSCommandLog("npm", "install")
+
+				_, err := executeCmd(currentRootCmd, "--cwd", nestedDir+"/", "--no-ancestor-search", "install")
+				assert.NoError(err)
+				assert.True(mockCommandRunner.HasCommand("npm", "install"))
+			})
+		})
 	})
 
 	const CommandIntegration = "Command Integration"
@@ -4696,7 +6344,7 @@ var _ = Describe("JPD Commands", func() {
 					userCommands++
 				}
 			}
-			assert.Equal(11, userCommands)
+			assert.Equal(25, userCommands)
 		})
 	})
 
@@ -0,0 +1,101 @@
+// Package cmd provides command-line interface implementations for the JavaScript package delegator.
+package cmd
+
+import (
+	// standard library
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	// external
+	"github.com/charmbracelet/colorprofile"
+	"github.com/charmbracelet/fang"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
+	"github.com/muesli/termenv"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+)
+
+const (
+	_COLOR_FLAG  = "color"
+	COLOR_AUTO   = "auto"
+	COLOR_ALWAYS = "always"
+	COLOR_NEVER  = "never"
+)
+
+var colorModes = []string{COLOR_AUTO, COLOR_ALWAYS, COLOR_NEVER}
+
+// resolveColorProfile decides how JPD colors its own output written to w.
+// always and never win over the environment, auto honours NO_COLOR first,
+// then FORCE_COLOR, then whether w is a terminal.
+func resolveColorProfile(mode string, w io.Writer, environ []string) (colorprofile.Profile, error) {
+	switch mode {
+	case COLOR_ALWAYS:
+		return colorprofile.TrueColor, nil
+	case COLOR_NEVER:
+		return colorprofile.NoTTY, nil
+	case COLOR_AUTO, "":
+	default:
+		return colorprofile.NoTTY, fmt.Errorf("the --%s flag must be one of %v", _COLOR_FLAG, colorModes)
+	}
+
+	env := lo.SliceToMap(environ, func(entry string) (string, string) {
+		key, value, _ := strings.Cut(entry, "=")
+		return key, value
+	})
+
+	if env["NO_COLOR"] != "" {
+		return colorprofile.NoTTY, nil
+	}
+
+	// FORCE_COLOR follows the levels used by Node.js tooling
+	if force := env["FORCE_COLOR"]; force != "" {
+		switch force {
+		case "0", "false":
+		case "2":
+			return colorprofile.ANSI256, nil
+		case "3":
+			return colorprofile.TrueColor, nil
+		default:
+			return colorprofile.ANSI, nil
+		}
+	}
+
+	return colorprofile.Detect(w, environ), nil
+}
+
+// applyColorProfile makes the logger and the interactive prompts use profile.
+// Commands run by the package manager write to the terminal directly and are not affected.
+func applyColorProfile(profile colorprofile.Profile) {
+	termenvProfile := map[colorprofile.Profile]termenv.Profile{
+		colorprofile.NoTTY:     termenv.Ascii,
+		colorprofile.Ascii:     termenv.Ascii,
+		colorprofile.ANSI:      termenv.ANSI,
+		colorprofile.ANSI256:   termenv.ANSI256,
+		colorprofile.TrueColor: termenv.TrueColor,
+	}[profile]
+
+	log.SetColorProfile(termenvProfile)
+	lipgloss.SetColorProfile(termenvProfile)
+}
+
+// NewErrorHandler returns a fang error handler that colors errors according to the --color flag of root.
+func NewErrorHandler(root *cobra.Command) fang.ErrorHandler {
+	return func(w io.Writer, styles fang.Styles, err error) {
+		if colorWriter, ok := w.(*colorprofile.Writer); ok {
+			mode := ""
+			if flag := root.PersistentFlags().Lookup(_COLOR_FLAG); flag != nil {
+				mode = flag.Value.String()
+			}
+
+			// An invalid mode is reported as the error itself, the detected profile is kept for it
+			if profile, profileErr := resolveColorProfile(mode, colorWriter.Forward, os.Environ()); profileErr == nil {
+				colorWriter.Profile = profile
+			}
+		}
+
+		fang.DefaultErrorHandler(w, styles, err)
+	}
+}
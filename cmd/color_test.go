@@ -0,0 +1,88 @@
+package cmd_test
+
+import (
+	"bytes"
+	"os"
+
+	"github.com/charmbracelet/log"
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/louiss0/javascript-package-delegator/mock"
+	"github.com/louiss0/javascript-package-delegator/testutil"
+)
+
+var _ = Describe("Color flag", func() {
+	assert := assert.New(GinkgoT())
+
+	const ansiEscape = "\x1b["
+
+	var (
+		mockRunner *mock.MockCommandRunner
+		factory    *testutil.RootCommandFactory
+		root       *cobra.Command
+	)
+
+	// logAfterRunning runs the command and returns what JPD's logger prints afterwards.
+	logAfterRunning := func(args ...string) string {
+		logs := new(bytes.Buffer)
+		log.SetOutput(logs)
+		defer log.SetOutput(os.Stderr)
+
+		_, err := executeCmd(root, args...)
+		assert.NoError(err)
+
+		log.Warn("Package manager indicated by lock file is not installed")
+		return logs.String()
+	}
+
+	BeforeEach(func() {
+		GinkgoT().Setenv("NO_COLOR", "")
+		GinkgoT().Setenv("FORCE_COLOR", "")
+
+		mockRunner = mock.NewMockCommandRunner()
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+
+		root = factory.CreateNpmAsDefault(nil)
+	})
+
+	It("strips ANSI from JPD messages with --color=never", func() {
+		output := logAfterRunning("--color=never", "agent")
+		assert.Contains(output, "Package manager indicated by lock file is not installed")
+		assert.NotContains(output, ansiEscape)
+	})
+
+	It("colors JPD messages with --color=always", func() {
+		assert.Contains(logAfterRunning("--color=always", "agent"), ansiEscape)
+	})
+
+	It("does not color output that is not a terminal by default", func() {
+		assert.NotContains(logAfterRunning("agent"), ansiEscape)
+	})
+
+	It("colors output when FORCE_COLOR is set", func() {
+		GinkgoT().Setenv("FORCE_COLOR", "1")
+		assert.Contains(logAfterRunning("agent"), ansiEscape)
+	})
+
+	It("prefers NO_COLOR over FORCE_COLOR", func() {
+		GinkgoT().Setenv("FORCE_COLOR", "1")
+		GinkgoT().Setenv("NO_COLOR", "1")
+		assert.NotContains(logAfterRunning("agent"), ansiEscape)
+	})
+
+	It("lets --color=always override NO_COLOR", func() {
+		GinkgoT().Setenv("NO_COLOR", "1")
+		assert.Contains(logAfterRunning("--color=always", "agent"), ansiEscape)
+	})
+
+	It("rejects an unknown value", func() {
+		_, err := executeCmd(root, "--color=sometimes", "agent")
+		assert.ErrorContains(err, "the --color flag must be one of [auto always never]")
+	})
+})
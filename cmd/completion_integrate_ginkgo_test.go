@@ -1,12 +1,15 @@
 package cmd_test
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
 
 	. "github.com/onsi/ginkgo/v2"
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/louiss0/javascript-package-delegator/cmd"
 	"github.com/louiss0/javascript-package-delegator/detect"
 	"github.com/louiss0/javascript-package-delegator/mock"
 	"github.com/louiss0/javascript-package-delegator/testutil"
@@ -34,6 +37,32 @@ var _ = Describe("Completion (Ginkgo conversion)", func() {
 		assert.Contains(GinkgoT(), out, "nushell")
 	})
 
+	It("completes --agent with the supported package managers", func() {
+		mockRunner := mock.NewMockCommandRunner()
+		factory := testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+
+		root := factory.CreateNpmAsDefault(nil)
+
+		complete, ok := root.GetFlagCompletionFunc(cmd.AGENT_FLAG)
+		assert.True(GinkgoT(), ok)
+
+		agents, directive := complete(root, nil, "")
+		assert.ElementsMatch(GinkgoT(), []string{"npm", "pnpm", "yarn", "bun", "deno"}, agents)
+		assert.Equal(GinkgoT(), cobra.ShellCompDirectiveNoFileComp, directive)
+
+		// The flag is persistent, so subcommands complete it too
+		out := new(bytes.Buffer)
+		root.SetOut(out)
+		root.SetErr(new(bytes.Buffer))
+		root.SetArgs([]string{cobra.ShellCompRequestCmd, "install", "--agent", ""})
+		assert.NoError(GinkgoT(), root.Execute())
+		assert.Equal(GinkgoT(), "deno\nbun\npnpm\nyarn\nnpm\n:4\n", out.String())
+	})
+
 	It("generates per-shell scripts", func() {
 		mockRunner := mock.NewMockCommandRunner()
 		factory := testutil.NewRootCommandFactory(mockRunner)
@@ -0,0 +1,112 @@
+// Package cmd provides command-line interface implementations for the JavaScript package delegator.
+package cmd
+
+import (
+	// standard library
+	"fmt"
+	"strings"
+
+	// external
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+
+	// internal
+	"github.com/louiss0/javascript-package-delegator/custom_errors"
+	"github.com/louiss0/javascript-package-delegator/detect"
+)
+
+// NewConfigCmd creates the config command group that passes get/set through to the detected package manager.
+func NewConfigCmd() *cobra.Command {
+	configCmd := &cobra.Command{
+		Use:   "config",
+		Short: "Read and write package manager configuration",
+		Long: `Read and write configuration using the detected package manager.
+
+Package Manager Behavior:
+- npm:  Runs 'npm config get|set'
+- pnpm: Runs 'pnpm config get|set'
+- yarn: Runs 'yarn config get|set'
+- bun:  Not supported, bun reads its configuration from bunfig.toml
+- deno: Not supported
+
+Examples:
+  jpd config get registry
+  jpd config set registry https://registry.npmjs.org/`,
+		DisableFlagsInUseLine: true,
+	}
+
+	configCmd.AddCommand(NewConfigGetCmd())
+	configCmd.AddCommand(NewConfigSetCmd())
+
+	return configCmd
+}
+
+// NewConfigGetCmd creates the "config get" subcommand.
+func NewConfigGetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "get <key>",
+		Short: "Print a configuration value",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return custom_errors.CreateInvalidArgumentErrorWithMessage(
+					"config get requires exactly one key",
+				)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfigCommand(cmd, "get", args)
+		},
+	}
+}
+
+// NewConfigSetCmd creates the "config set" subcommand.
+func NewConfigSetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Set a configuration value",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 2 {
+				return custom_errors.CreateInvalidArgumentErrorWithMessage(
+					"config set requires both a key and a value",
+				)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfigCommand(cmd, "set", args)
+		},
+	}
+}
+
+func runConfigCommand(cmd *cobra.Command, action string, args []string) error {
+	pm, err := cmd.Flags().GetString(AGENT_FLAG)
+	if err != nil {
+		return fmt.Errorf("failed to get agent flag: %w", err)
+	}
+
+	goEnv := getGoEnvFromCommandContext(cmd)
+	cmdRunner := getCommandRunnerFromCommandContext(cmd)
+	de := getDebugExecutorFromCommandContext(cmd)
+
+	switch pm {
+	case detect.NPM, detect.PNPM, detect.YARN:
+	case detect.BUN:
+		return fmt.Errorf("bun does not support config %s, edit bunfig.toml instead", action)
+	case detect.DENO:
+		return fmt.Errorf("deno does not support the config command")
+	default:
+		return unsupportedPackageManagerError(pm)
+	}
+
+	cmdArgs := append([]string{"config", action}, args...)
+
+	de.LogJSCommandIfDebugIsTrue(pm, cmdArgs...)
+	cmdRunner.Command(pm, cmdArgs...)
+
+	goEnv.ExecuteIfModeIsProduction(func() {
+		log.Info("Running command", "pm", pm, "args", strings.Join(cmdArgs, " "))
+	})
+
+	return cmdRunner.Run()
+}
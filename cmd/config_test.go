@@ -0,0 +1,98 @@
+package cmd_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/louiss0/javascript-package-delegator/detect"
+	"github.com/louiss0/javascript-package-delegator/mock"
+	"github.com/louiss0/javascript-package-delegator/testutil"
+)
+
+var _ = Describe("Config Command", func() {
+	assert := assert.New(GinkgoT())
+
+	var (
+		mockRunner *mock.MockCommandRunner
+		factory    *testutil.RootCommandFactory
+	)
+
+	BeforeEach(func() {
+		mockRunner = mock.NewMockCommandRunner()
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+	})
+
+	rootFor := func(pm string) *cobra.Command {
+		switch pm {
+		case detect.PNPM:
+			return factory.CreatePnpmAsDefault(nil)
+		case detect.YARN:
+			return factory.CreateYarnTwoAsDefault(nil)
+		case detect.BUN:
+			return factory.CreateBunAsDefault(nil)
+		case detect.DENO:
+			return factory.CreateDenoAsDefault(nil)
+		default:
+			return factory.CreateNpmAsDefault(nil)
+		}
+	}
+
+	DescribeTable("passes config get through to the package manager",
+		func(pm string) {
+			testutil.DebugExecutorExpectationManager.ExpectJSCommandLog(pm, "config", "get", "registry")
+
+			_, err := executeCmd(rootFor(pm), "config", "get", "registry")
+			assert.NoError(err)
+			assert.True(mockRunner.HasCommand(pm, "config", "get", "registry"))
+		},
+		Entry("npm", detect.NPM),
+		Entry("pnpm", detect.PNPM),
+		Entry("yarn", detect.YARN),
+	)
+
+	DescribeTable("passes config set through to the package manager",
+		func(pm string) {
+			testutil.DebugExecutorExpectationManager.ExpectJSCommandLog(pm, "config", "set", "registry", "https://npm.example.com")
+
+			_, err := executeCmd(rootFor(pm), "config", "set", "registry", "https://npm.example.com")
+			assert.NoError(err)
+			assert.True(mockRunner.HasCommand(pm, "config", "set", "registry", "https://npm.example.com"))
+		},
+		Entry("npm", detect.NPM),
+		Entry("pnpm", detect.PNPM),
+		Entry("yarn", detect.YARN),
+	)
+
+	It("explains that bun has no config command", func() {
+		_, err := executeCmd(rootFor(detect.BUN), "config", "get", "registry")
+		assert.Error(err)
+		assert.Contains(err.Error(), "bunfig.toml")
+		assert.False(mockRunner.HasBeenCalled)
+	})
+
+	It("returns an error for deno", func() {
+		_, err := executeCmd(rootFor(detect.DENO), "config", "set", "registry", "https://npm.example.com")
+		assert.Error(err)
+		assert.Contains(err.Error(), "deno does not support the config command")
+		assert.False(mockRunner.HasBeenCalled)
+	})
+
+	It("requires both a key and a value for set", func() {
+		_, err := executeCmd(rootFor(detect.NPM), "config", "set", "registry")
+		assert.Error(err)
+		assert.Contains(err.Error(), "config set requires both a key and a value")
+		assert.False(mockRunner.HasBeenCalled)
+	})
+
+	It("requires a key for get", func() {
+		_, err := executeCmd(rootFor(detect.NPM), "config", "get")
+		assert.Error(err)
+		assert.Contains(err.Error(), "config get requires exactly one key")
+		assert.False(mockRunner.HasBeenCalled)
+	})
+})
@@ -0,0 +1,56 @@
+// Package cmd provides command-line interface implementations for the JavaScript package delegator.
+package cmd
+
+import (
+	// standard library
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	// external
+	"github.com/samber/lo"
+
+	// internal
+	"github.com/louiss0/javascript-package-delegator/detect"
+)
+
+const _COREPACK_FLAG = "corepack"
+
+// corepackCommandRunner runs the agent through its corepack shim, so the version pinned
+// by the packageManager field is used instead of whatever is installed globally.
+type corepackCommandRunner struct {
+	CommandRunner
+	agent func() string
+}
+
+func (c corepackCommandRunner) Command(name string, args ...string) {
+	agent := c.agent()
+	if agent == "" || name != agent || !lo.Contains([]string{detect.NPM, detect.PNPM, detect.YARN}, agent) {
+		c.CommandRunner.Command(name, args...)
+		return
+	}
+
+	c.CommandRunner.Command(detect.COREPACK, append([]string{name}, args...)...)
+}
+
+// readPackageManagerFieldFrom returns the packageManager field of the package.json in dir.
+// An empty string is returned when there is no package.json or the field is not set.
+func readPackageManagerFieldFrom(dir string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	var pkg struct {
+		PackageManager string `json:"packageManager"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return "", fmt.Errorf("failed to parse package.json: %w", err)
+	}
+
+	return pkg.PackageManager, nil
+}
@@ -0,0 +1,118 @@
+package cmd_test
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/louiss0/javascript-package-delegator/cmd"
+	"github.com/louiss0/javascript-package-delegator/detect"
+	"github.com/louiss0/javascript-package-delegator/mock"
+	"github.com/louiss0/javascript-package-delegator/testutil"
+)
+
+var _ = Describe("Corepack", func() {
+	assert := assert.New(GinkgoT())
+
+	var (
+		mockRunner *mock.MockCommandRunner
+		factory    *testutil.RootCommandFactory
+		dir        string
+	)
+
+	writePackageJSON := func(content string) {
+		assert.NoError(os.WriteFile(filepath.Join(dir, "package.json"), []byte(content), 0o644))
+	}
+
+	BeforeEach(func() {
+		mockRunner = mock.NewMockCommandRunner()
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+
+		dir = GinkgoT().TempDir()
+		writePackageJSON(`{"packageManager": "pnpm@9.1.0"}`)
+	})
+
+	It("runs the package manager through corepack with --corepack", func() {
+		root := factory.CreateRootCmdWith(testutil.LockfileDetected(detect.PNPM, detect.PNPM_LOCK_YAML), func(deps *cmd.Dependencies) {
+			deps.DetectCorepack = func() bool {
+				return true
+			}
+		})
+
+		_, err := executeCmd(root, "install", "--cwd", dir+"/", "--corepack")
+		assert.NoError(err)
+		assert.True(mockRunner.HasCommand("corepack", "pnpm", "install"))
+	})
+
+	It("runs the package manager directly without --corepack", func() {
+		root := factory.CreateRootCmdWith(testutil.LockfileDetected(detect.PNPM, detect.PNPM_LOCK_YAML), func(deps *cmd.Dependencies) {
+			deps.DetectCorepack = func() bool {
+				return true
+			}
+		})
+
+		_, err := executeCmd(root, "install", "--cwd", dir+"/")
+		assert.NoError(err)
+		assert.True(mockRunner.HasCommand("pnpm", "install"))
+	})
+
+	It("runs the package manager directly when the packageManager field is not set", func() {
+		writePackageJSON(`{"name": "app"}`)
+		root := factory.CreateRootCmdWith(testutil.LockfileDetected(detect.PNPM, detect.PNPM_LOCK_YAML), func(deps *cmd.Dependencies) {
+			deps.DetectCorepack = func() bool {
+				return true
+			}
+		})
+
+		_, err := executeCmd(root, "install", "--cwd", dir+"/", "--corepack")
+		assert.NoError(err)
+		assert.True(mockRunner.HasCommand("pnpm", "install"))
+	})
+
+	It("leaves package managers corepack does not manage alone", func() {
+		root := factory.CreateRootCmdWith(testutil.LockfileDetected(detect.BUN, detect.BUN_LOCKB), func(deps *cmd.Dependencies) {
+			deps.DetectCorepack = func() bool {
+				return true
+			}
+		})
+
+		_, err := executeCmd(root, "install", "--cwd", dir+"/", "--corepack")
+		assert.NoError(err)
+		assert.True(mockRunner.HasCommand("bun", "install"))
+	})
+
+	It("starts the script of run --timeout through corepack", func() {
+		writePackageJSON(`{"packageManager": "pnpm@9.1.0", "scripts": {"test": "vitest run"}}`)
+		scripts := &testutil.BlockingScripts{FinishIn: time.Millisecond}
+		root := factory.CreateRootCmdWith(testutil.LockfileDetected(detect.PNPM, detect.PNPM_LOCK_YAML), func(deps *cmd.Dependencies) {
+			deps.DetectCorepack = func() bool {
+				return true
+			}
+			deps.NewParallelCommandRunner = scripts.NewRunner
+		})
+
+		_, err := executeCmd(root, "run", "--cwd", dir+"/", "--corepack", "--timeout", "5s", "test")
+		assert.NoError(err)
+		assert.Equal([][]string{{"corepack", "pnpm", "run", "test"}}, scripts.Commands())
+		assert.False(mockRunner.HasBeenCalled)
+	})
+
+	It("returns an error when corepack is not in PATH", func() {
+		root := factory.CreateRootCmdWith(testutil.LockfileDetected(detect.PNPM, detect.PNPM_LOCK_YAML), func(deps *cmd.Dependencies) {
+			deps.DetectCorepack = func() bool {
+				return false
+			}
+		})
+
+		_, err := executeCmd(root, "install", "--cwd", dir+"/", "--corepack")
+		assert.ErrorContains(err, "the --corepack flag requires corepack to be in PATH")
+		assert.False(mockRunner.HasBeenCalled)
+	})
+})
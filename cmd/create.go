@@ -25,7 +25,13 @@ package cmd
 
 import (
 	// standard library
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 
 	// external
@@ -42,7 +48,18 @@ import (
 )
 
 // BuildCreateCommand builds command lines using each package manager's native create command.
+// For npm the arguments are placed after a single -- separator, any separators the user passed are dropped.
 func BuildCreateCommand(pm, yarnVersion, name string, args []string) (program string, argv []string, err error) {
+	return buildCreateCommand(pm, yarnVersion, name, args, true)
+}
+
+// BuildCreateCommandVerbatim builds the same command lines as BuildCreateCommand
+// but forwards the arguments exactly as given, npm gets no -- separator added or removed.
+func BuildCreateCommandVerbatim(pm, yarnVersion, name string, args []string) (program string, argv []string, err error) {
+	return buildCreateCommand(pm, yarnVersion, name, args, false)
+}
+
+func buildCreateCommand(pm, yarnVersion, name string, args []string, normalizeSeparator bool) (program string, argv []string, err error) {
 	_ = yarnVersion
 
 	if pm == "deno" {
@@ -64,16 +81,91 @@ func BuildCreateCommand(pm, yarnVersion, name string, args []string) (program st
 
 	switch pm {
 	case "npm":
+		if !normalizeSeparator {
+			return "npm", append([]string{"create", name}, args...), nil
+		}
+		// Users sometimes add the separator themselves, npm only needs the one inserted here
+		args = lo.Without(args, "--")
 		argv = append([]string{"create", name, "--"}, args...)
 		return "npm", argv, nil
 	case "pnpm", "yarn", "bun":
 		argv = append([]string{"create", name}, args...)
 		return pm, argv, nil
 	default:
-		return "", nil, fmt.Errorf("unsupported package manager: %s", pm)
+		return "", nil, unsupportedPackageManagerError(pm)
 	}
 }
 
+// ApplyCreateTemplateCache rewrites a create command so the package manager reuses its
+// cached copy of the scaffolder. It returns false when the package manager has no such option.
+func ApplyCreateTemplateCache(pm, yarnVersion string, argv []string) ([]string, bool) {
+	switch pm {
+	case "npm", "pnpm":
+	case "yarn":
+		// Yarn v2+ dlx always fetches into a temporary project
+		if ParseYarnMajor(yarnVersion) >= 2 {
+			return argv, false
+		}
+	default:
+		return argv, false
+	}
+
+	// argv starts with "create"; the cache option must precede the scaffolder name
+	return lo.Flatten([][]string{argv[:1], {"--prefer-offline"}, argv[1:]}), true
+}
+
+// StarterTemplates are the templates a starter offers and the flag that picks one of them.
+type StarterTemplates struct {
+	Flag      string
+	Templates []string
+}
+
+// knownStarterTemplates holds the templates of popular starters, keyed by the name without the create- prefix.
+var knownStarterTemplates = map[string]StarterTemplates{
+	"vite": {
+		Flag: "--template",
+		Templates: []string{
+			"vanilla", "vanilla-ts", "vue", "vue-ts", "react", "react-ts", "react-swc", "react-swc-ts",
+			"preact", "preact-ts", "lit", "lit-ts", "svelte", "svelte-ts", "solid", "solid-ts", "qwik", "qwik-ts",
+		},
+	},
+	"next-app": {
+		Flag:      "--example",
+		Templates: []string{"hello-world", "blog-starter", "with-docker", "with-mdx", "with-supabase", "with-tailwindcss"},
+	},
+	"astro": {
+		Flag:      "--template",
+		Templates: []string{"basics", "blog", "minimal", "portfolio", "starlight", "starlog"},
+	},
+}
+
+// LookupStarterTemplates returns the known templates of a starter given the way create takes it,
+// with or without the create- prefix and a version.
+func LookupStarterTemplates(starter string) (StarterTemplates, bool) {
+	name := ParsePackageNames([]string{starter})[0]
+	templates, found := knownStarterTemplates[strings.TrimPrefix(name, "create-")]
+	return templates, found
+}
+
+// printStarterTemplates writes the known templates of starter one per line.
+func printStarterTemplates(w io.Writer, starter string) error {
+	templates, found := LookupStarterTemplates(starter)
+	if !found {
+		_, err := fmt.Fprintf(w, "templates not known for this starter: %s\n", starter)
+		return err
+	}
+
+	if _, err := fmt.Fprintf(w, "Templates of %s, pick one with %s:\n", starter, templates.Flag); err != nil {
+		return err
+	}
+	for _, template := range templates.Templates {
+		if _, err := fmt.Fprintf(w, "  %s\n", template); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // CreateAppSelector provides an interface for selecting a create app package.
 // It follows Go Writing Philosophy: defined at point of use, with clean methods.
 type CreateAppSelector interface {
@@ -128,7 +220,11 @@ func (s createAppSelector) Value() string {
 }
 
 // NewCreateCmd creates a new Cobra command for the "create" functionality
-func NewCreateCmd(searcher CreateAppSearcher, newCreateAppSelector func([]services.PackageInfo) CreateAppSelector) *cobra.Command {
+func NewCreateCmd(
+	searcher CreateAppSearcher,
+	newCreateAppSelector func([]services.PackageInfo) CreateAppSelector,
+	newConfirmUI func(title string) UIConfirmer,
+) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "create [name|url] [args...]",
 		Short: "Scaffold a new project using create runners",
@@ -145,10 +241,20 @@ Package Manager Behavior:
 JPD flags (for this command):
   --search, -s    Search npm for popular "create-*" packages and select interactively
   --size <n>      Number of results to show when using --search (default: 25)
+  --cache-template  Reuse the package manager's cached copy of the scaffolder (npm, pnpm, yarn v1)
+  --list-templates  Print the known templates of the starter (vite, next-app, astro) without scaffolding
+  --retries <n>   Run the command again up to n times with exponential backoff when it fails
+  --no-separator-normalize  Forward arguments exactly as given, npm gets no -- separator added or removed
+  --force         Scaffold into a directory that isn't empty without asking first
+
+The directory named after the starter is checked before anything runs. When it exists and isn't
+empty jpd asks before scaffolding into it, --quiet turns the question into an error.
+Pass --force after a -- separator to forward it to the scaffolder instead.
 
 Passing flags to scaffolding tools:
 - npm: JPD automatically inserts the -- separator before the app name so flags go to the scaffolder.
        Do not add another -- yourself; if you do, JPD will normalize it.
+       Pass --no-separator-normalize to forward the arguments untouched instead.
 - pnpm / yarn / bun: pass flags directly after your app name.
 - deno: pass arguments directly after the URL.
 
@@ -156,6 +262,8 @@ Examples:
   jpd create react-app my-app
   jpd create vite@latest my-app -- --template react-swc
   jpd create next-app myapp --typescript --tailwind
+  jpd create vite --list-templates
+  jpd create vite my-app --force   # my-app already has files, scaffold into it anyway
   jpd -a deno create https://deno.land/x/fresh/init.ts my-fresh-app`,
 		Aliases: []string{"c"},
 		// Allow passing through unknown flags (e.g., flags intended for the underlying create tools)
@@ -167,8 +275,14 @@ Examples:
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Manually parse flags since we disabled flag parsing
 			search := false
+			cacheTemplate := false
+			listTemplates := false
+			force := false
+			normalizeSeparator := true
+			retries := 0
 			size := 0
 			createAppQuery := ""
+			cwd := ""
 			packageArgs := []string{}
 
 			// Parse arguments manually to separate flags from create arguments
@@ -214,6 +328,35 @@ Examples:
 					} else {
 						return fmt.Errorf("--size requires a value")
 					}
+				case arg == "--cache-template":
+					cacheTemplate = true
+				case arg == "--list-templates":
+					listTemplates = true
+				case arg == "--force" && !lo.Contains(args[:i], "--"):
+					force = true
+				case arg == "-q" || arg == "--quiet":
+					// Flag parsing is disabled, so the root command never sees it
+					if err := cmd.Flags().Set(_QUIET_FLAG, "true"); err != nil {
+						return err
+					}
+				case arg == "--no-separator-normalize":
+					normalizeSeparator = false
+				case arg == "--retries" || strings.HasPrefix(arg, "--retries="):
+					value, hasValue := strings.CutPrefix(arg, "--retries=")
+					if !hasValue {
+						if i+1 >= len(args) {
+							return fmt.Errorf("--retries requires a value")
+						}
+						i++
+						value = args[i]
+					}
+					n, err := strconv.Atoi(value)
+					if err != nil {
+						return fmt.Errorf("invalid retries value: %s", value)
+					}
+					if retries, err = parseRetries(n); err != nil {
+						return err
+					}
 				case arg == "-h" || arg == "--help":
 					return cmd.Help()
 				// Skip global flags - they're handled by the root command
@@ -222,15 +365,17 @@ Examples:
 						i++ // skip the value
 					}
 				case arg == "-C" || arg == "--cwd":
-					if arg == "-C" || arg == "--cwd" {
-						i++ // skip the value
+					if i+1 >= len(args) {
+						return fmt.Errorf("flag needs an argument: --%s", _CWD_FLAG)
 					}
+					i++
+					cwd = args[i]
 				case arg == "-d" || arg == "--debug":
 					// skip debug flag
 				case strings.HasPrefix(arg, "-a=") || strings.HasPrefix(arg, "--agent="):
 					// skip combined flag=value
 				case strings.HasPrefix(arg, "-C=") || strings.HasPrefix(arg, "--cwd="):
-					// skip combined flag=value
+					_, cwd, _ = strings.Cut(arg, "=")
 				default:
 					// This is either the package name or arguments to pass through
 					if createAppQuery == "" {
@@ -250,6 +395,16 @@ Examples:
 				return fmt.Errorf("when using the --search flag, you cannot pass any other arguments")
 			}
 
+			if listTemplates {
+				if search {
+					return fmt.Errorf("the --list-templates flag cannot be combined with --search")
+				}
+				if createAppQuery == "" {
+					return fmt.Errorf("the --list-templates flag requires a starter name")
+				}
+				return printStarterTemplates(cmd.OutOrStdout(), createAppQuery)
+			}
+
 			if !search && createAppQuery == "" {
 				return fmt.Errorf("requires at least 1 arg(s), only received 0")
 			}
@@ -287,6 +442,9 @@ Examples:
 				}
 
 				// Use new CreateAppSelector implementation
+				if err := errIfQuiet(cmd, "choosing a create package"); err != nil {
+					return err
+				}
 				selector := newCreateAppSelector(packageInfo)
 
 				if err := selector.Run(); err != nil {
@@ -319,6 +477,9 @@ Examples:
 				}
 
 				// Use new CreateAppSelector implementation
+				if err := errIfQuiet(cmd, "choosing a create package"); err != nil {
+					return err
+				}
 				selector := newCreateAppSelector(packageInfo)
 
 				if err := selector.Run(); err != nil {
@@ -329,6 +490,34 @@ Examples:
 				createAppQuery = strings.Split(chosen, " ")[0]
 			}
 
+			// Flag parsing is disabled, so the root command never sees --cwd either
+			targetDir := ""
+			if cwd != "" {
+				absCwd, err := filepath.Abs(cwd)
+				if err != nil {
+					return fmt.Errorf("failed to resolve the --%s flag: %w", _CWD_FLAG, err)
+				}
+				targetDir = withTrailingSeparator(absCwd)
+				if err := cmd.Flags().Set(_CWD_FLAG, targetDir); err != nil {
+					return err
+				}
+				if err := cmdRunner.SetTargetDir(targetDir); err != nil {
+					return err
+				}
+			}
+
+			if !force {
+				dir := createTargetDir(packageArgs)
+				if dir != "" && targetDir != "" && !filepath.IsAbs(dir) {
+					dir = filepath.Join(targetDir, dir)
+				}
+
+				proceed, err := confirmCreateTarget(cmd, newConfirmUI, dir)
+				if err != nil || !proceed {
+					return err
+				}
+			}
+
 			// Keep yarnVersion in the call signature for compatibility.
 			yarnVersion := ""
 			if pm == "yarn" {
@@ -339,25 +528,23 @@ Examples:
 				}
 			}
 
-			// Normalize extra "--" for npm (users sometimes add it themselves).
-			// npm mapping already injects one "--" internally, so remove any user-provided separators.
-			if pm == "npm" && len(packageArgs) > 0 {
-				filtered := make([]string, 0, len(packageArgs))
-				for _, a := range packageArgs {
-					if a == "--" {
-						continue // drop
-					}
-					filtered = append(filtered, a)
-				}
-				packageArgs = filtered
-			}
-
 			// Build command for creating projects
-			execCommand, cmdArgs, err := BuildCreateCommand(pm, yarnVersion, createAppQuery, packageArgs)
+			buildCommand := lo.Ternary(normalizeSeparator, BuildCreateCommand, BuildCreateCommandVerbatim)
+			execCommand, cmdArgs, err := buildCommand(pm, yarnVersion, createAppQuery, packageArgs)
 			if err != nil {
 				return err
 			}
 
+			if cacheTemplate {
+				var supported bool
+				cmdArgs, supported = ApplyCreateTemplateCache(pm, yarnVersion, cmdArgs)
+				if !supported {
+					if err := printNote(cmd, "%s has no cache option for create templates, --cache-template is ignored", pm); err != nil {
+						return err
+					}
+				}
+			}
+
 			// Execute the command
 			de.LogJSCommandIfDebugIsTrue(execCommand, cmdArgs...)
 			cmdRunner.Command(execCommand, cmdArgs...)
@@ -366,13 +553,58 @@ Examples:
 				log.Info("Running command", "cmd", execCommand, "args", strings.Join(cmdArgs, " "))
 			})
 
-			return cmdRunner.Run()
+			return runWithRetries(cmd, cmdRunner, retries)
 		},
 	}
 
 	// Add help-visible flags (parsing remains manual to allow passthrough)
 	cmd.Flags().BoolP("search", "s", false, "Search npm for create packages (interactive)")
 	cmd.Flags().Int("size", 25, "Number of results to show with --search")
+	cmd.Flags().Bool("cache-template", false, "Reuse the package manager's cached copy of the scaffolder")
+	cmd.Flags().Bool("list-templates", false, "Print the known templates of the starter without scaffolding")
+	cmd.Flags().Bool(_FORCE_FLAG, false, "Scaffold into a directory that isn't empty without asking first")
+	addRetriesFlag(cmd)
 
 	return cmd
 }
+
+// createTargetDir returns the directory the scaffolder writes to, the first argument after the starter
+// that isn't a flag. It returns "" when the arguments don't name one.
+func createTargetDir(packageArgs []string) string {
+	args := lo.Filter(packageArgs, func(arg string, _ int) bool { return arg != "--" })
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return ""
+	}
+	return args[0]
+}
+
+// confirmCreateTarget reports whether create may scaffold into dir. A missing or empty directory needs no
+// confirmation, otherwise the user is asked unless --quiet is set, which makes it an error instead.
+func confirmCreateTarget(cmd *cobra.Command, newConfirmUI func(title string) UIConfirmer, dir string) (bool, error) {
+	if dir == "" {
+		return true, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) || (err == nil && len(entries) == 0) {
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+
+	if err := errIfQuiet(cmd, "scaffolding into a directory that isn't empty"); err != nil {
+		return false, fmt.Errorf("%s is not empty, %w, pass --%s to scaffold into it anyway", dir, err, _FORCE_FLAG)
+	}
+
+	confirm := newConfirmUI(fmt.Sprintf("%s is not empty, scaffold into it anyway?", dir))
+	if err := confirm.Run(); err != nil {
+		return false, err
+	}
+
+	if !confirm.Value() {
+		_, err := fmt.Fprintln(cmd.OutOrStdout(), "Create cancelled, nothing was changed")
+		return false, err
+	}
+	return true, nil
+}
@@ -71,6 +71,10 @@ func (f *FakeCommandRunnerCwd) SetTargetDir(dir string) error {
 	return nil
 }
 
+func (f *FakeCommandRunnerCwd) SetEnv(env []string) {}
+
+func (f *FakeCommandRunnerCwd) ClearEnv() {}
+
 func (f *FakeCommandRunnerCwd) Run() error {
 	return nil
 }
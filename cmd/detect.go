@@ -0,0 +1,81 @@
+// Package cmd provides command-line interface implementations for the JavaScript package delegator.
+package cmd
+
+import (
+	// standard library
+	"encoding/json"
+	"fmt"
+	"os"
+
+	// external
+	"github.com/spf13/cobra"
+)
+
+// DetectResult is the lock file and the package manager it belongs to, printed by the detect command.
+type DetectResult struct {
+	Lockfile       string `json:"lockfile"`
+	PackageManager string `json:"packageManager"`
+}
+
+// NewDetectCmd creates a new Cobra command that prints the lock file of the project and its package manager.
+// Unlike agent it never runs the package manager and unlike doctor it reports nothing else.
+func NewDetectCmd(
+	detectLockfile func(targetDir string) (lockfile string, err error),
+	detectJSPackageManagerBasedOnLockFile func(detectedLockFile string) (packageManager string, err error),
+) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "detect",
+		Short: "Print the detected lock file and package manager",
+		Long: `Print the lock file of the project and the package manager it belongs to, one per line.
+Nothing is run, so the output can be read by scripts.
+
+Examples:
+  jpd detect              # package-lock.json and npm on two lines
+  jpd detect --json       # {"lockfile": "package-lock.json", "packageManager": "npm"}
+  jpd detect --cwd app/   # Detect in another directory`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			asJSON, err := cmd.Flags().GetBool(_JSON_FLAG)
+			if err != nil {
+				return err
+			}
+
+			targetDir, err := cmd.Flags().GetString(_CWD_FLAG)
+			if err != nil {
+				return fmt.Errorf("failed to parse --%s flag: %w", _CWD_FLAG, err)
+			}
+			if targetDir == "" {
+				if targetDir, err = os.Getwd(); err != nil {
+					return fmt.Errorf("failed to get current working directory: %w", err)
+				}
+			}
+
+			cache := getDetectionCacheFromCommandContext(cmd)
+
+			lockfile, err := cache.Lockfile(targetDir, detectLockfile)
+			if err != nil {
+				return fmt.Errorf("no lock file found in %s: %w", targetDir, err)
+			}
+
+			pm, err := cache.PackageManager(targetDir, lockfile, detectJSPackageManagerBasedOnLockFile)
+			if err != nil {
+				return fmt.Errorf("failed to detect the package manager of %s: %w", lockfile, err)
+			}
+
+			result := DetectResult{Lockfile: lockfile, PackageManager: pm}
+
+			if asJSON {
+				encoder := json.NewEncoder(cmd.OutOrStdout())
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(result)
+			}
+
+			_, err = fmt.Fprintf(cmd.OutOrStdout(), "%s\n%s\n", result.Lockfile, result.PackageManager)
+			return err
+		},
+	}
+
+	cmd.Flags().Bool(_JSON_FLAG, false, "Print the lock file and package manager as JSON")
+
+	return cmd
+}
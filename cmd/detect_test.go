@@ -0,0 +1,98 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/louiss0/javascript-package-delegator/cmd"
+	"github.com/louiss0/javascript-package-delegator/detect"
+	"github.com/louiss0/javascript-package-delegator/mock"
+	"github.com/louiss0/javascript-package-delegator/testutil"
+)
+
+var _ = Describe("Detect Command", func() {
+	assert := assert.New(GinkgoT())
+
+	var (
+		mockRunner *mock.MockCommandRunner
+		factory    *testutil.RootCommandFactory
+		root       *cobra.Command
+	)
+
+	// projectWith returns a temp dir holding the given lock file
+	projectWith := func(lockfile string) string {
+		dir := GinkgoT().TempDir()
+		assert.NoError(os.WriteFile(filepath.Join(dir, "package.json"), []byte(`{"name": "app"}`), 0o644))
+		if lockfile != "" {
+			assert.NoError(os.WriteFile(filepath.Join(dir, lockfile), []byte(""), 0o644))
+		}
+		return dir + "/"
+	}
+
+	BeforeEach(func() {
+		mockRunner = mock.NewMockCommandRunner()
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+
+		// Every package manager is installed, so the lock file alone decides
+		pathLookup := mock.NewMockPathLookup()
+		for _, pm := range detect.SupportedJSPackageManagers {
+			pathLookup.ExpectedLookPathResults[pm] = struct {
+				Path  string
+				Error error
+			}{"/usr/local/bin/" + pm, nil}
+		}
+
+		root = factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.DetectLockfile = func(targetDir string) (string, error) {
+				return detect.DetectLockfileIn(targetDir, detect.RealFileSystem{})
+			}
+			deps.DetectJSPackageManagerBasedOnLockFile = func(lockfile string) (string, error) {
+				return detect.DetectJSPackageManagerBasedOnLockFile(lockfile, pathLookup)
+			}
+			deps.YarnCommandVersionOutputter = mock.NewMockYarnCommandVersionOutputer("1.22.19")
+			deps.PathLookup = pathLookup
+		})
+	})
+
+	DescribeTable("prints the lock file and package manager of the project",
+		func(lockfile, pm string) {
+			output, err := executeCmd(root, "detect", "--cwd", projectWith(lockfile))
+			assert.NoError(err)
+			assert.Equal(lockfile+"\n"+pm+"\n", output)
+			assert.False(mockRunner.HasBeenCalled)
+		},
+		Entry("npm", detect.PACKAGE_LOCK_JSON, detect.NPM),
+		Entry("pnpm", detect.PNPM_LOCK_YAML, detect.PNPM),
+		Entry("yarn", detect.YARN_LOCK, detect.YARN),
+		Entry("bun", detect.BUN_LOCKB, detect.BUN),
+		Entry("deno", detect.DENO_LOCK, detect.DENO),
+	)
+
+	It("prints JSON with --json", func() {
+		output, err := executeCmd(root, "detect", "--json", "--cwd", projectWith(detect.PNPM_LOCK_YAML))
+		assert.NoError(err)
+
+		var result cmd.DetectResult
+		assert.NoError(json.Unmarshal([]byte(output), &result))
+		assert.Equal(cmd.DetectResult{Lockfile: detect.PNPM_LOCK_YAML, PackageManager: detect.PNPM}, result)
+	})
+
+	It("returns an error when the directory has no lock file", func() {
+		dir := projectWith("")
+		// jpd itself still finds npm in PATH, detect only reports the lock file
+		root := factory.CreateRootCmdWithPathDetected(detect.NPM, nil, false)
+
+		_, err := executeCmd(root, "detect", "--cwd", dir)
+		assert.ErrorContains(err, "no lock file found in "+dir)
+		assert.False(mockRunner.HasBeenCalled)
+	})
+})
@@ -0,0 +1,98 @@
+package cmd_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/louiss0/javascript-package-delegator/cmd"
+	"github.com/louiss0/javascript-package-delegator/detect"
+	"github.com/louiss0/javascript-package-delegator/mock"
+	"github.com/louiss0/javascript-package-delegator/testutil"
+)
+
+var _ = Describe("Detection cache", func() {
+	assert := assert.New(GinkgoT())
+
+	var (
+		mockRunner    *mock.MockCommandRunner
+		factory       *testutil.RootCommandFactory
+		yarnOutputter *mock.MockYarnCommandVersionOutputer
+		lockfileCalls map[string]int
+		pmCalls       int
+		root          *cobra.Command
+	)
+
+	BeforeEach(func() {
+		mockRunner = mock.NewMockCommandRunner()
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+
+		lockfileCalls = map[string]int{}
+		pmCalls = 0
+		yarnOutputter = mock.NewMockYarnCommandVersionOutputer("4.1.0")
+
+		// doctor looks up every package manager, only yarn is installed
+		pathLookup := mock.NewMockPathLookup()
+		for _, pm := range detect.SupportedJSPackageManagers {
+			pathLookup.ExpectedLookPathResults[pm] = struct {
+				Path  string
+				Error error
+			}{"", fmt.Errorf("%s not found", pm)}
+		}
+		pathLookup.ExpectedLookPathResults[detect.YARN] = struct {
+			Path  string
+			Error error
+		}{"/usr/local/bin/yarn", nil}
+
+		root = factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.DetectLockfile = func(targetDir string) (string, error) {
+				lockfileCalls[targetDir]++
+				return detect.YARN_LOCK, nil
+			}
+			deps.DetectJSPackageManagerBasedOnLockFile = func(lockfile string) (string, error) {
+				pmCalls++
+				return detect.YARN, nil
+			}
+			deps.YarnCommandVersionOutputter = yarnOutputter
+			deps.PathLookup = pathLookup
+		})
+	})
+
+	It("detects the lock file once per directory when a command detects again", func() {
+		dir := GinkgoT().TempDir() + "/"
+
+		_, err := executeCmd(root, "doctor", "--cwd", dir)
+		assert.NoError(err)
+		assert.Equal(map[string]int{dir: 1}, lockfileCalls)
+		assert.Equal(1, pmCalls)
+	})
+
+	It("asks yarn for its version once per invocation", func() {
+		dir := GinkgoT().TempDir() + "/"
+		testutil.DebugExecutorExpectationManager.ExpectJSCommandLog("yarn", "add", "lodash")
+
+		_, err := executeCmd(root, "install", "--node-linker", "hoisted", "--registry", "https://npm.example.com", "lodash", "--cwd", dir)
+		assert.NoError(err)
+		yarnOutputter.AssertNumberOfCalls(GinkgoT(), "Output", 1)
+	})
+
+	It("detects again on the next invocation", func() {
+		dir := GinkgoT().TempDir() + "/"
+		testutil.DebugExecutorExpectationManager.ExpectJSCommandLog("yarn", "add", "lodash")
+
+		_, err := executeCmd(root, "install", "--registry", "https://npm.example.com", "lodash", "--cwd", dir)
+		assert.NoError(err)
+		_, err = executeCmd(root, "install", "--registry", "https://npm.example.com", "lodash", "--cwd", dir)
+		assert.NoError(err)
+
+		assert.Equal(map[string]int{dir: 2}, lockfileCalls)
+		assert.Equal(2, pmCalls)
+		yarnOutputter.AssertNumberOfCalls(GinkgoT(), "Output", 2)
+	})
+})
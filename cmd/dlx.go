@@ -69,7 +69,37 @@ func BuildDLXCommand(pm, yarnVersion, pkgOrURL string, args []string) (program s
 		argv = append([]string{"run", pkgOrURL}, args...)
 		return "deno", argv, nil
 	default:
-		return "", nil, fmt.Errorf("unsupported package manager: %s", pm)
+		return "", nil, unsupportedPackageManagerError(pm)
+	}
+}
+
+// addDLXQuietFlag adds the quiet option of pm to argv built by BuildDLXCommand.
+// The option goes before the package so it isn't passed on to the package:
+//
+// | Package Manager | Quiet command                  |
+// |-----------------|--------------------------------|
+// | npm             | npx --quiet <package>          |
+// | pnpm            | pnpm dlx --silent <package>    |
+// | yarn v1         | yarn --silent <package>        |
+// | yarn v2+        | yarn dlx --quiet <package>     |
+// | bun             | bunx --silent <package>        |
+// | deno            | deno run --quiet <url>         |
+func addDLXQuietFlag(pm string, argv []string) []string {
+	switch pm {
+	case "pnpm":
+		return insertAfter(argv, "dlx", "--silent")
+	case "yarn":
+		// BuildDLXCommand only starts with dlx for yarn v2+
+		if len(argv) > 0 && argv[0] == "dlx" {
+			return insertAfter(argv, "dlx", "--quiet")
+		}
+		return append([]string{"--silent"}, argv...)
+	case "bun":
+		return append([]string{"--silent"}, argv...)
+	case "deno":
+		return insertAfter(argv, "run", "--quiet")
+	default:
+		return append([]string{"--quiet"}, argv...)
 	}
 }
 
@@ -84,7 +114,8 @@ Examples:
   javascript-package-delegator dlx create-react-app my-app
   javascript-package-delegator dlx @angular/cli new my-project
   javascript-package-delegator dlx typescript --version
-  javascript-package-delegator dlx prettier --check .`,
+  javascript-package-delegator dlx prettier --check .
+  javascript-package-delegator --quiet dlx cowsay hi # Only print the output of cowsay`,
 		Aliases: []string{"x"},
 		Args:    cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -116,6 +147,11 @@ Examples:
 				return err
 			}
 
+			// --quiet also hides the output of the package manager while it fetches the package
+			if isQuiet(cmd) {
+				cmdArgs = addDLXQuietFlag(pm, cmdArgs)
+			}
+
 			// Execute the command
 			de.LogJSCommandIfDebugIsTrue(execCommand, cmdArgs...)
 			cmdRunner.Command(execCommand, cmdArgs...)
@@ -0,0 +1,175 @@
+// Package cmd provides command-line interface implementations for the JavaScript package delegator.
+package cmd
+
+import (
+	// standard library
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	// external
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+
+	// internal
+	"github.com/louiss0/javascript-package-delegator/detect"
+)
+
+const _JSON_FLAG = "json"
+
+// DoctorPackageManager describes a single package manager as seen by the doctor command.
+type DoctorPackageManager struct {
+	Name    string `json:"name"`
+	Found   bool   `json:"found"`
+	Path    string `json:"path,omitempty"`
+	Version string `json:"version,omitempty"`
+}
+
+// PackageManagerVersionOutputter returns the output of '<path> --version' for the package manager at path.
+type PackageManagerVersionOutputter func(path string) (string, error)
+
+// runPackageManagerVersion asks the package manager at path for its version.
+func runPackageManagerVersion(path string) (string, error) {
+	output, err := exec.Command(path, "--version").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to run %s --version: %w", path, err)
+	}
+	return string(output), nil
+}
+
+// parsePackageManagerVersion picks the version out of the --version output.
+// Deno prints its name before the version and the versions of v8 and typescript on the next lines.
+func parsePackageManagerVersion(output string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(output), "\n")
+	fields := strings.Fields(line)
+	if len(fields) > 1 {
+		return fields[1]
+	}
+	return strings.TrimSpace(line)
+}
+
+// DoctorReport is the diagnostic report printed by the doctor command.
+type DoctorReport struct {
+	GoEnvMode       string                 `json:"goEnvMode"`
+	Agent           string                 `json:"agent"`
+	Lockfile        string                 `json:"lockfile"`
+	Volta           bool                   `json:"volta"`
+	PackageManagers []DoctorPackageManager `json:"packageManagers"`
+}
+
+// NewDoctorCmd creates a new Cobra command for the "doctor" functionality.
+// The command aggregates the existing detection primitives into one read-only report.
+// Apart from asking each package manager found for its version through versionOutputter,
+// it never executes a package manager.
+func NewDoctorCmd(
+	pathLookup detect.PathLookup,
+	detectVolta func() bool,
+	detectLockfile func(targetDir string) (lockfile string, err error),
+	versionOutputter PackageManagerVersionOutputter,
+) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Report the environment jpd sees",
+		Long: `Print a diagnostic report of the environment jpd uses to make decisions.
+
+The report includes which package managers are found in PATH and their versions, whether Volta is active,
+the lock file detected in the working directory, the resolved agent and the Go env mode.
+Apart from '<package manager> --version' no package manager command is executed.
+
+Examples:
+  jpd doctor         # Print a human readable report
+  jpd doctor --json  # Print the report as JSON`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			asJSON, err := cmd.Flags().GetBool(_JSON_FLAG)
+			if err != nil {
+				return err
+			}
+
+			agent, err := cmd.Flags().GetString(AGENT_FLAG)
+			if err != nil {
+				return fmt.Errorf("failed to get agent flag: %w", err)
+			}
+
+			targetDir, err := cmd.Flags().GetString(_CWD_FLAG)
+			if err != nil {
+				return fmt.Errorf("failed to parse --%s flag: %w", _CWD_FLAG, err)
+			}
+			if targetDir == "" {
+				targetDir, err = os.Getwd()
+				if err != nil {
+					return fmt.Errorf("failed to get current working directory: %w", err)
+				}
+			}
+
+			// A missing lock file is a valid state for the report, so the error is not surfaced.
+			lockfile, _ := getDetectionCacheFromCommandContext(cmd).Lockfile(targetDir, detectLockfile)
+
+			report := DoctorReport{
+				GoEnvMode: getGoEnvFromCommandContext(cmd).Mode(),
+				Agent:     agent,
+				Lockfile:  lockfile,
+				Volta:     detectVolta(),
+				PackageManagers: lo.Map(
+					detect.SupportedJSPackageManagers[:],
+					func(pm string, _ int) DoctorPackageManager {
+						path, err := pathLookup.LookPath(pm)
+						if err != nil {
+							return DoctorPackageManager{Name: pm}
+						}
+
+						packageManager := DoctorPackageManager{Name: pm, Found: true, Path: path}
+
+						// A package manager that can't report its version is still listed
+						if output, err := versionOutputter(path); err == nil {
+							packageManager.Version = parsePackageManagerVersion(output)
+						}
+
+						return packageManager
+					},
+				),
+			}
+
+			if asJSON {
+				encoder := json.NewEncoder(cmd.OutOrStdout())
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(report)
+			}
+
+			return writeDoctorReport(cmd.OutOrStdout(), report)
+		},
+	}
+
+	cmd.Flags().Bool(_JSON_FLAG, false, "Print the report as JSON")
+
+	return cmd
+}
+
+// writeDoctorReport renders the report in a human readable form.
+func writeDoctorReport(w io.Writer, report DoctorReport) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Go env mode: %s\n", lo.Ternary(report.GoEnvMode != "", report.GoEnvMode, "development"))
+	fmt.Fprintf(&b, "Agent: %s\n", lo.Ternary(report.Agent != "", report.Agent, "none"))
+	fmt.Fprintf(&b, "Lockfile: %s\n", lo.Ternary(report.Lockfile != "", report.Lockfile, "none"))
+	fmt.Fprintf(&b, "Volta: %s\n", lo.Ternary(report.Volta, "active", "not found"))
+	b.WriteString("Package managers:\n")
+
+	for _, pm := range report.PackageManagers {
+		if !pm.Found {
+			fmt.Fprintf(&b, "  %-5s not found\n", pm.Name)
+			continue
+		}
+		if pm.Version != "" {
+			fmt.Fprintf(&b, "  %-5s %s (%s)\n", pm.Name, pm.Path, pm.Version)
+			continue
+		}
+		fmt.Fprintf(&b, "  %-5s %s\n", pm.Name, pm.Path)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,159 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/louiss0/javascript-package-delegator/cmd"
+	"github.com/louiss0/javascript-package-delegator/detect"
+	"github.com/louiss0/javascript-package-delegator/mock"
+	"github.com/louiss0/javascript-package-delegator/testutil"
+)
+
+var _ = Describe("Doctor Command", func() {
+	assert := assert.New(GinkgoT())
+
+	var (
+		mockRunner *mock.MockCommandRunner
+		factory    *testutil.RootCommandFactory
+		pathLookup *mock.MockPathLookup
+	)
+
+	BeforeEach(func() {
+		mockRunner = mock.NewMockCommandRunner()
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+
+		pathLookup = mock.NewMockPathLookup()
+		notFound := fmt.Errorf("executable file not found in $PATH")
+		pathLookup.ExpectedLookPathResults[detect.DENO] = struct {
+			Path  string
+			Error error
+		}{"", notFound}
+		pathLookup.ExpectedLookPathResults[detect.BUN] = struct {
+			Path  string
+			Error error
+		}{"", notFound}
+		pathLookup.ExpectedLookPathResults[detect.PNPM] = struct {
+			Path  string
+			Error error
+		}{"/usr/local/bin/pnpm", nil}
+		pathLookup.ExpectedLookPathResults[detect.YARN] = struct {
+			Path  string
+			Error error
+		}{"", notFound}
+		pathLookup.ExpectedLookPathResults[detect.NPM] = struct {
+			Path  string
+			Error error
+		}{"/usr/bin/npm", nil}
+	})
+
+	It("prints a human readable report", func() {
+		root := factory.CreateRootCmdWith(testutil.LockfileDetected(detect.PNPM, detect.PNPM_LOCK_YAML), func(deps *cmd.Dependencies) {
+			deps.PathLookup = pathLookup
+			deps.DetectVolta = func() bool {
+				return true
+			}
+		})
+
+		out, err := executeCmd(root, "doctor")
+		assert.NoError(err)
+		assert.Contains(out, "Agent: pnpm")
+		assert.Contains(out, "Lockfile: pnpm-lock.yaml")
+		assert.Contains(out, "Volta: active")
+		assert.Contains(out, "pnpm  /usr/local/bin/pnpm")
+		assert.Contains(out, "npm   /usr/bin/npm")
+		assert.Contains(out, "deno  not found")
+		assert.False(mockRunner.HasBeenCalled, "doctor must not execute a package manager")
+	})
+
+	It("prints the report as JSON", func() {
+		root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.PathLookup = pathLookup
+		})
+
+		out, err := executeCmd(root, "doctor", "--json")
+		assert.NoError(err)
+
+		var report cmd.DoctorReport
+		assert.NoError(json.Unmarshal([]byte(out), &report))
+		assert.Equal(detect.NPM, report.Agent)
+		assert.Equal(detect.PACKAGE_LOCK_JSON, report.Lockfile)
+		assert.False(report.Volta)
+		assert.Len(report.PackageManagers, len(detect.SupportedJSPackageManagers))
+
+		found := map[string]string{}
+		for _, pm := range report.PackageManagers {
+			if pm.Found {
+				found[pm.Name] = pm.Path
+			}
+		}
+		assert.Equal(map[string]string{
+			detect.PNPM: "/usr/local/bin/pnpm",
+			detect.NPM:  "/usr/bin/npm",
+		}, found)
+		assert.False(mockRunner.HasBeenCalled, "doctor must not execute a package manager")
+	})
+
+	It("reports the version of every package manager found", func() {
+		root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.PathLookup = pathLookup
+			deps.PackageManagerVersionOutputter = func(path string) (string, error) {
+				return map[string]string{
+					"/usr/local/bin/pnpm": "9.1.0\n",
+					"/usr/bin/npm":        "10.8.2\n",
+				}[path], nil
+			}
+		})
+
+		out, err := executeCmd(root, "doctor")
+		assert.NoError(err)
+		assert.Contains(out, "pnpm  /usr/local/bin/pnpm (9.1.0)")
+		assert.Contains(out, "npm   /usr/bin/npm (10.8.2)")
+		assert.False(mockRunner.HasBeenCalled, "doctor must not execute a package manager")
+	})
+
+	It("reads the version out of the deno --version output", func() {
+		pathLookup.ExpectedLookPathResults[detect.DENO] = struct {
+			Path  string
+			Error error
+		}{"/usr/local/bin/deno", nil}
+		root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.PathLookup = pathLookup
+			deps.PackageManagerVersionOutputter = func(path string) (string, error) {
+				if path != "/usr/local/bin/deno" {
+					return "", fmt.Errorf("exit status 1")
+				}
+				return "deno 1.46.3 (stable, release, x86_64-unknown-linux-gnu)\nv8 12.9.202.5-rusty\ntypescript 5.5.2\n", nil
+			}
+		})
+
+		out, err := executeCmd(root, "doctor", "--json")
+		assert.NoError(err)
+
+		var report cmd.DoctorReport
+		assert.NoError(json.Unmarshal([]byte(out), &report))
+		versions := map[string]string{}
+		for _, pm := range report.PackageManagers {
+			versions[pm.Name] = pm.Version
+		}
+		assert.Equal("1.46.3", versions[detect.DENO])
+		assert.Equal("", versions[detect.NPM])
+	})
+
+	It("reports the agent passed with --agent", func() {
+		root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.PathLookup = pathLookup
+		})
+
+		out, err := executeCmd(root, "doctor", "--agent", "pnpm")
+		assert.NoError(err)
+		assert.Contains(out, "Agent: pnpm")
+	})
+})
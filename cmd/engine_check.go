@@ -0,0 +1,96 @@
+// Package cmd provides command-line interface implementations for the JavaScript package delegator.
+package cmd
+
+import (
+	// standard library
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	// external
+	"github.com/spf13/cobra"
+
+	// internal
+	"github.com/louiss0/javascript-package-delegator/internal/engines"
+)
+
+const _ENGINE_CHECK_FLAG = "engine-check"
+
+// NodeVersionOutputter returns the output of 'node --version' run in a directory.
+type NodeVersionOutputter func(targetDir string) (string, error)
+
+// runNodeVersion asks the node on PATH for its version.
+// It runs in the target directory so version managers that pin node per project pick the right one.
+func runNodeVersion(targetDir string) (string, error) {
+	nodeCmd := exec.Command("node", "--version")
+	nodeCmd.Dir = targetDir
+	output, err := nodeCmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to run node --version: %w", err)
+	}
+	return string(output), nil
+}
+
+// addEngineCheckFlag registers the --engine-check flag on commands that run project code.
+func addEngineCheckFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool(_ENGINE_CHECK_FLAG, false, "Fail when the active node version doesn't satisfy engines.node in package.json")
+}
+
+// checkNodeEngineIfRequested compares the active node version with engines.node of the package.json
+// in targetDir when --engine-check is passed. Projects without engines.node always pass.
+func checkNodeEngineIfRequested(cmd *cobra.Command, targetDir string) error {
+	engineCheck, err := cmd.Flags().GetBool(_ENGINE_CHECK_FLAG)
+	if err != nil {
+		return err
+	}
+	if !engineCheck {
+		return nil
+	}
+
+	data, err := os.ReadFile(filepath.Join(targetDir, "package.json"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read package.json: %w", err)
+	}
+
+	var pkg struct {
+		Engines struct {
+			Node string `json:"node"`
+		} `json:"engines"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return fmt.Errorf("failed to parse package.json: %w", err)
+	}
+
+	required := strings.TrimSpace(pkg.Engines.Node)
+	if required == "" {
+		return nil
+	}
+
+	output, err := getNodeVersionOutputterFromCommandContext(cmd)(targetDir)
+	if err != nil {
+		return err
+	}
+
+	active, err := engines.ParseVersion(output)
+	if err != nil {
+		return fmt.Errorf("failed to parse the node version: %w", err)
+	}
+
+	satisfied, err := engines.Satisfies(active, required)
+	if err != nil {
+		return fmt.Errorf("failed to read engines.node from package.json: %w", err)
+	}
+	if !satisfied {
+		return fmt.Errorf("node version mismatch: package.json requires %q but the active version is v%s", required, active)
+	}
+
+	return nil
+}
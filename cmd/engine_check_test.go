@@ -0,0 +1,98 @@
+package cmd_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/louiss0/javascript-package-delegator/cmd"
+	"github.com/louiss0/javascript-package-delegator/mock"
+	"github.com/louiss0/javascript-package-delegator/testutil"
+)
+
+var _ = Describe("Engine check", func() {
+	assert := assert.New(GinkgoT())
+
+	var (
+		mockRunner   *mock.MockCommandRunner
+		factory      *testutil.RootCommandFactory
+		targetDir    string
+		versionCalls int
+	)
+
+	rootWithNode := func(version string) *cobra.Command {
+		return factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.NodeVersionOutputter = func(dir string) (string, error) {
+				versionCalls++
+				assert.Equal(targetDir+"/", dir)
+				return version + "\n", nil
+			}
+		})
+	}
+
+	BeforeEach(func() {
+		mockRunner = mock.NewMockCommandRunner()
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+
+		versionCalls = 0
+		targetDir = GinkgoT().TempDir()
+		packageJSON := `{"scripts": {"dev": "vite"}, "engines": {"node": ">=18.17.0 <21"}}`
+		assert.NoError(os.WriteFile(filepath.Join(targetDir, "package.json"), []byte(packageJSON), 0644))
+	})
+
+	It("runs the script when the active node version satisfies engines.node", func() {
+		_, err := executeCmd(rootWithNode("v20.11.1"), "--cwd", targetDir+"/", "run", "dev", "--engine-check")
+		assert.NoError(err)
+		assert.Equal(1, versionCalls)
+		assert.True(mockRunner.HasCommand("npm", "run", "dev"))
+	})
+
+	It("refuses to run the script when the active node version is outside engines.node", func() {
+		_, err := executeCmd(rootWithNode("v16.20.2"), "--cwd", targetDir+"/", "run", "dev", "--engine-check")
+		assert.Error(err)
+		assert.Contains(err.Error(), `package.json requires ">=18.17.0 <21" but the active version is v16.20.2`)
+		assert.False(mockRunner.HasBeenCalled)
+	})
+
+	It("refuses to install when the active node version is outside engines.node", func() {
+		_, err := executeCmd(rootWithNode("v21.0.0"), "--cwd", targetDir+"/", "install", "lodash", "--engine-check")
+		assert.Error(err)
+		assert.Contains(err.Error(), "node version mismatch")
+		assert.False(mockRunner.HasBeenCalled)
+	})
+
+	It("doesn't ask for the node version without the flag", func() {
+		_, err := executeCmd(rootWithNode("v16.20.2"), "--cwd", targetDir+"/", "install", "lodash")
+		assert.NoError(err)
+		assert.Equal(0, versionCalls)
+		assert.True(mockRunner.HasCommand("npm", "install", "lodash"))
+	})
+
+	It("passes projects without engines.node", func() {
+		assert.NoError(os.WriteFile(filepath.Join(targetDir, "package.json"), []byte(`{"scripts": {"dev": "vite"}}`), 0644))
+
+		_, err := executeCmd(rootWithNode("v16.20.2"), "--cwd", targetDir+"/", "run", "dev", "--engine-check")
+		assert.NoError(err)
+		assert.Equal(0, versionCalls)
+	})
+
+	It("returns the error when the node version can't be read", func() {
+		root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.NodeVersionOutputter = func(string) (string, error) {
+				return "", fmt.Errorf("failed to run node --version: executable file not found in $PATH")
+			}
+		})
+
+		_, err := executeCmd(root, "--cwd", targetDir+"/", "run", "dev", "--engine-check")
+		assert.Error(err)
+		assert.Contains(err.Error(), "failed to run node --version")
+	})
+})
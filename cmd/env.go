@@ -0,0 +1,295 @@
+// Package cmd provides command-line interface implementations for the JavaScript package delegator.
+package cmd
+
+import (
+	// standard library
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	// external
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	// internal
+	"github.com/louiss0/javascript-package-delegator/detect"
+)
+
+// Where the agent of an invocation was resolved from, in order of precedence.
+const (
+	AGENT_SOURCE_FLAG        = "flag"
+	AGENT_SOURCE_ENV         = "env"
+	AGENT_SOURCE_LOCKFILE    = "lockfile"
+	AGENT_SOURCE_YARN_CONFIG = "yarn-config"
+	AGENT_SOURCE_PATH        = "path"
+	AGENT_SOURCE_NONE        = "none"
+)
+
+// Where the registry of the agent was resolved from, in order of precedence.
+const (
+	REGISTRY_SOURCE_ENV     = "env"
+	REGISTRY_SOURCE_YARNRC  = "yarnrc"
+	REGISTRY_SOURCE_NPMRC   = "npmrc"
+	REGISTRY_SOURCE_DEFAULT = "default"
+)
+
+// DEFAULT_REGISTRY is the registry package managers install from when nothing else is configured.
+const DEFAULT_REGISTRY = "https://registry.npmjs.org/"
+
+// What run does when the lockfile changed after the last install.
+const (
+	AUTO_INSTALL_WARN = "warn" // run prints a note, run --auto-install installs first
+	AUTO_INSTALL_OFF  = "off"  // there is no node_modules lockfile to compare with the last install
+)
+
+// jpdEnvVars are the environment variables jpd reads.
+var jpdEnvVars = []string{JPD_AGENT_ENV_VAR, JPD_ALLOWED_AGENTS_ENV_VAR, JPD_DENIED_AGENTS_ENV_VAR, JPD_CONFIG_ENV_VAR}
+
+// agentSourceDescriptions name the agent sources in the human readable report.
+var agentSourceDescriptions = map[string]string{
+	AGENT_SOURCE_FLAG:        "--" + AGENT_FLAG,
+	AGENT_SOURCE_ENV:         JPD_AGENT_ENV_VAR,
+	AGENT_SOURCE_LOCKFILE:    "lock file",
+	AGENT_SOURCE_YARN_CONFIG: "yarn config file",
+	AGENT_SOURCE_PATH:        "PATH",
+}
+
+// registrySourceDescriptions name the registry sources in the human readable report.
+var registrySourceDescriptions = map[string]string{
+	REGISTRY_SOURCE_ENV:    "the environment",
+	REGISTRY_SOURCE_YARNRC: detect.YARNRC_YML,
+	REGISTRY_SOURCE_NPMRC:  ".npmrc",
+}
+
+// EnvReport is the configuration jpd resolved for an invocation, printed by the env command.
+type EnvReport struct {
+	Agent                string            `json:"agent"`
+	AgentSource          string            `json:"agentSource"`
+	Cwd                  string            `json:"cwd"`
+	CwdSource            string            `json:"cwdSource"`
+	Lockfile             string            `json:"lockfile"`
+	Volta                bool              `json:"volta"`
+	Corepack             bool              `json:"corepack"`
+	PackageManagerField  string            `json:"packageManagerField,omitempty"`
+	Registry             string            `json:"registry"`
+	RegistrySource       string            `json:"registrySource"`
+	AutoInstall          string            `json:"autoInstall"`
+	EnvironmentVariables map[string]string `json:"environmentVariables"`
+}
+
+// NewEnvCmd creates a new Cobra command that prints the configuration jpd resolved and where each part came from.
+// Like doctor it is read-only, no package manager command is executed.
+func NewEnvCmd(
+	detectVolta func() bool,
+	detectLockfile func(targetDir string) (lockfile string, err error),
+) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "env",
+		Short: "Print the configuration jpd resolved and where it came from",
+		Long: `Print the configuration jpd resolved for this invocation and where it came from.
+
+The agent is resolved from the first of these that sets it:
+
+  flag      --agent
+  env       JPD_AGENT
+  lockfile  the lock file of the project or of an ancestor directory
+  path      the first package manager found in PATH
+
+The report also shows the working directory, the lock file, whether Volta and corepack
+are used, the registry the agent installs from, what run does when the lock file changed
+after the last install and which of the JPD_* environment variables are set.
+
+The registry is resolved from the first of these that sets it:
+
+  env      npm_config_registry, or YARN_NPM_REGISTRY_SERVER for yarn
+  yarnrc   npmRegistryServer in .yarnrc.yml, for yarn
+  npmrc    registry in the .npmrc of the project or of the home directory
+  default  https://registry.npmjs.org/
+
+Examples:
+  jpd env              # Print the resolved configuration
+  jpd env --json       # Print it as JSON
+  jpd --agent bun env  # See that --agent wins over JPD_AGENT and the lock file`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			asJSON, err := cmd.Flags().GetBool(_JSON_FLAG)
+			if err != nil {
+				return err
+			}
+
+			agent, err := cmd.Flags().GetString(AGENT_FLAG)
+			if err != nil {
+				return fmt.Errorf("failed to get agent flag: %w", err)
+			}
+
+			targetDir, err := cmd.Flags().GetString(_CWD_FLAG)
+			if err != nil {
+				return fmt.Errorf("failed to parse --%s flag: %w", _CWD_FLAG, err)
+			}
+			cwdSource := "flag"
+			if targetDir == "" {
+				cwdSource = "process"
+				if targetDir, err = os.Getwd(); err != nil {
+					return fmt.Errorf("failed to get current working directory: %w", err)
+				}
+			}
+
+			useCorepack, err := cmd.Flags().GetBool(_COREPACK_FLAG)
+			if err != nil {
+				return err
+			}
+
+			packageManagerField, err := readPackageManagerFieldFrom(targetDir)
+			if err != nil {
+				return err
+			}
+
+			// A missing lock file is a valid state for the report, so the error is not surfaced.
+			lockfile, _ := getDetectionCacheFromCommandContext(cmd).Lockfile(targetDir, detectLockfile)
+
+			registry, registrySource := resolveRegistry(agent, targetDir)
+			_, lockfileChecked := freshnessLockfile(agent, targetDir)
+
+			report := EnvReport{
+				Agent:       agent,
+				AgentSource: getAgentSourceFromCommandContext(cmd),
+				Cwd:         targetDir,
+				CwdSource:   cwdSource,
+				Lockfile:    lockfile,
+				Volta:       detectVolta(),
+				// Corepack is only put in front of the agent when the project pins a version
+				Corepack:             useCorepack && packageManagerField != "",
+				PackageManagerField:  packageManagerField,
+				Registry:             registry,
+				RegistrySource:       registrySource,
+				AutoInstall:          lo.Ternary(lockfileChecked, AUTO_INSTALL_WARN, AUTO_INSTALL_OFF),
+				EnvironmentVariables: map[string]string{},
+			}
+
+			for _, name := range jpdEnvVars {
+				if value, ok := os.LookupEnv(name); ok {
+					report.EnvironmentVariables[name] = value
+				}
+			}
+
+			if asJSON {
+				encoder := json.NewEncoder(cmd.OutOrStdout())
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(report)
+			}
+
+			return writeEnvReport(cmd.OutOrStdout(), report)
+		},
+	}
+
+	cmd.Flags().Bool(_JSON_FLAG, false, "Print the configuration as JSON")
+
+	return cmd
+}
+
+// writeEnvReport renders the report in a human readable form.
+func writeEnvReport(w io.Writer, report EnvReport) error {
+	var b strings.Builder
+
+	if report.Agent == "" {
+		b.WriteString("Agent: none\n")
+	} else {
+		fmt.Fprintf(&b, "Agent: %s (from %s)\n", report.Agent, agentSourceDescriptions[report.AgentSource])
+	}
+	fmt.Fprintf(&b, "Working directory: %s (from %s)\n", report.Cwd, lo.Ternary(report.CwdSource == "flag", "--"+_CWD_FLAG, "the process"))
+	fmt.Fprintf(&b, "Lockfile: %s\n", lo.Ternary(report.Lockfile != "", report.Lockfile, "none"))
+	fmt.Fprintf(&b, "Volta: %s\n", lo.Ternary(report.Volta, "active", "not found"))
+	fmt.Fprintf(&b, "Corepack: %s\n", lo.Ternary(report.Corepack, "used for "+report.PackageManagerField, "not used"))
+	if report.RegistrySource == REGISTRY_SOURCE_DEFAULT {
+		fmt.Fprintf(&b, "Registry: %s (default)\n", report.Registry)
+	} else {
+		fmt.Fprintf(&b, "Registry: %s (from %s)\n", report.Registry, registrySourceDescriptions[report.RegistrySource])
+	}
+	fmt.Fprintf(&b, "Auto-install: %s\n", lo.Ternary(
+		report.AutoInstall == AUTO_INSTALL_WARN,
+		"warn when the lock file changed, run --"+_AUTO_INSTALL_FLAG+" installs first",
+		"off",
+	))
+
+	if len(report.EnvironmentVariables) == 0 {
+		b.WriteString("Environment variables: none\n")
+	} else {
+		b.WriteString("Environment variables:\n")
+		for _, name := range jpdEnvVars {
+			if value, ok := report.EnvironmentVariables[name]; ok {
+				fmt.Fprintf(&b, "  %s=%s\n", name, value)
+			}
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// resolveRegistry returns the registry agent installs from in targetDir and where it was set.
+// Yarn v2+ reads its own variable and .yarnrc.yml, every package manager reads npm_config_registry and .npmrc.
+func resolveRegistry(agent, targetDir string) (registry, source string) {
+	envVars := []string{"npm_config_registry", "NPM_CONFIG_REGISTRY"}
+	if agent == detect.YARN {
+		envVars = append([]string{"YARN_NPM_REGISTRY_SERVER"}, envVars...)
+	}
+	for _, name := range envVars {
+		if value := os.Getenv(name); value != "" {
+			return value, REGISTRY_SOURCE_ENV
+		}
+	}
+
+	if agent == detect.YARN {
+		if registry := readYarnrcRegistry(filepath.Join(targetDir, detect.YARNRC_YML)); registry != "" {
+			return registry, REGISTRY_SOURCE_YARNRC
+		}
+	}
+
+	npmrcPaths := []string{filepath.Join(targetDir, ".npmrc")}
+	if home, err := os.UserHomeDir(); err == nil {
+		npmrcPaths = append(npmrcPaths, filepath.Join(home, ".npmrc"))
+	}
+	for _, path := range npmrcPaths {
+		if registry := readNpmrcRegistry(path); registry != "" {
+			return registry, REGISTRY_SOURCE_NPMRC
+		}
+	}
+
+	return DEFAULT_REGISTRY, REGISTRY_SOURCE_DEFAULT
+}
+
+// readNpmrcRegistry returns the registry set in the .npmrc at path, scoped registries are left out.
+func readNpmrcRegistry(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		key, value, found := strings.Cut(strings.TrimSpace(line), "=")
+		if found && strings.TrimSpace(key) == "registry" {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
+
+// readYarnrcRegistry returns npmRegistryServer of the .yarnrc.yml at path.
+func readYarnrcRegistry(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+
+	var yarnrc struct {
+		NpmRegistryServer string `yaml:"npmRegistryServer"`
+	}
+	if err := yaml.Unmarshal(data, &yarnrc); err != nil {
+		return ""
+	}
+	return yarnrc.NpmRegistryServer
+}
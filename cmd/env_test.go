@@ -0,0 +1,185 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/louiss0/javascript-package-delegator/cmd"
+	"github.com/louiss0/javascript-package-delegator/detect"
+	"github.com/louiss0/javascript-package-delegator/mock"
+	"github.com/louiss0/javascript-package-delegator/testutil"
+)
+
+var _ = Describe("Env Command", func() {
+	assert := assert.New(GinkgoT())
+
+	var (
+		mockRunner *mock.MockCommandRunner
+		factory    *testutil.RootCommandFactory
+		projectDir string
+	)
+
+	readReport := func(rootCmd *cobra.Command, args ...string) cmd.EnvReport {
+		output, err := executeCmd(rootCmd, append(args, "--cwd", projectDir+"/", "env", "--json")...)
+		assert.NoError(err)
+
+		var report cmd.EnvReport
+		assert.NoError(json.Unmarshal([]byte(output), &report))
+		return report
+	}
+
+	BeforeEach(func() {
+		mockRunner = mock.NewMockCommandRunner()
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+
+		for _, name := range []string{
+			cmd.JPD_AGENT_ENV_VAR, cmd.JPD_ALLOWED_AGENTS_ENV_VAR, cmd.JPD_DENIED_AGENTS_ENV_VAR, cmd.JPD_CONFIG_ENV_VAR,
+			"npm_config_registry", "NPM_CONFIG_REGISTRY", "YARN_NPM_REGISTRY_SERVER",
+		} {
+			GinkgoT().Setenv(name, "")
+			assert.NoError(os.Unsetenv(name))
+		}
+		GinkgoT().Setenv("HOME", GinkgoT().TempDir())
+
+		projectDir = GinkgoT().TempDir()
+		assert.NoError(os.WriteFile(filepath.Join(projectDir, "package.json"), []byte(`{"name": "app"}`), 0o644))
+	})
+
+	Context("agent source precedence", func() {
+		It("prefers --agent over JPD_AGENT and the lock file", func() {
+			GinkgoT().Setenv(cmd.JPD_AGENT_ENV_VAR, "yarn")
+
+			report := readReport(factory.CreateNpmAsDefault(nil), "--agent", "pnpm")
+			assert.Equal("pnpm", report.Agent)
+			assert.Equal(cmd.AGENT_SOURCE_FLAG, report.AgentSource)
+			assert.Equal(map[string]string{cmd.JPD_AGENT_ENV_VAR: "yarn"}, report.EnvironmentVariables)
+		})
+
+		It("prefers JPD_AGENT over the lock file", func() {
+			GinkgoT().Setenv(cmd.JPD_AGENT_ENV_VAR, "yarn")
+
+			report := readReport(factory.CreateNpmAsDefault(nil))
+			assert.Equal("yarn", report.Agent)
+			assert.Equal(cmd.AGENT_SOURCE_ENV, report.AgentSource)
+		})
+
+		It("uses the lock file without --agent or JPD_AGENT", func() {
+			report := readReport(factory.CreateNpmAsDefault(nil))
+			assert.Equal("npm", report.Agent)
+			assert.Equal(cmd.AGENT_SOURCE_LOCKFILE, report.AgentSource)
+			assert.Equal(detect.PACKAGE_LOCK_JSON, report.Lockfile)
+			assert.Empty(report.EnvironmentVariables)
+		})
+
+		It("falls back to PATH without a lock file", func() {
+			report := readReport(factory.CreateRootCmdWithPathDetected(detect.BUN, nil, false))
+			assert.Equal("bun", report.Agent)
+			assert.Equal(cmd.AGENT_SOURCE_PATH, report.AgentSource)
+			assert.Empty(report.Lockfile)
+		})
+	})
+
+	Context("registry source precedence", func() {
+		It("prefers npm_config_registry over .npmrc", func() {
+			GinkgoT().Setenv("npm_config_registry", "https://env.example.com/")
+			assert.NoError(os.WriteFile(filepath.Join(projectDir, ".npmrc"), []byte("registry=https://npmrc.example.com/\n"), 0o644))
+
+			report := readReport(factory.CreateNpmAsDefault(nil))
+			assert.Equal("https://env.example.com/", report.Registry)
+			assert.Equal(cmd.REGISTRY_SOURCE_ENV, report.RegistrySource)
+		})
+
+		It("prefers the .npmrc of the project over the one of the home directory", func() {
+			assert.NoError(os.WriteFile(filepath.Join(os.Getenv("HOME"), ".npmrc"), []byte("registry=https://home.example.com/\n"), 0o644))
+			assert.NoError(os.WriteFile(filepath.Join(projectDir, ".npmrc"), []byte("@scope:registry=https://scope.example.com/\nregistry=https://npmrc.example.com/\n"), 0o644))
+
+			report := readReport(factory.CreateNpmAsDefault(nil))
+			assert.Equal("https://npmrc.example.com/", report.Registry)
+			assert.Equal(cmd.REGISTRY_SOURCE_NPMRC, report.RegistrySource)
+		})
+
+		It("prefers npmRegistryServer of .yarnrc.yml over .npmrc for yarn", func() {
+			assert.NoError(os.WriteFile(filepath.Join(projectDir, detect.YARNRC_YML), []byte("npmRegistryServer: \"https://yarnrc.example.com/\"\n"), 0o644))
+			assert.NoError(os.WriteFile(filepath.Join(projectDir, ".npmrc"), []byte("registry=https://npmrc.example.com/\n"), 0o644))
+
+			report := readReport(factory.CreateNpmAsDefault(nil), "--agent", "yarn")
+			assert.Equal("https://yarnrc.example.com/", report.Registry)
+			assert.Equal(cmd.REGISTRY_SOURCE_YARNRC, report.RegistrySource)
+		})
+
+		It("ignores .yarnrc.yml for other agents", func() {
+			assert.NoError(os.WriteFile(filepath.Join(projectDir, detect.YARNRC_YML), []byte("npmRegistryServer: \"https://yarnrc.example.com/\"\n"), 0o644))
+
+			report := readReport(factory.CreateNpmAsDefault(nil))
+			assert.Equal(cmd.DEFAULT_REGISTRY, report.Registry)
+			assert.Equal(cmd.REGISTRY_SOURCE_DEFAULT, report.RegistrySource)
+		})
+	})
+
+	Context("auto-install mode", func() {
+		It("warns when the project has a lock file run can compare with the last install", func() {
+			assert.NoError(os.WriteFile(filepath.Join(projectDir, detect.PACKAGE_LOCK_JSON), []byte("{}"), 0o644))
+
+			report := readReport(factory.CreateNpmAsDefault(nil))
+			assert.Equal(cmd.AUTO_INSTALL_WARN, report.AutoInstall)
+		})
+
+		It("is off without a lock file", func() {
+			report := readReport(factory.CreateNpmAsDefault(nil))
+			assert.Equal(cmd.AUTO_INSTALL_OFF, report.AutoInstall)
+		})
+
+		It("is off for deno", func() {
+			assert.NoError(os.WriteFile(filepath.Join(projectDir, detect.PACKAGE_LOCK_JSON), []byte("{}"), 0o644))
+
+			report := readReport(factory.CreateNpmAsDefault(nil), "--agent", "deno")
+			assert.Equal(cmd.AUTO_INSTALL_OFF, report.AutoInstall)
+		})
+	})
+
+	It("reports the working directory, volta, corepack and the agent policy variables", func() {
+		GinkgoT().Setenv(cmd.JPD_DENIED_AGENTS_ENV_VAR, "bun")
+		assert.NoError(os.WriteFile(filepath.Join(projectDir, "package.json"), []byte(`{"packageManager": "pnpm@9.1.0"}`), 0o644))
+
+		report := readReport(factory.CreateRootCmdWith(testutil.LockfileDetected(detect.PNPM, detect.PNPM_LOCK_YAML), func(deps *cmd.Dependencies) {
+			deps.DetectCorepack = func() bool {
+				return true
+			}
+		}), "--corepack")
+		assert.Equal(projectDir+"/", report.Cwd)
+		assert.Equal("flag", report.CwdSource)
+		assert.False(report.Volta)
+		assert.True(report.Corepack)
+		assert.Equal("pnpm@9.1.0", report.PackageManagerField)
+		assert.Equal(map[string]string{cmd.JPD_DENIED_AGENTS_ENV_VAR: "bun"}, report.EnvironmentVariables)
+		assert.False(mockRunner.HasBeenCalled)
+	})
+
+	It("prints a human readable report", func() {
+		GinkgoT().Setenv(cmd.JPD_AGENT_ENV_VAR, "pnpm")
+
+		output, err := executeCmd(factory.CreateRootCmdWithLockfileDetected(detect.NPM, detect.PACKAGE_LOCK_JSON, nil, true), "--cwd", projectDir+"/", "env")
+		assert.NoError(err)
+		assert.Equal(
+			"Agent: pnpm (from JPD_AGENT)\n"+
+				"Working directory: "+projectDir+"/ (from --cwd)\n"+
+				"Lockfile: package-lock.json\n"+
+				"Volta: active\n"+
+				"Corepack: not used\n"+
+				"Registry: https://registry.npmjs.org/ (default)\n"+
+				"Auto-install: off\n"+
+				"Environment variables:\n"+
+				"  JPD_AGENT=pnpm\n",
+			output,
+		)
+	})
+})
@@ -25,11 +25,16 @@ package cmd
 
 import (
 	// standard library
+	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 
 	// external
 	"github.com/charmbracelet/log"
+	"github.com/samber/lo"
 	"github.com/spf13/cobra"
 
 	// internal
@@ -60,14 +65,36 @@ func isURL(s string) bool {
 	return strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://")
 }
 
+const (
+	_PACKAGE_FLAG    = "package"
+	_WHICH_FLAG      = "which"
+	_NO_INSTALL_FLAG = "no-install"
+
+	_STRICT_PASSTHROUGH_FLAG = "strict-passthrough"
+)
+
 // BuildExecCommand builds command line for running local dependencies
 func BuildExecCommand(pm, yarnVersion, bin string, args []string) (program string, argv []string, err error) {
+	return buildExecCommand(pm, yarnVersion, bin, args, false)
+}
+
+// BuildExecCommandNoInstall builds the command line for running bin without fetching it when it's missing.
+// pnpm exec and yarn only run installed binaries already, so their command line doesn't change.
+func BuildExecCommandNoInstall(pm, yarnVersion, bin string, args []string) (program string, argv []string, err error) {
+	return buildExecCommand(pm, yarnVersion, bin, args, true)
+}
+
+func buildExecCommand(pm, yarnVersion, bin string, args []string, noInstall bool) (program string, argv []string, err error) {
 	if bin == "" {
 		return "", nil, fmt.Errorf("binary name is required for exec command")
 	}
 
 	switch pm {
 	case "npm":
+		if noInstall {
+			argv = append([]string{"exec", "--no-install", "--", bin}, args...)
+			return "npm", argv, nil
+		}
 		argv = append([]string{"exec", bin, "--"}, args...)
 		return "npm", argv, nil
 	case "pnpm":
@@ -77,13 +104,45 @@ func BuildExecCommand(pm, yarnVersion, bin string, args []string) (program strin
 		argv = append([]string{bin}, args...)
 		return "yarn", argv, nil
 	case "bun":
-		argv = append([]string{"x", bin}, args...)
+		argv = append(lo.Ternary(noInstall, []string{"x", "--no-install", bin}, []string{"x", bin}), args...)
 		return "bun", argv, nil
 	case "deno":
-		argv = append([]string{"run", bin}, args...)
+		// deno has no install step, --cached-only refuses to download what isn't cached yet
+		argv = append(lo.Ternary(noInstall, []string{"run", "--cached-only", bin}, []string{"run", bin}), args...)
 		return "deno", argv, nil
 	default:
-		return "", nil, fmt.Errorf("unsupported package manager: %s", pm)
+		return "", nil, unsupportedPackageManagerError(pm)
+	}
+}
+
+// BuildExecPackageCommand builds the command line for running bin from pkg
+// when the binary name differs from the package name.
+func BuildExecPackageCommand(pm, yarnVersion, pkg, bin string, args []string) (program string, argv []string, err error) {
+	if bin == "" {
+		return "", nil, fmt.Errorf("binary name is required for exec command")
+	}
+
+	switch pm {
+	case "npm":
+		argv = append([]string{"exec", "--package=" + pkg, "--", bin}, args...)
+		return "npm", argv, nil
+	case "pnpm":
+		// pnpm exec only runs local binaries, dlx is the only command that accepts a package
+		argv = append([]string{"dlx", "--package=" + pkg, bin}, args...)
+		return "pnpm", argv, nil
+	case "yarn":
+		if ParseYarnMajor(yarnVersion) < 2 {
+			return "", nil, fmt.Errorf("yarn v1 does not support the --%s flag, use yarn v2+ or pass the package to jpd dlx", _PACKAGE_FLAG)
+		}
+		argv = append([]string{"dlx", "--package", pkg, bin}, args...)
+		return "yarn", argv, nil
+	case "bun":
+		argv = append([]string{"x", "--package", pkg, bin}, args...)
+		return "bun", argv, nil
+	case "deno":
+		return "", nil, fmt.Errorf("deno does not support the --%s flag", _PACKAGE_FLAG)
+	default:
+		return "", nil, unsupportedPackageManagerError(pm)
 	}
 }
 
@@ -99,10 +158,44 @@ Examples:
   javascript-package-delegator exec eslint --version
   javascript-package-delegator exec ts-node src/index.ts
   javascript-package-delegator exec vite build
-  javascript-package-delegator exec prettier --check .`,
+  javascript-package-delegator exec prettier --check .
+  javascript-package-delegator exec --package @angular/cli ng new my-app
+  javascript-package-delegator exec --which tsup
+  javascript-package-delegator exec --no-install eslint .
+  javascript-package-delegator exec eslint --fix .  # --fix goes to eslint, not to jpd
+
+Everything after the binary name is passed to it untouched, jpd flags go before it.
+A -- right after the binary name is still read as the end of the jpd flags.
+--strict-passthrough=false lets jpd parse its flags after the binary name again.
+
+--no-install stops npm and bun from downloading a missing binary and makes deno use
+its cache only. pnpm exec and yarn never download binaries, so it changes nothing for them.`,
 		Aliases: []string{"e"},
 		Args:    cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			strict, err := cmd.Flags().GetBool(_STRICT_PASSTHROUGH_FLAG)
+			if err != nil {
+				return err
+			}
+
+			if !strict {
+				// Parse what came after the binary name again, this time for jpd flags
+				cmd.Flags().SetInterspersed(true)
+				defer cmd.Flags().SetInterspersed(false)
+				if err := cmd.Flags().Parse(args); err != nil {
+					return err
+				}
+				if help, _ := cmd.Flags().GetBool("help"); help {
+					return cmd.Help()
+				}
+				args = cmd.Flags().Args()
+				if len(args) == 0 {
+					return fmt.Errorf("binary name is required for exec command")
+				}
+			} else if len(args) > 1 && args[1] == "--" {
+				args = append(args[:1:1], args[2:]...)
+			}
+
 			pm, _ := cmd.Flags().GetString(AGENT_FLAG)
 			goEnv := getGoEnvFromCommandContext(cmd)
 			cmdRunner := getCommandRunnerFromCommandContext(cmd)
@@ -111,6 +204,31 @@ Examples:
 			binaryName := args[0]
 			binaryArgs := args[1:]
 
+			which, err := cmd.Flags().GetBool(_WHICH_FLAG)
+			if err != nil {
+				return err
+			}
+
+			if which {
+				targetDir, err := cmd.Flags().GetString(_CWD_FLAG)
+				if err != nil {
+					return fmt.Errorf("failed to parse --%s flag: %w", _CWD_FLAG, err)
+				}
+				if targetDir == "" {
+					if targetDir, err = os.Getwd(); err != nil {
+						return fmt.Errorf("failed to get current working directory: %w", err)
+					}
+				}
+
+				path, err := resolveLocalBin(targetDir, binaryName)
+				if err != nil {
+					return err
+				}
+
+				_, err = fmt.Fprintln(cmd.OutOrStdout(), path)
+				return err
+			}
+
 			goEnv.ExecuteIfModeIsProduction(func() {
 				log.Info("Using package manager", "pm", pm)
 			})
@@ -125,12 +243,39 @@ Examples:
 				}
 			}
 
+			pkg, err := cmd.Flags().GetString(_PACKAGE_FLAG)
+			if err != nil {
+				return err
+			}
+
 			// Build command for executing local dependencies
-			execCommand, cmdArgs, err := BuildExecCommand(pm, yarnVersion, binaryName, binaryArgs)
+			var execCommand string
+			var cmdArgs []string
+			noInstall, err := cmd.Flags().GetBool(_NO_INSTALL_FLAG)
 			if err != nil {
 				return err
 			}
 
+			switch {
+			case pkg != "" && noInstall:
+				return fmt.Errorf("the --%s flag cannot be combined with --%s", _NO_INSTALL_FLAG, _PACKAGE_FLAG)
+			case pkg != "":
+				execCommand, cmdArgs, err = BuildExecPackageCommand(pm, yarnVersion, pkg, binaryName, binaryArgs)
+			case noInstall:
+				execCommand, cmdArgs, err = BuildExecCommandNoInstall(pm, yarnVersion, binaryName, binaryArgs)
+			default:
+				execCommand, cmdArgs, err = BuildExecCommand(pm, yarnVersion, binaryName, binaryArgs)
+			}
+			if err != nil {
+				return err
+			}
+
+			if pkg != "" && pm == "pnpm" {
+				if err := printNote(cmd, "pnpm exec cannot run binaries from other packages, pnpm dlx is used instead"); err != nil {
+					return err
+				}
+			}
+
 			// Execute the command
 			cmdRunner.Command(execCommand, cmdArgs...)
 			de.LogJSCommandIfDebugIsTrue(execCommand, cmdArgs...)
@@ -143,5 +288,84 @@ Examples:
 		},
 	}
 
+	cmd.Flags().Bool(_WHICH_FLAG, false, "Print the path of the binary in node_modules/.bin without running it")
+	cmd.Flags().String(_PACKAGE_FLAG, "", "Package that provides the binary when its name differs from the binary name")
+	cmd.Flags().Bool(_NO_INSTALL_FLAG, false, "Fail instead of downloading the binary when it isn't installed")
+	cmd.Flags().Bool(_STRICT_PASSTHROUGH_FLAG, true, "Pass every argument after the binary name to it without parsing jpd flags")
+
+	// Flags after the binary name belong to the binary, --strict-passthrough=false parses them again in RunE
+	cmd.Flags().SetInterspersed(false)
+
 	return cmd
 }
+
+// resolveLocalBin returns the path of a binary in the node_modules/.bin directory of targetDir.
+// A scoped package name resolves to the binary its package.json declares.
+func resolveLocalBin(targetDir, name string) (string, error) {
+	if IsYarnPnpProject(targetDir) {
+		return "", fmt.Errorf(
+			"yarn Plug'n'Play projects have no node_modules/.bin directory, run 'jpd exec %s' directly instead",
+			name,
+		)
+	}
+
+	binDir := filepath.Join(targetDir, "node_modules", ".bin")
+
+	binName := name
+	if strings.HasPrefix(name, "@") {
+		var err error
+		if binName, err = scopedPackageBin(targetDir, name); err != nil {
+			return "", err
+		}
+	}
+
+	// Windows package managers write .cmd shims next to the POSIX ones
+	for _, candidate := range []string{binName, binName + ".cmd"} {
+		path := filepath.Join(binDir, candidate)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return filepath.Abs(path)
+		}
+	}
+
+	return "", fmt.Errorf("%s was not found in %s, install the package that provides it first", binName, binDir)
+}
+
+// scopedPackageBin returns the binary a scoped package installs.
+// Packages with several binaries must be asked for by binary name instead.
+func scopedPackageBin(targetDir, name string) (string, error) {
+	_, unscoped, _ := strings.Cut(name, "/")
+
+	data, err := os.ReadFile(filepath.Join(targetDir, "node_modules", name, "package.json"))
+	if err != nil {
+		// Not installed, the unscoped name is the usual binary name
+		return unscoped, nil
+	}
+
+	var pkg struct {
+		Bin json.RawMessage `json:"bin"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return "", fmt.Errorf("failed to parse package.json of %s: %w", name, err)
+	}
+
+	var bins map[string]string
+	if err := json.Unmarshal(pkg.Bin, &bins); err != nil {
+		// A string bin is installed under the unscoped package name
+		return unscoped, nil
+	}
+
+	if _, ok := bins[unscoped]; ok {
+		return unscoped, nil
+	}
+
+	names := lo.Keys(bins)
+	switch len(names) {
+	case 0:
+		return unscoped, nil
+	case 1:
+		return names[0], nil
+	default:
+		slices.Sort(names)
+		return "", fmt.Errorf("%s provides several binaries %v, pass one of them instead", name, names)
+	}
+}
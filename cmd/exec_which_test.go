@@ -0,0 +1,78 @@
+package cmd_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/louiss0/javascript-package-delegator/mock"
+	"github.com/louiss0/javascript-package-delegator/testutil"
+)
+
+var _ = Describe("Exec --which", func() {
+	assert := assert.New(GinkgoT())
+
+	var (
+		mockRunner *mock.MockCommandRunner
+		factory    *testutil.RootCommandFactory
+		dir        string
+	)
+
+	writeFile := func(path, content string) {
+		full := filepath.Join(dir, path)
+		assert.NoError(os.MkdirAll(filepath.Dir(full), 0o755))
+		assert.NoError(os.WriteFile(full, []byte(content), 0o755))
+	}
+
+	BeforeEach(func() {
+		mockRunner = mock.NewMockCommandRunner()
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+
+		dir = GinkgoT().TempDir()
+		writeFile("node_modules/.bin/tsup", "#!/bin/sh\n")
+	})
+
+	It("prints the path of the binary in node_modules/.bin without running it", func() {
+		output, err := executeCmd(factory.CreateNpmAsDefault(nil), "exec", "--which", "--cwd", dir+"/", "tsup")
+		assert.NoError(err)
+		assert.Equal(filepath.Join(dir, "node_modules", ".bin", "tsup")+"\n", output)
+		assert.False(mockRunner.HasBeenCalled, "exec --which must not run anything")
+	})
+
+	It("returns an error when the binary is not installed", func() {
+		_, err := executeCmd(factory.CreateNpmAsDefault(nil), "exec", "--which", "--cwd", dir+"/", "vite")
+		assert.ErrorContains(err, "vite was not found in "+filepath.Join(dir, "node_modules", ".bin"))
+	})
+
+	It("resolves the binary of a scoped package from its package.json", func() {
+		writeFile("node_modules/@angular/cli/package.json", `{"name": "@angular/cli", "bin": {"ng": "bin/ng.js"}}`)
+		writeFile("node_modules/.bin/ng", "#!/bin/sh\n")
+
+		output, err := executeCmd(factory.CreateNpmAsDefault(nil), "exec", "--which", "--cwd", dir+"/", "@angular/cli")
+		assert.NoError(err)
+		assert.Equal(filepath.Join(dir, "node_modules", ".bin", "ng")+"\n", output)
+	})
+
+	It("resolves a scoped package with a string bin to its unscoped name", func() {
+		writeFile("node_modules/@biomejs/biome/package.json", `{"name": "@biomejs/biome", "bin": "bin/biome"}`)
+		writeFile("node_modules/.bin/biome", "#!/bin/sh\n")
+
+		output, err := executeCmd(factory.CreateNpmAsDefault(nil), "exec", "--which", "--cwd", dir+"/", "@biomejs/biome")
+		assert.NoError(err)
+		assert.Equal(filepath.Join(dir, "node_modules", ".bin", "biome")+"\n", output)
+	})
+
+	It("explains that Yarn PnP projects have no .bin directory", func() {
+		writeFile(".pnp.cjs", "")
+
+		_, err := executeCmd(factory.CreateYarnTwoAsDefault(nil), "exec", "--which", "--cwd", dir+"/", "tsup")
+		assert.ErrorContains(err, "yarn Plug'n'Play projects have no node_modules/.bin directory, run 'jpd exec tsup' directly instead")
+		assert.False(mockRunner.HasBeenCalled)
+	})
+})
@@ -0,0 +1,100 @@
+package cmd_test
+
+import (
+	"bytes"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	tmock "github.com/stretchr/testify/mock"
+
+	"github.com/louiss0/javascript-package-delegator/cmd"
+	"github.com/louiss0/javascript-package-delegator/mock"
+	"github.com/louiss0/javascript-package-delegator/testutil"
+)
+
+// fakeExitError mimics *exec.ExitError by reporting a fixed exit code.
+type fakeExitError struct {
+	code int
+}
+
+func (f fakeExitError) Error() string {
+	return fmt.Sprintf("exit status %d", f.code)
+}
+
+func (f fakeExitError) ExitCode() int {
+	return f.code
+}
+
+var _ = Describe("Exit codes", func() {
+	assert := assert.New(GinkgoT())
+
+	var (
+		mockRunner *mock.MockCommandRunner
+		factory    *testutil.RootCommandFactory
+	)
+
+	// executeRaw runs the command without flattening the error so its type survives.
+	executeRaw := func(root *cobra.Command, args ...string) error {
+		root.SetOut(new(bytes.Buffer))
+		root.SetErr(new(bytes.Buffer))
+		root.SetArgs(args)
+		return root.Execute()
+	}
+
+	BeforeEach(func() {
+		mockRunner = mock.NewMockCommandRunner()
+		mockRunner.On("Run", "npm", []string{"install", "broken-pkg"}, tmock.Anything).
+			Return(fakeExitError{code: 7}).Maybe()
+		mockRunner.On("Run", "npm", []string{"install", "wrapped-pkg"}, tmock.Anything).
+			Return(&cmd.CommandExitError{Code: 3, Err: fmt.Errorf("exit status 3")}).Maybe()
+
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+	})
+
+	It("propagates the exit code of the delegated command", func() {
+		err := executeRaw(factory.CreateNpmAsDefault(nil), "install", "broken-pkg")
+		assert.Error(err)
+		assert.Equal(7, cmd.ExitCodeForError(err))
+	})
+
+	It("keeps the exit code captured by the command runner", func() {
+		err := executeRaw(factory.CreateNpmAsDefault(nil), "install", "wrapped-pkg")
+		assert.Error(err)
+		assert.Equal(3, cmd.ExitCodeForError(err))
+	})
+
+	It("keeps the exit code when the failure is wrapped by the command", func() {
+		err := executeRaw(factory.CreateNpmAsDefault(nil), "install", "--separate", "react", "broken-pkg")
+		assert.Error(err)
+		assert.Equal(7, cmd.ExitCodeForError(err))
+	})
+
+	It("keeps the exit code when --continue-on-error collects the failures", func() {
+		err := executeRaw(factory.CreateNpmAsDefault(nil), "install", "--separate", "--continue-on-error", "react", "broken-pkg", "vue")
+		assert.Error(err)
+		assert.Contains(err.Error(), "failed to install 1 of 3 packages")
+		assert.Equal(7, cmd.ExitCodeForError(err))
+	})
+
+	It("uses the usage exit code for validation errors", func() {
+		err := executeRaw(factory.CreateNpmAsDefault(nil), "install", "--continue-on-error", "react")
+		assert.Error(err)
+		assert.Equal(cmd.EXIT_CODE_USAGE_ERROR, cmd.ExitCodeForError(err))
+		assert.False(mockRunner.HasBeenCalled)
+	})
+
+	It("falls back to the failure exit code when the command has none", func() {
+		err := &cmd.CommandExitError{Code: 0, Err: fmt.Errorf("signal: killed")}
+		assert.Equal(cmd.EXIT_CODE_COMMAND_FAILURE, cmd.ExitCodeForError(err))
+	})
+
+	It("returns zero without an error", func() {
+		assert.Equal(0, cmd.ExitCodeForError(nil))
+	})
+})
@@ -0,0 +1,102 @@
+// Package cmd provides command-line interface implementations for the JavaScript package delegator.
+package cmd
+
+import (
+	// standard library
+	"fmt"
+	"strings"
+
+	// external
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+
+	// internal
+	"github.com/louiss0/javascript-package-delegator/detect"
+)
+
+// BuildInfoCommand builds the command line that prints the registry metadata of a package.
+// spec may carry a version, as in react@18.
+func BuildInfoCommand(pm, yarnVersion, spec string, args []string) (program string, argv []string, err error) {
+	if spec == "" {
+		return "", nil, fmt.Errorf("package name is required for info command")
+	}
+
+	switch pm {
+	case "npm", "pnpm":
+		argv = append([]string{"info", spec}, args...)
+		return pm, argv, nil
+	case "yarn":
+		if ParseYarnMajor(yarnVersion) >= 2 {
+			// Yarn v2+ moved registry commands under "yarn npm"
+			argv = append([]string{"npm", "info", spec}, args...)
+		} else {
+			argv = append([]string{"info", spec}, args...)
+		}
+		return "yarn", argv, nil
+	case "bun":
+		argv = append([]string{"info", spec}, args...)
+		return "bun", argv, nil
+	case "deno":
+		if !isURL(spec) && !strings.HasPrefix(spec, "jsr:") && !strings.HasPrefix(spec, "npm:") {
+			return "", nil, fmt.Errorf("deno info requires a URL, jsr: or npm: specifier, got: %s", spec)
+		}
+		argv = append([]string{"info", spec}, args...)
+		return "deno", argv, nil
+	default:
+		return "", nil, unsupportedPackageManagerError(pm)
+	}
+}
+
+// NewInfoCmd creates a new Cobra command for the "info" functionality.
+func NewInfoCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "info <package> [fields...]",
+		Short: "Show registry metadata of a package",
+		Long: `Show the registry metadata of a package using the detected package manager.
+
+Package Manager Behavior:
+- npm:  Runs 'npm info <package>'
+- pnpm: Runs 'pnpm info <package>'
+- yarn: Runs 'yarn info <package>' (v1) or 'yarn npm info <package>' (v2+)
+- bun:  Runs 'bun info <package>'
+- deno: Runs 'deno info <specifier>' (expects a URL, jsr: or npm: specifier)
+
+Examples:
+  jpd info react
+  jpd info react@18
+  jpd info react version
+  jpd -a deno info jsr:@std/path`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pm, _ := cmd.Flags().GetString(AGENT_FLAG)
+			goEnv := getGoEnvFromCommandContext(cmd)
+			cmdRunner := getCommandRunnerFromCommandContext(cmd)
+			de := getDebugExecutorFromCommandContext(cmd)
+
+			yarnVersion := ""
+			if pm == "yarn" {
+				if version, err := detect.DetectYarnVersion(
+					getYarnVersionRunnerCommandContext(cmd),
+				); err == nil {
+					yarnVersion = version
+				}
+			}
+
+			execCommand, cmdArgs, err := BuildInfoCommand(pm, yarnVersion, args[0], args[1:])
+			if err != nil {
+				return err
+			}
+
+			de.LogJSCommandIfDebugIsTrue(execCommand, cmdArgs...)
+			cmdRunner.Command(execCommand, cmdArgs...)
+
+			goEnv.ExecuteIfModeIsProduction(func() {
+				log.Info("Running command", "cmd", execCommand, "args", strings.Join(cmdArgs, " "))
+			})
+
+			return cmdRunner.Run()
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,75 @@
+package cmd_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/louiss0/javascript-package-delegator/cmd"
+	"github.com/louiss0/javascript-package-delegator/mock"
+	"github.com/louiss0/javascript-package-delegator/testutil"
+)
+
+var _ = Describe("Info Command", func() {
+	assert := assert.New(GinkgoT())
+
+	var (
+		mockRunner *mock.MockCommandRunner
+		factory    *testutil.RootCommandFactory
+	)
+
+	BeforeEach(func() {
+		mockRunner = mock.NewMockCommandRunner()
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+	})
+
+	DescribeTable("runs the info command of each package manager",
+		func(newRoot func(f *testutil.RootCommandFactory) *cobra.Command, args []string, expected []string) {
+			_, err := executeCmd(newRoot(factory), append([]string{"info"}, args...)...)
+			assert.NoError(err)
+			assert.True(mockRunner.HasCommand(expected[0], expected[1:]...))
+		},
+		Entry("npm",
+			func(f *testutil.RootCommandFactory) *cobra.Command { return f.CreateNpmAsDefault(nil) },
+			[]string{"react"}, []string{"npm", "info", "react"}),
+		Entry("npm with a version",
+			func(f *testutil.RootCommandFactory) *cobra.Command { return f.CreateNpmAsDefault(nil) },
+			[]string{"react@18", "version"}, []string{"npm", "info", "react@18", "version"}),
+		Entry("pnpm",
+			func(f *testutil.RootCommandFactory) *cobra.Command { return f.CreatePnpmAsDefault(nil) },
+			[]string{"react@18"}, []string{"pnpm", "info", "react@18"}),
+		Entry("yarn v1",
+			func(f *testutil.RootCommandFactory) *cobra.Command { return f.CreateYarnOneAsDefault(nil) },
+			[]string{"react@18"}, []string{"yarn", "info", "react@18"}),
+		Entry("yarn v2+",
+			func(f *testutil.RootCommandFactory) *cobra.Command { return f.CreateYarnTwoAsDefault(nil) },
+			[]string{"react@18"}, []string{"yarn", "npm", "info", "react@18"}),
+		Entry("bun",
+			func(f *testutil.RootCommandFactory) *cobra.Command { return f.CreateBunAsDefault(nil) },
+			[]string{"react@18"}, []string{"bun", "info", "react@18"}),
+		Entry("deno with a jsr specifier",
+			func(f *testutil.RootCommandFactory) *cobra.Command { return f.CreateDenoAsDefault(nil) },
+			[]string{"jsr:@std/path@1"}, []string{"deno", "info", "jsr:@std/path@1"}),
+	)
+
+	It("rejects a bare package name for deno", func() {
+		_, err := executeCmd(factory.CreateDenoAsDefault(nil), "info", "react")
+		assert.ErrorContains(err, "deno info requires a URL, jsr: or npm: specifier")
+		assert.False(mockRunner.HasBeenCalled)
+	})
+
+	It("requires a package name", func() {
+		_, err := executeCmd(factory.CreateNpmAsDefault(nil), "info")
+		assert.Error(err)
+	})
+
+	It("builds the yarn command from the yarn version", func() {
+		_, argv, err := cmd.BuildInfoCommand("yarn", "berry-3.1.0", "react", nil)
+		assert.NoError(err)
+		assert.Equal([]string{"npm", "info", "react"}, argv)
+	})
+})
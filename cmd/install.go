@@ -25,7 +25,16 @@ package cmd
 
 import (
 	// standard library
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 
 	// external
 	"github.com/charmbracelet/huh"
@@ -37,19 +46,316 @@ import (
 	"github.com/louiss0/javascript-package-delegator/custom_errors"
 	"github.com/louiss0/javascript-package-delegator/custom_flags"
 	"github.com/louiss0/javascript-package-delegator/detect"
+	"github.com/louiss0/javascript-package-delegator/internal/deps"
 	"github.com/louiss0/javascript-package-delegator/services"
 )
 
 // Add flags
 const (
-	_DEV_FLAG        = "dev"
-	_GLOBAL_FLAG     = "global"
-	_PRODUCTION_FLAG = "production"
-	_FROZEN_FLAG     = "frozen"
-	_SEARCH_FLAG     = "search"
-	_NO_VOLTA_FLAG   = "no-volta"
+	_DEV_FLAG              = "dev"
+	_GLOBAL_FLAG           = "global"
+	_PRODUCTION_FLAG       = "production"
+	_FROZEN_FLAG           = "frozen"
+	_SEARCH_FLAG           = "search"
+	_NO_VOLTA_FLAG         = "no-volta"
+	_SEPARATE_FLAG         = "separate"
+	_CONTINUE_FLAG         = "continue-on-error"
+	_REGISTRY_FLAG         = "registry"
+	_NODE_LINKER_FLAG      = "node-linker"
+	_EXACT_FLAG            = "exact"
+	_WORKSPACE_ROOT_FLAG   = "workspace-root"
+	_SAVE_PREFIX_FLAG      = "save-prefix"
+	_HOIST_FLAG            = "hoist"
+	_STORE_DIR_FLAG        = "store-dir"
+	_WITH_PEERS_FLAG       = "with-peers"
+	_LOCKFILE_ONLY_FLAG    = "lockfile-only"
+	_BUNDLE_FLAG           = "bundle"
+	_MAX_SOCKETS_FLAG      = "max-sockets"
+	_ONLY_FLAG             = "only"
+	_NAME_FLAG             = "name"
+	_SEARCH_BACKEND_FLAG   = "search-backend"
+	_PREFER_WORKSPACE_FLAG = "prefer-workspace"
 )
 
+// Values of --search-backend
+const (
+	_SEARCH_BACKEND_HTTP = "http" // Search the npm registry over HTTP
+	_SEARCH_BACKEND_EXEC = "exec" // Run npm search --json, for machines that only reach the registry through npm
+)
+
+var searchBackends = []string{_SEARCH_BACKEND_HTTP, _SEARCH_BACKEND_EXEC}
+
+// NpmSearchOutputter returns what npm prints when it runs with args in targetDir.
+type NpmSearchOutputter func(targetDir string, args ...string) ([]byte, error)
+
+// runNpmSearch runs npm with args in targetDir and returns its output.
+func runNpmSearch(targetDir string, args ...string) ([]byte, error) {
+	searchCmd := exec.Command("npm", args...)
+	searchCmd.Dir = targetDir
+	return searchCmd.Output()
+}
+
+// denoPermissionFlags are the permission flags of deno install that --global forwards after the packages.
+var denoPermissionFlags = []string{
+	"allow-all", "allow-read", "allow-write", "allow-net", "allow-env",
+	"allow-run", "allow-sys", "allow-ffi", "allow-import",
+}
+
+// denoPermissionGranted is the value of a permission flag passed without a list, like --allow-net.
+const denoPermissionGranted = "*"
+
+// savePrefixes are the range characters --save-prefix accepts, an empty prefix saves exact versions.
+var savePrefixes = []string{"^", "~"}
+
+// shamefullyHoistFlag is the pnpm name of --hoist, kept as a hidden alias.
+const shamefullyHoistFlag = "shamefully-hoist"
+
+// cacheDirFlag is the bun name of --store-dir, kept as a hidden alias.
+const cacheDirFlag = "cache-dir"
+
+// pnpmAddingToRootError is the error code pnpm prints when it refuses to add to the workspace root.
+const pnpmAddingToRootError = "ERR_PNPM_ADDING_TO_ROOT"
+
+// yarnNodeLinkers maps the --node-linker values to the matching Yarn Berry nodeLinker setting.
+var yarnNodeLinkers = map[string]string{
+	"isolated": "pnpm",
+	"hoisted":  "node-modules",
+	"pnp":      "pnp",
+}
+
+// PeerDependencyFetcher is a minimal interface to read the peer dependencies of a package from the registry.
+type PeerDependencyFetcher interface {
+	PeerDependencies(pkg string) (map[string]string, error)
+}
+
+// InstallOptions holds the install flags that change the argv of the package manager.
+type InstallOptions struct {
+	Dev           bool
+	Global        bool
+	Production    bool
+	Frozen        bool
+	SaveExact     bool
+	WorkspaceRoot bool
+	LockfileOnly  bool
+	DevOnly       bool
+	Name          string   // The executable name of a global deno install
+	Permissions   []string // The permission flags of a global deno install
+}
+
+// BuildInstallCommand builds the install command line of each package manager.
+// An empty package list installs the dependencies of the project.
+func BuildInstallCommand(pm, yarnVersion string, packages []string, opts InstallOptions) (program string, args []string, err error) {
+	switch pm {
+	case "npm":
+		args = append([]string{"install"}, packages...)
+		if opts.Dev {
+			args = append(args, "--save-dev")
+		}
+		if opts.Global {
+			args = append(args, "--global")
+		}
+		if opts.Production {
+			args = append(args, "--omit=dev")
+		}
+		// npm 7 dropped --only=dev, only --only=prod is still read as --omit=dev
+		if opts.DevOnly {
+			return "", nil, fmt.Errorf("npm can't install only the devDependencies")
+		}
+		if opts.Frozen || opts.LockfileOnly {
+			args = append(args, "--package-lock-only")
+		}
+		if opts.SaveExact {
+			args = append(args, "--save-exact")
+		}
+
+	case "yarn":
+		args = append([]string{lo.Ternary(len(packages) == 0, "install", "add")}, packages...)
+		if opts.Dev {
+			args = append(args, "--dev")
+		}
+		if opts.Global {
+			args = append(args, "--global")
+		}
+		if opts.Production {
+			args = append(args, "--production")
+		}
+		if opts.DevOnly {
+			return "", nil, fmt.Errorf("yarn can't install only the devDependencies")
+		}
+		if opts.Frozen {
+			args = append(args, "--frozen-lockfile")
+		}
+		if opts.LockfileOnly {
+			if ParseYarnMajor(yarnVersion) < 2 {
+				return "", nil, fmt.Errorf("yarn v1 doesn't support lockfile only installs, use yarn v2 or newer")
+			}
+			args = append(args, "--mode=update-lockfile")
+		}
+		if opts.SaveExact {
+			args = append(args, "--exact")
+		}
+		// Yarn v2+ adds to the workspace root without asking
+		if opts.WorkspaceRoot && ParseYarnMajor(yarnVersion) < 2 {
+			args = append(args, "-W")
+		}
+
+	case "pnpm":
+		args = append([]string{lo.Ternary(len(packages) == 0, "install", "add")}, packages...)
+		if opts.Dev {
+			args = append(args, "--save-dev")
+		}
+		if opts.Global {
+			args = append(args, "--global")
+		}
+		if opts.Production {
+			args = append(args, "--prod")
+		}
+		if opts.DevOnly {
+			args = append(args, "--dev")
+		}
+		if opts.Frozen {
+			args = append(args, "--frozen-lockfile")
+		}
+		if opts.LockfileOnly {
+			args = append(args, "--lockfile-only")
+		}
+		if opts.SaveExact {
+			args = append(args, "--save-exact")
+		}
+		if opts.WorkspaceRoot {
+			args = append(args, "-w")
+		}
+
+	case "bun":
+		args = append([]string{lo.Ternary(len(packages) == 0, "install", "add")}, packages...)
+		if opts.Dev {
+			args = append(args, "--development")
+		}
+		if opts.Global {
+			args = append(args, "--global")
+		}
+		if opts.Production {
+			args = append(args, "--production")
+		}
+		if opts.DevOnly {
+			return "", nil, fmt.Errorf("bun can't install only the devDependencies")
+		}
+		if opts.LockfileOnly {
+			args = append(args, "--lockfile-only")
+		}
+		if opts.SaveExact {
+			args = append(args, "--exact")
+		}
+
+	case "deno":
+		if opts.LockfileOnly {
+			return "", nil, fmt.Errorf("deno doesn't support lockfile only installs")
+		}
+		if len(packages) == 0 {
+			return "", nil, fmt.Errorf("for deno one or more packages is required")
+		}
+		if opts.Production {
+			return "", nil, fmt.Errorf("deno doesn't support prod")
+		}
+		if opts.DevOnly {
+			return "", nil, fmt.Errorf("deno doesn't support dev only installs")
+		}
+		if opts.SaveExact {
+			return "", nil, fmt.Errorf("deno doesn't support exact installs")
+		}
+		if opts.WorkspaceRoot {
+			return "", nil, fmt.Errorf("deno doesn't support workspace root installs")
+		}
+		if opts.Global {
+			if opts.Name != "" && len(packages) > 1 {
+				return "", nil, fmt.Errorf("deno names one global install at a time, got %d packages", len(packages))
+			}
+			args = []string{"install", "--global"}
+			if opts.Name != "" {
+				args = append(args, "-n", opts.Name)
+			}
+			return "deno", lo.Flatten([][]string{args, packages, opts.Permissions}), nil
+		}
+
+		args = append([]string{"add"}, packages...)
+		if opts.Dev {
+			args = append(args, "--dev")
+		}
+
+	default:
+		return "", nil, unsupportedPackageManagerError(pm)
+	}
+
+	// deno install --global returned above, it is the only install that takes a name
+	if opts.Name != "" {
+		return "", nil, fmt.Errorf("only deno install --global takes a name, %s can't use one", lo.Ternary(pm == "deno", "deno add", pm))
+	}
+
+	return pm, args, nil
+}
+
+// BuildGlobalListCommand builds the command line that lists the global packages of each package manager.
+// Deno has no such command, listDenoGlobalInstalls reads its global installs instead.
+func BuildGlobalListCommand(pm, yarnVersion string) (program string, args []string, err error) {
+	switch pm {
+	case "npm":
+		return pm, []string{"ls", "-g", "--depth=0"}, nil
+
+	case "pnpm":
+		return pm, []string{"ls", "-g"}, nil
+
+	case "yarn":
+		if ParseYarnMajor(yarnVersion) >= 2 {
+			return "", nil, fmt.Errorf("yarn %s has no global packages to list, yarn global was removed in v2", strings.TrimSpace(yarnVersion))
+		}
+		return pm, []string{"global", "list"}, nil
+
+	case "bun":
+		return pm, []string{"pm", "ls", "-g"}, nil
+
+	case "deno":
+		return "", nil, fmt.Errorf("deno has no command that lists its global installs")
+
+	default:
+		return "", nil, unsupportedPackageManagerError(pm)
+	}
+}
+
+// listDenoGlobalInstalls writes the name of every executable deno install --global put in the bin directory
+// of the install root, DENO_INSTALL_ROOT or ~/.deno.
+func listDenoGlobalInstalls(cmd *cobra.Command) error {
+	installRoot := os.Getenv("DENO_INSTALL_ROOT")
+	if installRoot == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return fmt.Errorf("failed to find the deno install root: %w", err)
+		}
+		installRoot = filepath.Join(home, ".deno")
+	}
+	binDir := filepath.Join(installRoot, "bin")
+
+	entries, err := os.ReadDir(binDir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return printNote(cmd, "deno has no global installs in %s", binDir)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", binDir, err)
+	}
+
+	for _, entry := range entries {
+		name := strings.TrimSuffix(entry.Name(), ".cmd")
+		// deno itself lives there when it was installed with the install script
+		if entry.IsDir() || strings.TrimSuffix(name, ".exe") == "deno" {
+			continue
+		}
+		if _, err := fmt.Fprintln(cmd.OutOrStdout(), name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 type packageMultiSelectUI struct {
 	value         []string
 	multiSelectUI *huh.MultiSelect[string]
@@ -87,7 +393,12 @@ func (p *packageMultiSelectUI) Run() error {
 // This command delegates to the appropriate JavaScript package manager (npm, Yarn, pnpm, Bun, or Deno)
 // to install project dependencies or specific packages.
 // It also includes optional Volta integration to ensure consistent toolchain usage.
-func NewInstallCmd(detectVolta func() bool, newPackageMultiSelectUI func([]services.PackageInfo) MultiUISelecter) *cobra.Command {
+func NewInstallCmd(
+	detectVolta func() bool,
+	newPackageMultiSelectUI func([]services.PackageInfo) MultiUISelecter,
+	newPeerDependencyFetcher func() PeerDependencyFetcher,
+	npmSearch NpmSearchOutputter,
+) *cobra.Command {
 	searchFlag := custom_flags.NewEmptyStringFlag(_SEARCH_FLAG)
 
 	cmd := &cobra.Command{
@@ -100,8 +411,24 @@ Examples:
   jpd install           # Install all dependencies
   jpd install lodash    # Install lodash
   jpd install -D vitest # Install vitest as dev dependency
+  jpd install --prefer-workspace @acme/ui # Link the @acme/ui package of the monorepo instead of the registry one
   jpd install -g typescript # Install globally
+  jpd install -g --list # List the global packages (npm ls -g --depth=0, pnpm ls -g, yarn global list, bun pm ls -g)
+  jpd install -g -n serve jsr:@std/http/file-server --allow-net --allow-read # Forward the deno permission flags after the package
+  jpd install -g --name mytool https://deno.land/x/mytool/cli.ts # Name a global deno install (deno install -n)
+  jpd install --search react --search-backend exec # Search with npm search, through the registry and proxy npm is set up with
   jpd install --no-volta # Install packages bypassing Volta, even if installed
+  jpd install --registry https://npm.example.com @acme/ui # Install from a private registry
+  jpd install -W lodash  # Add lodash to the workspace root
+  jpd install --node-linker hoisted # Install with a hoisted node_modules layout (pnpm and yarn v2+)
+  jpd install --hoist    # Install with pnpm --shamefully-hoist, the other package managers already hoist
+  jpd install --store-dir .cache/pnpm # Keep the store or cache in a directory CI can cache
+  jpd install --only devDependencies # Install only the devDependencies of package.json (pnpm --dev)
+  jpd install --max-sockets 5 # Open at most 5 connections to the registry (npm --maxsockets, pnpm --network-concurrency)
+  jpd install --separate --continue-on-error react vue # Install each package on its own, reporting failures at the end
+  jpd install --lockfile-only # Update the lockfile without touching node_modules
+  jpd install --with-peers react-redux # Install react-redux with the peer dependencies it declares
+  jpd install --bundle lodash # Install lodash and add it to bundledDependencies (npm only)
 `,
 		Aliases: []string{"i", "add"},
 		Args: func(cmd *cobra.Command, args []string) error {
@@ -119,15 +446,84 @@ Examples:
 			cmdRunner := getCommandRunnerFromCommandContext(cmd)
 			de := getDebugExecutorFromCommandContext(cmd)
 
+			targetDir, err := cmd.Flags().GetString(_CWD_FLAG)
+			if err != nil {
+				return fmt.Errorf("failed to parse --%s flag: %w", _CWD_FLAG, err)
+			}
+			if targetDir == "" {
+				targetDir, err = os.Getwd()
+				if err != nil {
+					return fmt.Errorf("failed to get current working directory: %w", err)
+				}
+			}
+
+			if err := checkNodeEngineIfRequested(cmd, targetDir); err != nil {
+				return err
+			}
+
+			yarnVersion := ""
+			if pm == detect.YARN {
+				if version, err := detect.DetectYarnVersion(
+					getYarnVersionRunnerCommandContext(cmd),
+				); err == nil {
+					yarnVersion = version
+				}
+			}
+
+			listGlobal, err := cmd.Flags().GetBool(_LIST_FLAG)
+			if err != nil {
+				return err
+			}
+			if listGlobal {
+				if global, _ := cmd.Flags().GetBool(_GLOBAL_FLAG); !global {
+					return fmt.Errorf("the --%s flag lists the global packages, it requires --%s", _LIST_FLAG, _GLOBAL_FLAG)
+				}
+				if len(args) > 0 {
+					return fmt.Errorf("the --%s flag takes no packages", _LIST_FLAG)
+				}
+
+				if pm == detect.DENO {
+					return listDenoGlobalInstalls(cmd)
+				}
+
+				program, listArgs, err := BuildGlobalListCommand(pm, yarnVersion)
+				if err != nil {
+					return err
+				}
+
+				cmdRunner.Command(program, listArgs...)
+				de.LogJSCommandIfDebugIsTrue(program, listArgs...)
+				return cmdRunner.Run()
+			}
+
 			// Build command based on package manager and flags
-			var cmdArgs []string
 			var selectedPackages []string
 
+			searchBackend, err := cmd.Flags().GetString(_SEARCH_BACKEND_FLAG)
+			if err != nil {
+				return err
+			}
+			if !lo.Contains(searchBackends, searchBackend) {
+				return fmt.Errorf("the --%s flag must be one of %v, got: %s", _SEARCH_BACKEND_FLAG, searchBackends, searchBackend)
+			}
+			if cmd.Flags().Changed(_SEARCH_BACKEND_FLAG) && searchFlag.String() == "" {
+				return fmt.Errorf("the --%s flag requires --%s", _SEARCH_BACKEND_FLAG, _SEARCH_FLAG)
+			}
+
 			if searchFlag.String() != "" {
+				if err := errIfQuiet(cmd, "choosing packages from the search results"); err != nil {
+					return err
+				}
 
-				npmRegistryService := services.NewNpmRegistryService()
+				var searcher services.PackageSearcher = services.NewNpmRegistryService()
+				if searchBackend == _SEARCH_BACKEND_EXEC {
+					searcher = services.NewNpmCLISearcher(func(args ...string) ([]byte, error) {
+						de.LogJSCommandIfDebugIsTrue("npm", args...)
+						return npmSearch(targetDir, args...)
+					})
+				}
 
-				packageInfo, err := npmRegistryService.SearchPackages(searchFlag.String())
+				packageInfo, err := searcher.SearchPackages(searchFlag.String())
 				if err != nil {
 					return err
 				}
@@ -146,117 +542,331 @@ Examples:
 
 			}
 
-			switch pm {
-			case "npm":
-				if len(args) == 0 {
-					cmdArgs = lo.Flatten([][]string{{"install"}, selectedPackages})
-				} else {
-					cmdArgs = lo.Flatten([][]string{
-						{"install"},
-						args,
-					})
-				}
-				if dev, _ := cmd.Flags().GetBool("dev"); dev {
-					cmdArgs = append(cmdArgs, "--save-dev")
-				}
-				if global, _ := cmd.Flags().GetBool("global"); global {
-					cmdArgs = append(cmdArgs, "--global")
+			registry, err := cmd.Flags().GetString(_REGISTRY_FLAG)
+			if err != nil {
+				return err
+			}
+
+			var registryArgs []string
+			if registry != "" {
+				if !isURL(registry) {
+					return fmt.Errorf("the --%s flag must be an http or https URL, got: %s", _REGISTRY_FLAG, registry)
 				}
-				if production, _ := cmd.Flags().GetBool("production"); production {
-					cmdArgs = append(cmdArgs, "--omit=dev")
+
+				switch pm {
+				case detect.DENO:
+					return fmt.Errorf("deno does not support the --%s flag", _REGISTRY_FLAG)
+
+				case detect.YARN:
+					if ParseYarnMajor(yarnVersion) >= 2 {
+						// Yarn v2+ has no --registry option, the registry lives in .yarnrc.yml
+						err := printNote(
+							cmd,
+							"yarn %s reads the registry from npmRegistryServer in .yarnrc.yml, --%s is ignored",
+							strings.TrimSpace(yarnVersion), _REGISTRY_FLAG,
+						)
+						if err != nil {
+							return err
+						}
+						break
+					}
+					registryArgs = []string{"--registry", registry}
+
+				default:
+					registryArgs = []string{"--registry", registry}
 				}
-				if frozen, _ := cmd.Flags().GetBool("frozen"); frozen {
-					cmdArgs = append(cmdArgs, "--package-lock-only")
+			}
+
+			nodeLinker, err := cmd.Flags().GetString(_NODE_LINKER_FLAG)
+			if err != nil {
+				return err
+			}
+
+			// Yarn v2+ takes some settings from the environment only, they are set together once every flag is read
+			var yarnEnv []string
+
+			var nodeLinkerArgs []string
+			if nodeLinker != "" {
+				yarnNodeLinker, ok := yarnNodeLinkers[nodeLinker]
+				if !ok {
+					return fmt.Errorf(
+						"the --%s flag must be one of %v, got: %s",
+						_NODE_LINKER_FLAG, []string{"isolated", "hoisted", "pnp"}, nodeLinker,
+					)
 				}
 
-			case "yarn":
-				if len(args) == 0 {
-					cmdArgs = lo.Flatten([][]string{{"install"}, selectedPackages})
-				} else {
-					cmdArgs = lo.Flatten([][]string{
-						{"add"},
-						args,
-					})
+				switch pm {
+				case detect.PNPM:
+					nodeLinkerArgs = []string{fmt.Sprintf("--config.node-linker=%s", nodeLinker)}
+
+				case detect.YARN:
+					if ParseYarnMajor(yarnVersion) < 2 {
+						return fmt.Errorf("the --%s flag requires yarn v2 or newer", _NODE_LINKER_FLAG)
+					}
+
+					// Yarn v2+ has no --nodeLinker option, the environment overrides .yarnrc.yml instead
+					err := printNote(
+						cmd,
+						"yarn %s has no node linker option, --%s is passed as YARN_NODE_LINKER",
+						strings.TrimSpace(yarnVersion), _NODE_LINKER_FLAG,
+					)
+					if err != nil {
+						return err
+					}
+					yarnEnv = append(yarnEnv, "YARN_NODE_LINKER="+yarnNodeLinker)
+
+				default:
+					return fmt.Errorf("%s does not support the --%s flag", pm, _NODE_LINKER_FLAG)
 				}
-				if dev, _ := cmd.Flags().GetBool("dev"); dev {
-					cmdArgs = append(cmdArgs, "--dev")
+			}
+
+			hoist, err := cmd.Flags().GetBool(_HOIST_FLAG)
+			if err != nil {
+				return err
+			}
+			shamefullyHoist, err := cmd.Flags().GetBool(shamefullyHoistFlag)
+			if err != nil {
+				return err
+			}
+			hoist = hoist || shamefullyHoist
+
+			var hoistArgs []string
+			if hoist {
+				switch pm {
+				case detect.PNPM:
+					hoistArgs = []string{"--" + shamefullyHoistFlag}
+
+				case detect.NPM, detect.YARN, detect.BUN:
+					err := printNote(
+						cmd,
+						"%s hoists dependencies by default, --%s is ignored",
+						pm, _HOIST_FLAG,
+					)
+					if err != nil {
+						return err
+					}
+
+				default:
+					return fmt.Errorf("%s does not support the --%s flag", pm, _HOIST_FLAG)
 				}
-				if global, _ := cmd.Flags().GetBool("global"); global {
-					cmdArgs = append(cmdArgs, "--global")
+			}
+
+			preferWorkspace, err := cmd.Flags().GetBool(_PREFER_WORKSPACE_FLAG)
+			if err != nil {
+				return err
+			}
+
+			// pnpm links workspace packages through an option, yarn through the workspace: protocol of each package
+			var preferWorkspaceArgs []string
+			if preferWorkspace {
+				switch pm {
+				case detect.PNPM:
+					preferWorkspaceArgs = []string{"--prefer-workspace-packages"}
+
+				case detect.YARN:
+					if ParseYarnMajor(yarnVersion) < 2 {
+						return fmt.Errorf("the --%s flag requires yarn v2 or newer, yarn v1 has no workspace: protocol", _PREFER_WORKSPACE_FLAG)
+					}
+					if len(args) == 0 && searchFlag.String() == "" {
+						return fmt.Errorf("the --%s flag requires at least one package on yarn", _PREFER_WORKSPACE_FLAG)
+					}
+
+				default:
+					return fmt.Errorf("%s does not support the --%s flag", pm, _PREFER_WORKSPACE_FLAG)
 				}
-				if production, _ := cmd.Flags().GetBool("production"); production {
-					cmdArgs = append(cmdArgs, "--production")
+			}
+
+			storeDir, err := cmd.Flags().GetString(_STORE_DIR_FLAG)
+			if err != nil {
+				return err
+			}
+			if cmd.Flags().Changed(cacheDirFlag) {
+				if storeDir, err = cmd.Flags().GetString(cacheDirFlag); err != nil {
+					return err
 				}
-				if frozen, _ := cmd.Flags().GetBool("frozen"); frozen {
-					cmdArgs = append(cmdArgs, "--frozen-lockfile")
+			}
+
+			var storeDirArgs []string
+			if cmd.Flags().Changed(_STORE_DIR_FLAG) || cmd.Flags().Changed(cacheDirFlag) {
+				if storeDir, err = resolveStoreDir(targetDir, storeDir); err != nil {
+					return err
 				}
 
-			case "pnpm":
-				if len(args) == 0 {
-					cmdArgs = lo.Flatten([][]string{{"install"}, selectedPackages})
-				} else {
-					cmdArgs = lo.Flatten([][]string{
-						{"add"},
-						selectedPackages,
-						args,
-					})
+				switch pm {
+				case detect.NPM:
+					storeDirArgs = []string{"--cache", storeDir}
+
+				case detect.PNPM:
+					storeDirArgs = []string{"--store-dir", storeDir}
+
+				case detect.BUN:
+					storeDirArgs = []string{"--cache-dir", storeDir}
+
+				case detect.YARN:
+					if ParseYarnMajor(yarnVersion) < 2 {
+						storeDirArgs = []string{"--cache-folder", storeDir}
+						break
+					}
+
+					// Yarn v2+ has no --cache-folder option, the environment overrides .yarnrc.yml instead
+					err := printNote(
+						cmd,
+						"yarn %s has no cache folder option, --%s is passed as YARN_CACHE_FOLDER",
+						strings.TrimSpace(yarnVersion), _STORE_DIR_FLAG,
+					)
+					if err != nil {
+						return err
+					}
+					yarnEnv = append(yarnEnv, "YARN_CACHE_FOLDER="+storeDir)
+
+				default:
+					return fmt.Errorf("%s does not support the --%s flag", pm, _STORE_DIR_FLAG)
 				}
-				if dev, _ := cmd.Flags().GetBool("dev"); dev {
-					cmdArgs = append(cmdArgs, "--save-dev")
+			}
+
+			if len(yarnEnv) > 0 {
+				cmdRunner.SetEnv(yarnEnv)
+			}
+
+			maxSocketsArgs, err := readMaxSocketsArgs(cmd, pm, yarnVersion)
+			if err != nil {
+				return err
+			}
+
+			dev, _ := cmd.Flags().GetBool(_DEV_FLAG)
+			global, _ := cmd.Flags().GetBool(_GLOBAL_FLAG)
+			production, _ := cmd.Flags().GetBool(_PRODUCTION_FLAG)
+			frozen, _ := cmd.Flags().GetBool(_FROZEN_FLAG)
+			lockfileOnly, _ := cmd.Flags().GetBool(_LOCKFILE_ONLY_FLAG)
+			exact, _ := cmd.Flags().GetBool(_EXACT_FLAG)
+
+			var savePrefixArgs []string
+			if cmd.Flags().Changed(_SAVE_PREFIX_FLAG) {
+				savePrefix, err := cmd.Flags().GetString(_SAVE_PREFIX_FLAG)
+				if err != nil {
+					return err
 				}
-				if global, _ := cmd.Flags().GetBool("global"); global {
-					cmdArgs = append(cmdArgs, "--global")
+
+				if savePrefix != "" && !lo.Contains(savePrefixes, savePrefix) {
+					return fmt.Errorf(
+						"the --%s flag must be one of %v or empty for exact versions, got: %s",
+						_SAVE_PREFIX_FLAG, savePrefixes, savePrefix,
+					)
 				}
-				if production, _ := cmd.Flags().GetBool("production"); production {
-					cmdArgs = append(cmdArgs, "--prod")
+				if savePrefix != "" && exact {
+					return fmt.Errorf("the --%s flag can't be combined with --%s", _SAVE_PREFIX_FLAG, _EXACT_FLAG)
 				}
-				if frozen, _ := cmd.Flags().GetBool("frozen"); frozen {
-					cmdArgs = append(cmdArgs, "--frozen-lockfile")
+
+				switch pm {
+				case detect.NPM, detect.PNPM:
+					if savePrefix == "" {
+						exact = true
+						break
+					}
+					savePrefixArgs = []string{"--save-prefix", savePrefix}
+
+				case detect.YARN:
+					if savePrefix == "" {
+						exact = true
+						break
+					}
+					if ParseYarnMajor(yarnVersion) >= 2 {
+						err := printNote(
+							cmd,
+							"yarn %s reads the range prefix from defaultSemverRangePrefix in .yarnrc.yml, --%s is ignored",
+							strings.TrimSpace(yarnVersion), _SAVE_PREFIX_FLAG,
+						)
+						if err != nil {
+							return err
+						}
+						break
+					}
+					// Yarn v1 saves caret ranges unless told to use a tilde
+					if savePrefix == "~" {
+						savePrefixArgs = []string{"--tilde"}
+					}
+
+				default:
+					return fmt.Errorf("%s does not support the --%s flag", pm, _SAVE_PREFIX_FLAG)
 				}
+			}
 
-			case "bun":
-				if len(args) == 0 {
-					cmdArgs = lo.Flatten([][]string{{"install"}, selectedPackages})
-				} else {
-					cmdArgs = lo.Flatten([][]string{
-						{"add"},
-						args,
-					})
+			workspaceRoot, _ := cmd.Flags().GetBool(_WORKSPACE_ROOT_FLAG)
+			// Only pnpm and yarn v1 refuse to add to the workspace root without a flag
+			if workspaceRoot && (pm == detect.NPM || pm == detect.BUN || (pm == detect.YARN && ParseYarnMajor(yarnVersion) >= 2)) {
+				err := printNote(
+					cmd,
+					"%s adds to the workspace root without a flag, --%s is ignored",
+					strings.TrimSpace(lo.Ternary(pm == detect.YARN, "yarn "+yarnVersion, pm)), _WORKSPACE_ROOT_FLAG,
+				)
+				if err != nil {
+					return err
 				}
-				if dev, _ := cmd.Flags().GetBool("dev"); dev {
-					cmdArgs = append(cmdArgs, "--development")
+			}
+
+			installOptions := InstallOptions{
+				Dev:           dev,
+				Global:        global,
+				Production:    production,
+				Frozen:        frozen,
+				SaveExact:     exact,
+				WorkspaceRoot: workspaceRoot,
+				LockfileOnly:  lockfileOnly,
+			}
+
+			name, err := cmd.Flags().GetString(_NAME_FLAG)
+			if err != nil {
+				return err
+			}
+			permissions := readDenoPermissionArgs(cmd)
+			if name != "" {
+				if pm != detect.DENO {
+					return fmt.Errorf("%s can't name a global install, the --%s flag only works with deno", pm, _NAME_FLAG)
 				}
-				if global, _ := cmd.Flags().GetBool("global"); global {
-					cmdArgs = append(cmdArgs, "--global")
+				if !global {
+					return fmt.Errorf("the --%s flag names a global install, it requires --%s", _NAME_FLAG, _GLOBAL_FLAG)
 				}
-				if production, _ := cmd.Flags().GetBool("production"); production {
-					cmdArgs = append(cmdArgs, "--production")
+				installOptions.Name = name
+			}
+			if len(permissions) > 0 {
+				if pm != detect.DENO || !global {
+					return fmt.Errorf("%s only works with --%s on deno", strings.Join(permissions, " "), _GLOBAL_FLAG)
 				}
+				installOptions.Permissions = permissions
+			}
 
-			case "deno":
-
-				if len(args) == 0 {
-					return fmt.Errorf("for deno one or more packages is required")
+			only, err := cmd.Flags().GetString(_ONLY_FLAG)
+			if err != nil {
+				return err
+			}
+			if only != "" {
+				if !lo.Contains(installOnlyGroups, only) {
+					return fmt.Errorf("the --%s flag must be one of %v, got: %s", _ONLY_FLAG, installOnlyGroups, only)
 				}
-
-				if production, _ := cmd.Flags().GetBool("production"); production {
-					return fmt.Errorf("deno doesn't support prod")
+				if len(args) > 0 || searchFlag.String() != "" {
+					return fmt.Errorf("the --%s flag installs a group of package.json, it takes no packages", _ONLY_FLAG)
 				}
 
-				if global, _ := cmd.Flags().GetBool("global"); global {
-
-					cmdArgs = append([]string{"install"}, args...)
-					break
+				group, err := readDependencyGroupFrom(targetDir, only)
+				if err != nil {
+					return err
+				}
+				if len(group) == 0 {
+					return printNote(cmd, "package.json has no %s, there is nothing to install", only)
 				}
 
-				cmdArgs = append([]string{"add"}, args...)
+				installOptions.Production = only == "dependencies"
+				installOptions.DevOnly = only == "devDependencies"
+			}
 
-				if dev, _ := cmd.Flags().GetBool("dev"); dev {
-					cmdArgs = append(cmdArgs, "--dev")
+			// buildInstallArgs maps the packages to the install arguments of the package manager.
+			buildInstallArgs := func(packages []string) ([]string, error) {
+				_, cmdArgs, err := BuildInstallCommand(pm, yarnVersion, packages, installOptions)
+				if err != nil {
+					return nil, err
 				}
 
-			default:
-				return fmt.Errorf("unsupported package manager: %s", pm)
+				return lo.Flatten([][]string{cmdArgs, registryArgs, nodeLinkerArgs, hoistArgs, preferWorkspaceArgs, savePrefixArgs, storeDirArgs, maxSocketsArgs}), nil
 			}
 
 			noVolta, err := cmd.Flags().GetBool(_NO_VOLTA_FLAG)
@@ -264,6 +874,14 @@ Examples:
 				return err
 			}
 
+			retries, err := cmd.Flags().GetInt(_RETRIES_FLAG)
+			if err != nil {
+				return err
+			}
+			if retries, err = parseRetries(retries); err != nil {
+				return err
+			}
+
 			// shouldUseVoltaWithPackageManager is true if:
 			// 1. Volta is detected on the system (detectVolta())
 			// 2. The detected package manager (pm) is one of npm, pnpm, or yarn (lo.Contains checks this)
@@ -272,40 +890,348 @@ Examples:
 				lo.Contains([]string{detect.NPM, detect.PNPM, detect.YARN}, pm) &&
 				!noVolta
 
-			if shouldUseVoltaWithPackageManager {
+			// runInstall hands the arguments to the command runner, going through Volta when needed.
+			runInstall := func(cmdArgs []string) error {
+				if shouldUseVoltaWithPackageManager {
+
+					completeVoltaCommand := lo.Flatten([][]string{
+						detect.VOLTA_RUN_COMMAND,
+						{pm},
+						cmdArgs,
+					})
+					cmdRunner.Command(completeVoltaCommand[0], completeVoltaCommand[1:]...)
+
+					goEnv.ExecuteIfModeIsProduction(func() {
+						log.Info("Executing this ", "command", completeVoltaCommand)
+					})
+					de.LogJSCommandIfDebugIsTrue(completeVoltaCommand[0], completeVoltaCommand[1:]...)
+				} else {
+
+					cmdRunner.Command(pm, cmdArgs...)
+
+					goEnv.ExecuteIfModeIsProduction(func() {
+						log.Info("Executing this ", "command", append([]string{pm}, cmdArgs...))
+					})
+					de.LogJSCommandIfDebugIsTrue(pm, cmdArgs...)
+				}
+
+				// Execute the command
+				err := runWithRetries(cmd, cmdRunner, retries)
+
+				var exitErr *CommandExitError
+				if pm == detect.PNPM && !workspaceRoot && errors.As(err, &exitErr) &&
+					strings.Contains(exitErr.Stderr, pnpmAddingToRootError) {
+					return fmt.Errorf("%w (hint: pass -W/--%s to add the dependency to the workspace root)", err, _WORKSPACE_ROOT_FLAG)
+				}
+
+				return err
+			}
+
+			separate, err := cmd.Flags().GetBool(_SEPARATE_FLAG)
+			if err != nil {
+				return err
+			}
+
+			continueOnError, err := cmd.Flags().GetBool(_CONTINUE_FLAG)
+			if err != nil {
+				return err
+			}
+
+			if continueOnError && !separate {
+				return fmt.Errorf("the --%s flag requires --%s", _CONTINUE_FLAG, _SEPARATE_FLAG)
+			}
+
+			bundle, err := cmd.Flags().GetStringArray(_BUNDLE_FLAG)
+			if err != nil {
+				return err
+			}
+			if len(bundle) > 0 && pm != detect.NPM {
+				return fmt.Errorf("%s has no bundledDependencies, the --%s flag only works with npm", pm, _BUNDLE_FLAG)
+			}
+
+			// react react@18 installs react once, at the version that was asked for
+			packages := DedupePackageSpecs(lo.Flatten([][]string{selectedPackages, args, bundle}))
+			if preferWorkspace && pm == detect.YARN {
+				if packages, err = withWorkspaceProtocol(packages); err != nil {
+					return err
+				}
+			}
+
+			// recordBundledDependencies adds the --bundle packages to package.json once they are installed
+			recordBundledDependencies := func() error {
+				if len(bundle) == 0 {
+					return nil
+				}
+				if _, err := deps.AddBundledDependencies(targetDir, ParsePackageNames(bundle)); err != nil {
+					return fmt.Errorf("failed to record the bundled dependencies: %w", err)
+				}
+				return nil
+			}
+
+			withPeers, err := cmd.Flags().GetBool(_WITH_PEERS_FLAG)
+			if err != nil {
+				return err
+			}
+			if withPeers {
+				if len(packages) == 0 {
+					return fmt.Errorf("the --%s flag requires at least one package", _WITH_PEERS_FLAG)
+				}
+				if pm == detect.DENO {
+					return fmt.Errorf("deno does not support the --%s flag", _WITH_PEERS_FLAG)
+				}
+
+				present, err := readDependencyNamesFrom(targetDir)
+				if err != nil && !errors.Is(err, fs.ErrNotExist) {
+					return err
+				}
+
+				peers, err := resolvePeerDependencies(newPeerDependencyFetcher(), packages, present)
+				if err != nil {
+					return err
+				}
+				if len(peers) > 0 {
+					if err := printNote(cmd, "adding the peer dependencies %s", strings.Join(peers, ", ")); err != nil {
+						return err
+					}
+				}
+				packages = append(packages, peers...)
+			}
+
+			if !separate {
+				cmdArgs, err := buildInstallArgs(packages)
+				if err != nil {
+					return err
+				}
+				if err := runInstall(cmdArgs); err != nil {
+					return err
+				}
+				return recordBundledDependencies()
+			}
 
-				completeVoltaCommand := lo.Flatten([][]string{
-					detect.VOLTA_RUN_COMMAND,
-					{pm},
-					cmdArgs,
-				})
-				cmdRunner.Command(completeVoltaCommand[0], completeVoltaCommand[1:]...)
+			if len(packages) == 0 {
+				return fmt.Errorf("the --%s flag requires at least one package", _SEPARATE_FLAG)
+			}
 
-				goEnv.ExecuteIfModeIsProduction(func() {
-					log.Info("Executing this ", "command", completeVoltaCommand)
-				})
-				de.LogJSCommandIfDebugIsTrue(completeVoltaCommand[0], completeVoltaCommand[1:]...)
-			} else {
+			// Each package gets its own package manager invocation
+			var failures []error
+			for _, pkg := range packages {
+				cmdArgs, err := buildInstallArgs([]string{pkg})
+				if err != nil {
+					return err
+				}
 
-				cmdRunner.Command(pm, cmdArgs...)
+				if err := runInstall(cmdArgs); err != nil {
+					if !continueOnError {
+						return fmt.Errorf("failed to install %s: %w", pkg, err)
+					}
 
-				goEnv.ExecuteIfModeIsProduction(func() {
-					log.Info("Executing this ", "command", append([]string{pm}, cmdArgs...))
-				})
-				de.LogJSCommandIfDebugIsTrue(pm, cmdArgs...)
+					de.LogDebugMessageIfDebugIsTrue("Install failed, continuing", "package", pkg, "error", err)
+					failures = append(failures, fmt.Errorf("%s (%w)", pkg, err))
+				}
+			}
+
+			if len(failures) > 0 {
+				// Joining keeps every failure reachable so the package manager exit code survives
+				return fmt.Errorf(
+					"failed to install %d of %d packages: %w",
+					len(failures), len(packages), errors.Join(failures...),
+				)
 			}
 
-			// Execute the command
-			return cmdRunner.Run()
+			return recordBundledDependencies()
 		},
 	}
 
 	cmd.Flags().BoolP(_DEV_FLAG, "D", false, "Install as dev dependency")
+	cmd.Flags().StringP(_NAME_FLAG, "n", "", "Name the executable of a global deno install (deno install -n)")
+	for _, permission := range denoPermissionFlags {
+		shorthand := lo.Ternary(permission == "allow-all", "A", "")
+		cmd.Flags().StringP(permission, shorthand, "", fmt.Sprintf("Pass --%s to a global deno install, with an optional =list", permission))
+		cmd.Flags().Lookup(permission).NoOptDefVal = denoPermissionGranted
+	}
 	cmd.Flags().BoolP(_GLOBAL_FLAG, "g", false, "Install globally")
+	cmd.Flags().Bool(_LIST_FLAG, false, "List the global packages instead of installing, requires --global")
 	cmd.Flags().BoolP(_PRODUCTION_FLAG, "P", false, "Install production dependencies only")
 	cmd.Flags().Bool(_FROZEN_FLAG, false, "Install with frozen lockfile")
+	cmd.Flags().Bool(_LOCKFILE_ONLY_FLAG, false, "Update the lockfile without installing node_modules")
+	cmd.MarkFlagsMutuallyExclusive(_FROZEN_FLAG, _LOCKFILE_ONLY_FLAG)
+	cmd.Flags().BoolP(_EXACT_FLAG, "E", false, "Save the exact version instead of a range")
+	cmd.Flags().String(_SAVE_PREFIX_FLAG, "", "Save versions with this range prefix: ^ or ~, empty saves exact versions (npm, pnpm and yarn)")
+	cmd.Flags().BoolP(_WORKSPACE_ROOT_FLAG, "W", false, "Add to the workspace root (pnpm -w, yarn v1 -W)")
+	cmd.Flags().Bool(_PREFER_WORKSPACE_FLAG, false, "Link the local workspace version of the packages (pnpm --prefer-workspace-packages, yarn v2+ workspace:*)")
+	cmd.MarkFlagsMutuallyExclusive(_PREFER_WORKSPACE_FLAG, _GLOBAL_FLAG)
 	cmd.Flags().VarP(&searchFlag, _SEARCH_FLAG, "s", "Interactive package search selection")
+	cmd.Flags().String(_SEARCH_BACKEND_FLAG, _SEARCH_BACKEND_HTTP, "Search the registry over http or with npm search (exec), for --search")
 	cmd.Flags().Bool(_NO_VOLTA_FLAG, false, "Disable Volta integration for this command") // New flag for Volta opt-out
+	cmd.Flags().Bool(_SEPARATE_FLAG, false, "Run one package manager invocation per package")
+	cmd.Flags().Bool(_CONTINUE_FLAG, false, "Keep installing the remaining packages when one fails (requires --separate)")
+	cmd.Flags().String(_REGISTRY_FLAG, "", "Install from this registry URL")
+	cmd.Flags().String(_NODE_LINKER_FLAG, "", "Set the node_modules layout: isolated, hoisted or pnp (pnpm and yarn v2+)")
+	cmd.Flags().Bool(_HOIST_FLAG, false, "Hoist dependencies into a flat node_modules (pnpm --shamefully-hoist)")
+	cmd.Flags().Bool(shamefullyHoistFlag, false, "Same as --hoist")
+	_ = cmd.Flags().MarkHidden(shamefullyHoistFlag)
+	cmd.Flags().String(_STORE_DIR_FLAG, "", "Keep the package store or cache in this directory (pnpm --store-dir, npm --cache, bun --cache-dir, yarn --cache-folder)")
+	cmd.Flags().String(cacheDirFlag, "", "Same as --store-dir")
+	_ = cmd.Flags().MarkHidden(cacheDirFlag)
+	cmd.MarkFlagsMutuallyExclusive(_STORE_DIR_FLAG, cacheDirFlag)
+	cmd.Flags().Bool(_WITH_PEERS_FLAG, false, "Also install the peer dependencies the packages declare in the registry")
+	cmd.Flags().String(_ONLY_FLAG, "", "Install only this group of package.json: dependencies or devDependencies")
+	cmd.MarkFlagsMutuallyExclusive(_ONLY_FLAG, _PRODUCTION_FLAG)
+	cmd.MarkFlagsMutuallyExclusive(_ONLY_FLAG, _DEV_FLAG)
+	cmd.MarkFlagsMutuallyExclusive(_ONLY_FLAG, _GLOBAL_FLAG)
+	cmd.Flags().Int(_MAX_SOCKETS_FLAG, 0, "Open at most n connections to the registry at the same time (npm --maxsockets, pnpm, yarn v1 and bun --network-concurrency)")
+	cmd.Flags().StringArray(_BUNDLE_FLAG, nil, "Install the package and add it to bundledDependencies in package.json (repeatable, npm only)")
+	cmd.MarkFlagsMutuallyExclusive(_BUNDLE_FLAG, _GLOBAL_FLAG)
+	for _, flag := range []string{_SEARCH_FLAG, _DEV_FLAG, _PRODUCTION_FLAG, _FROZEN_FLAG, _LOCKFILE_ONLY_FLAG, _NAME_FLAG} {
+		cmd.MarkFlagsMutuallyExclusive(_LIST_FLAG, flag)
+	}
+	addEngineCheckFlag(cmd)
+	addRetriesFlag(cmd)
 
 	return cmd
 }
+
+// withWorkspaceProtocol makes yarn resolve every package to the workspace that has its name.
+// A package that names a version can't, since the workspace decides the version.
+func withWorkspaceProtocol(packages []string) ([]string, error) {
+	names := ParsePackageNames(packages)
+	for i, pkg := range packages {
+		if pkg != names[i] {
+			return nil, fmt.Errorf("the --%s flag installs the workspace version of a package, %s names a version", _PREFER_WORKSPACE_FLAG, pkg)
+		}
+	}
+
+	return lo.Map(names, func(name string, _ int) string {
+		return name + "@workspace:*"
+	}), nil
+}
+
+// installOnlyGroups are the package.json groups --only installs on their own.
+var installOnlyGroups = []string{"dependencies", "devDependencies"}
+
+// readDenoPermissionArgs returns the deno permission flags that were passed, in the order deno documents them.
+func readDenoPermissionArgs(cmd *cobra.Command) []string {
+	return lo.FilterMap(denoPermissionFlags, func(permission string, _ int) (string, bool) {
+		flag := cmd.Flags().Lookup(permission)
+		if !flag.Changed {
+			return "", false
+		}
+		if flag.Value.String() == denoPermissionGranted {
+			return "--" + permission, true
+		}
+		return "--" + permission + "=" + flag.Value.String(), true
+	})
+}
+
+// readDependencyGroupFrom returns the packages of the group ("dependencies" or "devDependencies")
+// in the package.json of baseDir.
+func readDependencyGroupFrom(baseDir, group string) (map[string]string, error) {
+	data, err := os.ReadFile(filepath.Join(baseDir, "package.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read package.json: %w", err)
+	}
+
+	var pkg map[string]json.RawMessage
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return nil, fmt.Errorf("failed to parse package.json: %w", err)
+	}
+
+	var packages map[string]string
+	if raw, ok := pkg[group]; ok {
+		if err := json.Unmarshal(raw, &packages); err != nil {
+			return nil, fmt.Errorf("failed to parse %s in package.json: %w", group, err)
+		}
+	}
+
+	return packages, nil
+}
+
+// readMaxSocketsArgs maps --max-sockets to the network concurrency option of the package manager:
+//
+// | Package Manager | Option                    |
+// |-----------------|---------------------------|
+// | npm             | --maxsockets <n>          |
+// | pnpm            | --network-concurrency <n> |
+// | yarn v1         | --network-concurrency <n> |
+// | bun             | --network-concurrency <n> |
+func readMaxSocketsArgs(cmd *cobra.Command, pm, yarnVersion string) ([]string, error) {
+	if !cmd.Flags().Changed(_MAX_SOCKETS_FLAG) {
+		return nil, nil
+	}
+
+	maxSockets, err := cmd.Flags().GetInt(_MAX_SOCKETS_FLAG)
+	if err != nil {
+		return nil, err
+	}
+	if maxSockets <= 0 {
+		return nil, fmt.Errorf("the --%s flag must be greater than 0, got: %d", _MAX_SOCKETS_FLAG, maxSockets)
+	}
+	value := strconv.Itoa(maxSockets)
+
+	switch pm {
+	case detect.NPM:
+		return []string{"--maxsockets", value}, nil
+
+	case detect.PNPM, detect.BUN:
+		return []string{"--network-concurrency", value}, nil
+
+	case detect.YARN:
+		if ParseYarnMajor(yarnVersion) >= 2 {
+			return nil, fmt.Errorf("yarn v2+ reads networkConcurrency from .yarnrc.yml, it has no --%s option", _MAX_SOCKETS_FLAG)
+		}
+		return []string{"--network-concurrency", value}, nil
+
+	default:
+		return nil, fmt.Errorf("%s does not support the --%s flag", pm, _MAX_SOCKETS_FLAG)
+	}
+}
+
+// resolvePeerDependencies returns the peer dependencies of packages as name@range specs.
+// Peers that are installed with packages, already in package.json or declared by an earlier package are left out.
+func resolvePeerDependencies(fetcher PeerDependencyFetcher, packages, present []string) ([]string, error) {
+	known := lo.SliceToMap(append(ParsePackageNames(packages), present...), func(name string) (string, bool) {
+		return name, true
+	})
+
+	var peers []string
+	for _, pkg := range packages {
+		peerDependencies, err := fetcher.PeerDependencies(pkg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the peer dependencies of %s: %w", pkg, err)
+		}
+
+		names := lo.Keys(peerDependencies)
+		sort.Strings(names)
+		for _, name := range names {
+			if known[name] {
+				continue
+			}
+			known[name] = true
+			peers = append(peers, name+"@"+peerDependencies[name])
+		}
+	}
+
+	return peers, nil
+}
+
+// resolveStoreDir checks the --store-dir path and makes it absolute, relative paths start at targetDir.
+// The directory doesn't have to exist, the package managers create it.
+func resolveStoreDir(targetDir, storeDir string) (string, error) {
+	if strings.TrimSpace(storeDir) == "" {
+		return "", fmt.Errorf("the --%s flag requires a path", _STORE_DIR_FLAG)
+	}
+
+	if !filepath.IsAbs(storeDir) {
+		storeDir = filepath.Join(targetDir, storeDir)
+	}
+
+	info, err := os.Stat(storeDir)
+	if err == nil && !info.IsDir() {
+		return "", fmt.Errorf("the --%s flag must be a directory, got a file: %s", _STORE_DIR_FLAG, storeDir)
+	}
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return "", fmt.Errorf("failed to check the --%s path: %w", _STORE_DIR_FLAG, err)
+	}
+
+	return filepath.Clean(storeDir), nil
+}
@@ -0,0 +1,718 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	tmock "github.com/stretchr/testify/mock"
+
+	"github.com/louiss0/javascript-package-delegator/cmd"
+	"github.com/louiss0/javascript-package-delegator/detect"
+	"github.com/louiss0/javascript-package-delegator/mock"
+	"github.com/louiss0/javascript-package-delegator/services"
+	"github.com/louiss0/javascript-package-delegator/testutil"
+)
+
+var _ = Describe("Install Command batch mode", func() {
+	assert := assert.New(GinkgoT())
+
+	var (
+		mockRunner *mock.MockCommandRunner
+		factory    *testutil.RootCommandFactory
+	)
+
+	BeforeEach(func() {
+		mockRunner = mock.NewMockCommandRunner()
+		// The failing expectation is registered first so testify matches it before the permissive ones
+		mockRunner.On("Run", "npm", []string{"install", "broken-pkg"}, tmock.Anything).
+			Return(fmt.Errorf("exit status 1")).Maybe()
+
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+	})
+
+	It("runs one install per package with --separate", func() {
+		root := factory.CreateNpmAsDefault(nil)
+
+		_, err := executeCmd(root, "install", "--separate", "react", "vue")
+		assert.NoError(err)
+		assert.True(mockRunner.WasCommandCalled("npm", "install", "react"))
+		assert.True(mockRunner.WasCommandCalled("npm", "install", "vue"))
+		assert.False(mockRunner.WasCommandCalled("npm", "install", "react", "vue"))
+	})
+
+	It("stops at the first failure without --continue-on-error", func() {
+		root := factory.CreateNpmAsDefault(nil)
+
+		_, err := executeCmd(root, "install", "--separate", "react", "broken-pkg", "vue")
+		assert.Error(err)
+		assert.Contains(err.Error(), "failed to install broken-pkg")
+		assert.True(mockRunner.WasCommandCalled("npm", "install", "react"))
+		assert.False(mockRunner.WasCommandCalled("npm", "install", "vue"))
+	})
+
+	It("continues past failures and reports a summary with --continue-on-error", func() {
+		root := factory.CreateNpmAsDefault(nil)
+
+		_, err := executeCmd(root, "install", "--separate", "--continue-on-error", "react", "broken-pkg", "vue")
+		assert.Error(err)
+		assert.Contains(err.Error(), "failed to install 1 of 3 packages")
+		assert.Contains(err.Error(), "broken-pkg (exit status 1)")
+		assert.True(mockRunner.WasCommandCalled("npm", "install", "react"))
+		assert.True(mockRunner.WasCommandCalled("npm", "install", "broken-pkg"))
+		assert.True(mockRunner.WasCommandCalled("npm", "install", "vue"))
+	})
+
+	It("requires --separate for --continue-on-error", func() {
+		root := factory.CreateNpmAsDefault(nil)
+
+		_, err := executeCmd(root, "install", "--continue-on-error", "react")
+		assert.Error(err)
+		assert.Contains(err.Error(), "requires --separate")
+		assert.False(mockRunner.HasBeenCalled)
+	})
+})
+
+var _ = Describe("Install Command workspace root", func() {
+	assert := assert.New(GinkgoT())
+
+	var (
+		mockRunner *mock.MockCommandRunner
+		factory    *testutil.RootCommandFactory
+	)
+
+	BeforeEach(func() {
+		mockRunner = mock.NewMockCommandRunner()
+		mockRunner.On("Run", "pnpm", []string{"add", "lodash"}, tmock.Anything).
+			Return(&cmd.CommandExitError{
+				Code:   1,
+				Err:    errors.New("exit status 1"),
+				Stderr: " ERR_PNPM_ADDING_TO_ROOT  Running this command will add the dependency to the workspace root",
+			}).Maybe()
+
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+		testutil.DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.PNPM, detect.PNPM_LOCK_YAML)
+	})
+
+	It("translates -W to pnpm -w", func() {
+		testutil.DebugExecutorExpectationManager.ExpectJSCommandLog("pnpm", "add", "lodash", "-w")
+
+		_, err := executeCmd(factory.CreatePnpmAsDefault(nil), "install", "-W", "lodash")
+		assert.NoError(err)
+		assert.True(mockRunner.HasCommand("pnpm", "add", "lodash", "-w"))
+	})
+
+	DescribeTable("notes that -W is ignored where the workspace root needs no flag",
+		func(newRootCmd func(*testutil.RootCommandFactory, error) *cobra.Command, expected []string, pm string) {
+			output, err := executeCmd(newRootCmd(factory, nil), "install", "-W", "lodash")
+			assert.NoError(err)
+			assert.Contains(output, "Note: "+pm)
+			assert.Contains(output, "adds to the workspace root without a flag, --workspace-root is ignored")
+			assert.True(mockRunner.HasCommand(expected[0], expected[1:]...), "got %v", mockRunner.CommandCall)
+		},
+		Entry("npm", (*testutil.RootCommandFactory).CreateNpmAsDefault, []string{"npm", "install", "lodash"}, "npm"),
+		Entry("bun", (*testutil.RootCommandFactory).CreateBunAsDefault, []string{"bun", "add", "lodash"}, "bun"),
+		Entry("yarn v2+", (*testutil.RootCommandFactory).CreateYarnTwoAsDefault, []string{"yarn", "add", "lodash"}, "yarn "),
+	)
+
+	It("keeps -W for yarn v1 without a note", func() {
+		output, err := executeCmd(factory.CreateYarnOneAsDefault(nil), "install", "-W", "lodash")
+		assert.NoError(err)
+		assert.NotContains(output, "--workspace-root is ignored")
+		assert.True(mockRunner.HasCommand("yarn", "add", "lodash", "-W"))
+	})
+
+	It("adds a -W hint when pnpm refuses to add to the workspace root", func() {
+		testutil.DebugExecutorExpectationManager.ExpectJSCommandLog("pnpm", "add", "lodash")
+
+		_, err := executeCmd(factory.CreatePnpmAsDefault(nil), "install", "lodash")
+		assert.Error(err)
+		assert.Contains(err.Error(), "exit status 1")
+		assert.Contains(err.Error(), "hint: pass -W/--workspace-root")
+	})
+
+	It("keeps the exit code of pnpm when adding the hint", func() {
+		root := factory.CreatePnpmAsDefault(nil)
+		root.SetArgs([]string{"install", "lodash"})
+		root.SetOut(new(strings.Builder))
+		root.SetErr(new(strings.Builder))
+
+		err := root.Execute()
+		assert.Equal(1, cmd.ExitCodeForError(err))
+	})
+})
+
+var _ = Describe("Install Command --with-peers", func() {
+	assert := assert.New(GinkgoT())
+
+	var (
+		mockRunner *mock.MockCommandRunner
+		factory    *testutil.RootCommandFactory
+		fetcher    *mock.PeerDependencyFetcherMock
+		dir        string
+	)
+
+	BeforeEach(func() {
+		mockRunner = mock.NewMockCommandRunner()
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+
+		fetcher = &mock.PeerDependencyFetcherMock{}
+		fetcher.On("PeerDependencies", "react-redux").
+			Return(map[string]string{"react": "^18.0.0", "redux": "^5.0.0"}, nil)
+		fetcher.On("PeerDependencies", "@tanstack/react-query@5.0.0").
+			Return(map[string]string{"react": "^18 || ^19"}, nil)
+		fetcher.On("PeerDependencies", "lodash").
+			Return(nil, nil)
+
+		dir = GinkgoT().TempDir()
+		assert.NoError(os.WriteFile(filepath.Join(dir, "package.json"), []byte(`{"dependencies": {"react": "^18.2.0"}}`), 0o644))
+	})
+
+	It("adds the peer dependencies to the install argv", func() {
+		root := factory.CreateRootCmdWith(testutil.LockfileDetected(detect.PNPM, detect.PNPM_LOCK_YAML), func(deps *cmd.Dependencies) {
+			deps.NewPeerDependencyFetcher = func() cmd.PeerDependencyFetcher {
+				return fetcher
+			}
+		})
+
+		_, err := executeCmd(root, "install", "--cwd", GinkgoT().TempDir()+"/", "--with-peers", "react-redux")
+		assert.NoError(err)
+		assert.True(mockRunner.HasCommand("pnpm", "add", "react-redux", "react@^18.0.0", "redux@^5.0.0"), "got %v", mockRunner.CommandCall)
+	})
+
+	It("leaves out peers that are already in package.json or being installed", func() {
+		fetcher.On("PeerDependencies", "redux").Return(nil, nil)
+		root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.NewPeerDependencyFetcher = func() cmd.PeerDependencyFetcher {
+				return fetcher
+			}
+		})
+
+		output, err := executeCmd(root, "install", "--cwd", dir+"/", "--with-peers", "react-redux", "@tanstack/react-query@5.0.0", "redux")
+		assert.NoError(err)
+		assert.True(mockRunner.HasCommand("npm", "install", "react-redux", "@tanstack/react-query@5.0.0", "redux"), "got %v", mockRunner.CommandCall)
+		assert.NotContains(output, "adding the peer dependencies")
+	})
+
+	It("adds a peer declared by several packages once", func() {
+		root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.NewPeerDependencyFetcher = func() cmd.PeerDependencyFetcher {
+				return fetcher
+			}
+		})
+
+		output, err := executeCmd(root, "install", "--cwd", GinkgoT().TempDir()+"/", "--with-peers", "react-redux", "@tanstack/react-query@5.0.0", "lodash")
+		assert.NoError(err)
+		assert.True(mockRunner.HasCommand(
+			"npm", "install", "react-redux", "@tanstack/react-query@5.0.0", "lodash", "react@^18.0.0", "redux@^5.0.0",
+		), "got %v", mockRunner.CommandCall)
+		assert.Contains(output, "Note: adding the peer dependencies react@^18.0.0, redux@^5.0.0")
+	})
+
+	It("returns the registry error without installing", func() {
+		fetcher.On("PeerDependencies", "missing-pkg").Return(nil, fmt.Errorf("npm registry returned status 404"))
+		root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.NewPeerDependencyFetcher = func() cmd.PeerDependencyFetcher {
+				return fetcher
+			}
+		})
+
+		_, err := executeCmd(root, "install", "--cwd", dir+"/", "--with-peers", "missing-pkg")
+		assert.ErrorContains(err, "failed to read the peer dependencies of missing-pkg: npm registry returned status 404")
+		assert.False(mockRunner.HasBeenCalled)
+	})
+
+	It("requires at least one package", func() {
+		root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.NewPeerDependencyFetcher = func() cmd.PeerDependencyFetcher {
+				return fetcher
+			}
+		})
+
+		_, err := executeCmd(root, "install", "--cwd", dir+"/", "--with-peers")
+		assert.ErrorContains(err, "the --with-peers flag requires at least one package")
+		assert.False(mockRunner.HasBeenCalled)
+	})
+})
+
+var _ = Describe("Install --bundle", func() {
+	assert := assert.New(GinkgoT())
+
+	var (
+		mockRunner *mock.MockCommandRunner
+		factory    *testutil.RootCommandFactory
+		dir        string
+	)
+
+	BeforeEach(func() {
+		mockRunner = mock.NewMockCommandRunner()
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+
+		dir = GinkgoT().TempDir()
+		assert.NoError(os.WriteFile(filepath.Join(dir, "package.json"), []byte(`{"name": "lib", "dependencies": {"react": "^18.2.0"}}`), 0o644))
+	})
+
+	readBundled := func() []string {
+		data, err := os.ReadFile(filepath.Join(dir, "package.json"))
+		assert.NoError(err)
+		var manifest struct {
+			BundledDependencies []string `json:"bundledDependencies"`
+		}
+		assert.NoError(json.Unmarshal(data, &manifest))
+		return manifest.BundledDependencies
+	}
+
+	It("installs the package and adds it to bundledDependencies", func() {
+		_, err := executeCmd(factory.CreateNpmAsDefault(nil), "install", "--cwd", dir+"/", "react", "--bundle", "lodash@4.17.21", "--bundle", "@scope/util")
+		assert.NoError(err)
+		assert.True(mockRunner.HasCommand("npm", "install", "react", "lodash@4.17.21", "@scope/util"), "got %v", mockRunner.CommandCall)
+		assert.Equal([]string{"lodash", "@scope/util"}, readBundled())
+	})
+
+	It("records the bundled packages when each one is installed on its own", func() {
+		_, err := executeCmd(factory.CreateNpmAsDefault(nil), "install", "--cwd", dir+"/", "--separate", "--bundle", "lodash")
+		assert.NoError(err)
+		assert.True(mockRunner.HasCommand("npm", "install", "lodash"), "got %v", mockRunner.CommandCall)
+		assert.Equal([]string{"lodash"}, readBundled())
+	})
+
+	It("leaves package.json untouched when the install fails", func() {
+		mockRunner.InvalidCommands = []string{"npm"}
+
+		_, err := executeCmd(factory.CreateNpmAsDefault(nil), "install", "--cwd", dir+"/", "--bundle", "lodash")
+		assert.Error(err)
+		assert.Empty(readBundled())
+	})
+
+	DescribeTable("returns an error for the package managers without bundledDependencies",
+		func(newRootCmd func(*testutil.RootCommandFactory, error) *cobra.Command, pm string) {
+			_, err := executeCmd(newRootCmd(factory, nil), "install", "--cwd", dir+"/", "--bundle", "lodash")
+			assert.ErrorContains(err, pm+" has no bundledDependencies, the --bundle flag only works with npm")
+			assert.False(mockRunner.HasBeenCalled)
+			assert.Empty(readBundled())
+		},
+		Entry("pnpm", (*testutil.RootCommandFactory).CreatePnpmAsDefault, "pnpm"),
+		Entry("yarn", (*testutil.RootCommandFactory).CreateYarnTwoAsDefault, "yarn"),
+		Entry("bun", (*testutil.RootCommandFactory).CreateBunAsDefault, "bun"),
+		Entry("deno", (*testutil.RootCommandFactory).CreateDenoAsDefault, "deno"),
+	)
+})
+
+var _ = Describe("Install --only", func() {
+	assert := assert.New(GinkgoT())
+
+	var (
+		mockRunner *mock.MockCommandRunner
+		factory    *testutil.RootCommandFactory
+		dir        string
+	)
+
+	writeManifest := func(content string) {
+		assert.NoError(os.WriteFile(filepath.Join(dir, "package.json"), []byte(content), 0o644))
+	}
+
+	BeforeEach(func() {
+		mockRunner = mock.NewMockCommandRunner()
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+
+		dir = GinkgoT().TempDir()
+		writeManifest(`{"dependencies": {"react": "^18.2.0"}, "devDependencies": {"vitest": "^1.0.0"}}`)
+	})
+
+	DescribeTable("installs only the devDependencies",
+		func(newRootCmd func(*testutil.RootCommandFactory, error) *cobra.Command, expected []string) {
+			_, err := executeCmd(newRootCmd(factory, nil), "install", "--cwd", dir+"/", "--only", "devDependencies")
+			assert.NoError(err)
+			assert.True(mockRunner.HasCommand(expected[0], expected[1:]...), "got %v", mockRunner.CommandCall)
+		},
+		Entry("pnpm", (*testutil.RootCommandFactory).CreatePnpmAsDefault, []string{"pnpm", "install", "--dev"}),
+	)
+
+	DescribeTable("installs only the dependencies, leaving out the devDependencies",
+		func(newRootCmd func(*testutil.RootCommandFactory, error) *cobra.Command, expected []string) {
+			_, err := executeCmd(newRootCmd(factory, nil), "install", "--cwd", dir+"/", "--only", "dependencies")
+			assert.NoError(err)
+			assert.True(mockRunner.HasCommand(expected[0], expected[1:]...), "got %v", mockRunner.CommandCall)
+		},
+		Entry("npm", (*testutil.RootCommandFactory).CreateNpmAsDefault, []string{"npm", "install", "--omit=dev"}),
+		Entry("pnpm", (*testutil.RootCommandFactory).CreatePnpmAsDefault, []string{"pnpm", "install", "--prod"}),
+		Entry("yarn", (*testutil.RootCommandFactory).CreateYarnOneAsDefault, []string{"yarn", "install", "--production"}),
+		Entry("bun", (*testutil.RootCommandFactory).CreateBunAsDefault, []string{"bun", "install", "--production"}),
+	)
+
+	DescribeTable("returns an error for the package managers that can't install only the devDependencies",
+		func(newRootCmd func(*testutil.RootCommandFactory, error) *cobra.Command, pm string) {
+			_, err := executeCmd(newRootCmd(factory, nil), "install", "--cwd", dir+"/", "--only", "devDependencies")
+			assert.ErrorContains(err, pm+" can't install only the devDependencies")
+			assert.False(mockRunner.HasBeenCalled)
+		},
+		Entry("npm", (*testutil.RootCommandFactory).CreateNpmAsDefault, "npm"),
+		Entry("yarn", (*testutil.RootCommandFactory).CreateYarnOneAsDefault, "yarn"),
+		Entry("bun", (*testutil.RootCommandFactory).CreateBunAsDefault, "bun"),
+	)
+
+	It("skips the install when package.json has no packages in the group", func() {
+		writeManifest(`{"dependencies": {"react": "^18.2.0"}}`)
+
+		output, err := executeCmd(factory.CreateNpmAsDefault(nil), "install", "--cwd", dir+"/", "--only", "devDependencies")
+		assert.NoError(err)
+		assert.Contains(output, "Note: package.json has no devDependencies, there is nothing to install")
+		assert.False(mockRunner.HasBeenCalled)
+	})
+
+	It("returns an error for an unknown group", func() {
+		_, err := executeCmd(factory.CreateNpmAsDefault(nil), "install", "--cwd", dir+"/", "--only", "peerDependencies")
+		assert.ErrorContains(err, "the --only flag must be one of [dependencies devDependencies], got: peerDependencies")
+		assert.False(mockRunner.HasBeenCalled)
+	})
+
+	It("returns an error when packages are given", func() {
+		_, err := executeCmd(factory.CreateNpmAsDefault(nil), "install", "--cwd", dir+"/", "--only", "dependencies", "lodash")
+		assert.ErrorContains(err, "the --only flag installs a group of package.json, it takes no packages")
+		assert.False(mockRunner.HasBeenCalled)
+	})
+
+	It("returns an error when package.json is missing", func() {
+		assert.NoError(os.Remove(filepath.Join(dir, "package.json")))
+
+		_, err := executeCmd(factory.CreateNpmAsDefault(nil), "install", "--cwd", dir+"/", "--only", "dependencies")
+		assert.ErrorContains(err, "failed to read package.json")
+		assert.False(mockRunner.HasBeenCalled)
+	})
+})
+
+var _ = Describe("Install duplicate packages", func() {
+	assert := assert.New(GinkgoT())
+
+	var (
+		mockRunner *mock.MockCommandRunner
+		factory    *testutil.RootCommandFactory
+		dir        string
+	)
+
+	BeforeEach(func() {
+		mockRunner = mock.NewMockCommandRunner()
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+
+		dir = GinkgoT().TempDir()
+		assert.NoError(os.WriteFile(filepath.Join(dir, "package.json"), []byte(`{"name": "app"}`), 0o644))
+	})
+
+	It("installs a package passed twice once, at the version that was asked for", func() {
+		_, err := executeCmd(factory.CreatePnpmAsDefault(nil), "install", "--cwd", dir+"/", "react", "lodash", "react@18")
+		assert.NoError(err)
+		assert.True(mockRunner.HasCommand("pnpm", "add", "react@18", "lodash"), "got %v", mockRunner.CommandCall)
+	})
+
+	It("runs one install per package with --separate", func() {
+		_, err := executeCmd(factory.CreateNpmAsDefault(nil), "install", "--cwd", dir+"/", "--separate", "lodash", "lodash")
+		assert.NoError(err)
+		assert.Equal([]mock.CommandCall{{Name: "npm", Args: []string{"install", "lodash"}}}, mockRunner.CommandHistory())
+	})
+
+	Context("DedupePackageSpecs", func() {
+		It("collapses duplicate packages in the place of the first one", func() {
+			assert.Equal([]string{"react", "vite"}, cmd.DedupePackageSpecs([]string{"react", "vite", "react", "vite"}))
+		})
+
+		It("prefers a versioned spec over a bare name", func() {
+			assert.Equal([]string{"react@18"}, cmd.DedupePackageSpecs([]string{"react", "react@18"}))
+			assert.Equal([]string{"react@18"}, cmd.DedupePackageSpecs([]string{"react@18", "react"}))
+		})
+
+		It("keeps the last version", func() {
+			assert.Equal([]string{"@types/node@20", "react"}, cmd.DedupePackageSpecs([]string{"@types/node@18", "react", "@types/node@20"}))
+		})
+
+		It("keeps specs that aren't plain registry versions", func() {
+			specs := []string{"git@github.com:acme/a.git", "git@github.com:acme/b.git", "jsr:@std/path", "jsr:@std/fs"}
+			assert.Equal(specs, cmd.DedupePackageSpecs(specs))
+		})
+	})
+})
+
+// recordingMultiSelect is a multi-select UI that picks every option it is given.
+type recordingMultiSelect struct {
+	options []string
+}
+
+func (r *recordingMultiSelect) Values() []string { return r.options }
+
+func (r *recordingMultiSelect) Run() error { return nil }
+
+var _ = Describe("Install --search-backend", func() {
+	assert := assert.New(GinkgoT())
+
+	const searchOutput = `[
+		{"name": "left-pad", "version": "1.3.0", "description": "String left pad", "links": {"npm": "https://www.npmjs.com/package/left-pad"}},
+		{"name": "pad-left", "version": "2.1.0", "description": "Left pad a string", "links": {"homepage": "https://github.com/jonschlinkert/pad-left"}}
+	]`
+
+	var (
+		mockRunner *mock.MockCommandRunner
+		factory    *testutil.RootCommandFactory
+		dir        string
+		searched   [][]string
+		options    []services.PackageInfo
+	)
+
+	npmSearch := func(targetDir string, args ...string) ([]byte, error) {
+		assert.Equal(dir+"/", targetDir)
+		searched = append(searched, args)
+		return []byte(searchOutput), nil
+	}
+
+	newSelectUI := func(packageInfo []services.PackageInfo) cmd.MultiUISelecter {
+		options = packageInfo
+		return &recordingMultiSelect{options: lo.Map(packageInfo, func(info services.PackageInfo, _ int) string {
+			return info.Name
+		})}
+	}
+
+	BeforeEach(func() {
+		mockRunner = mock.NewMockCommandRunner()
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+
+		dir = GinkgoT().TempDir()
+		searched = nil
+		options = nil
+	})
+
+	It("feeds the results of npm search to the multi-select and installs the picked packages", func() {
+		_, err := executeCmd(factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.NpmSearchOutputter = npmSearch
+			deps.NewPackageMultiSelectUI = newSelectUI
+		}), "install", "--cwd", dir+"/", "--search", "left-pad", "--search-backend", "exec")
+		assert.NoError(err)
+		assert.Equal([][]string{{"search", "--json", "--searchlimit=35", "left-pad"}}, searched)
+		assert.Equal([]services.PackageInfo{
+			{Name: "left-pad", Version: "1.3.0", Description: "String left pad", Homepage: "https://www.npmjs.com/package/left-pad"},
+			{Name: "pad-left", Version: "2.1.0", Description: "Left pad a string", Homepage: "https://github.com/jonschlinkert/pad-left"},
+		}, options)
+		assert.True(mockRunner.HasCommand("npm", "install", "left-pad", "pad-left"), "got %v", mockRunner.CommandCall)
+	})
+
+	It("returns an error when npm search finds nothing", func() {
+		empty := func(string, ...string) ([]byte, error) { return []byte("[]"), nil }
+
+		_, err := executeCmd(factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.NpmSearchOutputter = empty
+			deps.NewPackageMultiSelectUI = newSelectUI
+		}), "install", "--cwd", dir+"/", "--search", "nothing-like-it", "--search-backend", "exec")
+		assert.ErrorContains(err, `search failed for "nothing-like-it"`)
+		assert.False(mockRunner.HasBeenCalled)
+	})
+
+	It("returns an error when npm search fails", func() {
+		failing := func(string, ...string) ([]byte, error) { return nil, errors.New("npm ERR! network") }
+
+		_, err := executeCmd(factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.NpmSearchOutputter = failing
+			deps.NewPackageMultiSelectUI = newSelectUI
+		}), "install", "--cwd", dir+"/", "--search", "left-pad", "--search-backend", "exec")
+		assert.ErrorContains(err, "failed to run npm search: npm ERR! network")
+		assert.Nil(options)
+	})
+
+	It("returns an error for an unknown backend", func() {
+		_, err := executeCmd(factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.NpmSearchOutputter = npmSearch
+			deps.NewPackageMultiSelectUI = newSelectUI
+		}), "install", "--cwd", dir+"/", "--search", "left-pad", "--search-backend", "grpc")
+		assert.ErrorContains(err, "the --search-backend flag must be one of [http exec], got: grpc")
+		assert.Empty(searched)
+	})
+
+	It("returns an error without --search", func() {
+		_, err := executeCmd(factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.NpmSearchOutputter = npmSearch
+			deps.NewPackageMultiSelectUI = newSelectUI
+		}), "install", "--cwd", dir+"/", "left-pad", "--search-backend", "exec")
+		assert.ErrorContains(err, "the --search-backend flag requires --search")
+		assert.False(mockRunner.HasBeenCalled)
+	})
+})
+
+var _ = Describe("Install --name", func() {
+	assert := assert.New(GinkgoT())
+
+	var (
+		mockRunner *mock.MockCommandRunner
+		factory    *testutil.RootCommandFactory
+	)
+
+	BeforeEach(func() {
+		mockRunner = mock.NewMockCommandRunner()
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+	})
+
+	DescribeTable("returns an error for the package managers that can't name a global install",
+		func(newRootCmd func(*testutil.RootCommandFactory, error) *cobra.Command, pm string) {
+			_, err := executeCmd(newRootCmd(factory, nil), "install", "--global", "--name", "mytool", "cowsay")
+			assert.ErrorContains(err, pm+" can't name a global install, the --name flag only works with deno")
+			assert.False(mockRunner.HasBeenCalled)
+		},
+		Entry("npm", (*testutil.RootCommandFactory).CreateNpmAsDefault, "npm"),
+		Entry("pnpm", (*testutil.RootCommandFactory).CreatePnpmAsDefault, "pnpm"),
+		Entry("yarn", (*testutil.RootCommandFactory).CreateYarnTwoAsDefault, "yarn"),
+		Entry("bun", (*testutil.RootCommandFactory).CreateBunAsDefault, "bun"),
+	)
+})
+
+var _ = Describe("Install --global --list", func() {
+	assert := assert.New(GinkgoT())
+
+	var (
+		mockRunner *mock.MockCommandRunner
+		factory    *testutil.RootCommandFactory
+	)
+
+	BeforeEach(func() {
+		mockRunner = mock.NewMockCommandRunner()
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+	})
+
+	DescribeTable("lists the global packages of each package manager",
+		func(newRootCmd func(*testutil.RootCommandFactory, error) *cobra.Command, pm string, args []string) {
+			_, err := executeCmd(newRootCmd(factory, nil), "install", "--global", "--list")
+			assert.NoError(err)
+			assert.Equal([]mock.CommandCall{{Name: pm, Args: args}}, mockRunner.CommandHistory())
+		},
+		Entry("npm", (*testutil.RootCommandFactory).CreateNpmAsDefault, "npm", []string{"ls", "-g", "--depth=0"}),
+		Entry("pnpm", (*testutil.RootCommandFactory).CreatePnpmAsDefault, "pnpm", []string{"ls", "-g"}),
+		Entry("yarn v1", (*testutil.RootCommandFactory).CreateYarnOneAsDefault, "yarn", []string{"global", "list"}),
+		Entry("bun", (*testutil.RootCommandFactory).CreateBunAsDefault, "bun", []string{"pm", "ls", "-g"}),
+	)
+
+	It("returns an error for yarn v2+, which has no global packages", func() {
+		_, err := executeCmd(factory.CreateYarnTwoAsDefault(nil), "install", "--global", "--list")
+		assert.ErrorContains(err, "has no global packages to list, yarn global was removed in v2")
+		assert.False(mockRunner.HasBeenCalled)
+	})
+
+	It("lists the executables deno installed globally", func() {
+		installRoot := GinkgoT().TempDir()
+		GinkgoT().Setenv("DENO_INSTALL_ROOT", installRoot)
+		assert.NoError(os.Mkdir(filepath.Join(installRoot, "bin"), 0o755))
+		for _, name := range []string{"deno", "fresh", "mytool"} {
+			assert.NoError(os.WriteFile(filepath.Join(installRoot, "bin", name), nil, 0o755))
+		}
+
+		output, err := executeCmd(factory.CreateDenoAsDefault(nil), "install", "--global", "--list")
+		assert.NoError(err)
+		assert.Equal("fresh\nmytool\n", output)
+		assert.False(mockRunner.HasBeenCalled)
+	})
+
+	It("requires --global", func() {
+		_, err := executeCmd(factory.CreateNpmAsDefault(nil), "install", "--list")
+		assert.ErrorContains(err, "the --list flag lists the global packages, it requires --global")
+		assert.False(mockRunner.HasBeenCalled)
+	})
+
+	It("takes no packages", func() {
+		_, err := executeCmd(factory.CreateNpmAsDefault(nil), "install", "--global", "--list", "typescript")
+		assert.ErrorContains(err, "the --list flag takes no packages")
+		assert.False(mockRunner.HasBeenCalled)
+	})
+})
+
+var _ = Describe("Install --prefer-workspace", func() {
+	assert := assert.New(GinkgoT())
+
+	var (
+		mockRunner *mock.MockCommandRunner
+		factory    *testutil.RootCommandFactory
+	)
+
+	BeforeEach(func() {
+		mockRunner = mock.NewMockCommandRunner()
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+	})
+
+	It("passes --prefer-workspace-packages to pnpm", func() {
+		_, err := executeCmd(factory.CreatePnpmAsDefault(nil), "install", "--prefer-workspace", "@acme/ui")
+		assert.NoError(err)
+		assert.Equal([]mock.CommandCall{
+			{Name: "pnpm", Args: []string{"add", "@acme/ui", "--prefer-workspace-packages"}},
+		}, mockRunner.CommandHistory())
+	})
+
+	It("adds the packages with the workspace: protocol on yarn v2+", func() {
+		_, err := executeCmd(factory.CreateYarnTwoAsDefault(nil), "install", "--prefer-workspace", "@acme/ui", "utils")
+		assert.NoError(err)
+		assert.Equal([]mock.CommandCall{
+			{Name: "yarn", Args: []string{"add", "@acme/ui@workspace:*", "utils@workspace:*"}},
+		}, mockRunner.CommandHistory())
+	})
+
+	It("returns an error for a yarn package that names a version", func() {
+		_, err := executeCmd(factory.CreateYarnTwoAsDefault(nil), "install", "--prefer-workspace", "@acme/ui@1.2.0")
+		assert.ErrorContains(err, "the --prefer-workspace flag installs the workspace version of a package, @acme/ui@1.2.0 names a version")
+		assert.False(mockRunner.HasBeenCalled)
+	})
+
+	It("returns an error for yarn v1", func() {
+		_, err := executeCmd(factory.CreateYarnOneAsDefault(nil), "install", "--prefer-workspace", "@acme/ui")
+		assert.ErrorContains(err, "the --prefer-workspace flag requires yarn v2 or newer")
+		assert.False(mockRunner.HasBeenCalled)
+	})
+
+	DescribeTable("returns an error for the package managers without workspace linking",
+		func(newRootCmd func(*testutil.RootCommandFactory, error) *cobra.Command, pm string) {
+			_, err := executeCmd(newRootCmd(factory, nil), "install", "--prefer-workspace", "@acme/ui")
+			assert.ErrorContains(err, pm+" does not support the --prefer-workspace flag")
+			assert.False(mockRunner.HasBeenCalled)
+		},
+		Entry("npm", (*testutil.RootCommandFactory).CreateNpmAsDefault, "npm"),
+		Entry("bun", (*testutil.RootCommandFactory).CreateBunAsDefault, "bun"),
+		Entry("deno", (*testutil.RootCommandFactory).CreateDenoAsDefault, "deno"),
+	)
+})
@@ -0,0 +1,79 @@
+// Package cmd provides command-line interface implementations for the JavaScript package delegator.
+package cmd
+
+import (
+	// standard library
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	// external
+	"gopkg.in/yaml.v3"
+)
+
+// JPD_CONFIG_ENV_VAR points jpd at another config file than the one in the user config directory.
+const JPD_CONFIG_ENV_VAR = "JPD_CONFIG"
+
+// JPDConfig is the config file of jpd itself, not of the package managers it delegates to.
+type JPDConfig struct {
+	Aliases map[string]string `yaml:"aliases,omitempty"`
+}
+
+// JPDConfigPath returns the path of the jpd config file, $JPD_CONFIG or jpd/config.yaml in the user config directory.
+func JPDConfigPath() (string, error) {
+	if path := os.Getenv(JPD_CONFIG_ENV_VAR); path != "" {
+		return path, nil
+	}
+
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find the user config directory: %w", err)
+	}
+	return filepath.Join(configDir, "jpd", "config.yaml"), nil
+}
+
+// LoadJPDConfig reads the jpd config file, a missing file is an empty config.
+func LoadJPDConfig() (JPDConfig, error) {
+	var config JPDConfig
+
+	path, err := JPDConfigPath()
+	if err != nil {
+		return config, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return config, nil
+	}
+	if err != nil {
+		return config, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return config, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// SaveJPDConfig writes config to the jpd config file, creating its directory when needed.
+func SaveJPDConfig(config JPDConfig) error {
+	path, err := JPDConfigPath()
+	if err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("failed to encode the jpd config: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+// Package cmd provides command-line interface implementations for the JavaScript package delegator.
+package cmd
+
+import (
+	// standard library
+	"encoding/json"
+	"fmt"
+	"os"
+
+	// external
+	"github.com/spf13/cobra"
+
+	// internal
+	"github.com/louiss0/javascript-package-delegator/internal/deps"
+)
+
+// NewListWorkspacesCmd creates a new Cobra command that prints the workspace packages of a monorepo.
+// It only reads the manifests, no package manager command is executed.
+func NewListWorkspacesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list-workspaces",
+		Short: "Print the workspace packages of the monorepo",
+		Long: `Print the name and path of every workspace package of the monorepo.
+The workspace globs are read from the first of these that declares them:
+
+  pnpm-workspace.yaml  packages
+  package.json         workspaces (array or {"packages": [...]})
+  deno.json            workspace
+
+Globs starting with ! leave out the directories they match.
+
+Examples:
+  jpd list-workspaces         # Print "name: path" for every workspace package
+  jpd list-workspaces --json  # Print the workspace packages as JSON`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			asJSON, err := cmd.Flags().GetBool(_JSON_FLAG)
+			if err != nil {
+				return err
+			}
+
+			targetDir, err := cmd.Flags().GetString(_CWD_FLAG)
+			if err != nil {
+				return fmt.Errorf("failed to parse --%s flag: %w", _CWD_FLAG, err)
+			}
+			if targetDir == "" {
+				if targetDir, err = os.Getwd(); err != nil {
+					return fmt.Errorf("failed to get current working directory: %w", err)
+				}
+			}
+
+			workspaces, err := deps.DiscoverWorkspaces(targetDir)
+			if err != nil {
+				return err
+			}
+
+			if asJSON {
+				encoder := json.NewEncoder(cmd.OutOrStdout())
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(workspaces)
+			}
+
+			for _, workspace := range workspaces {
+				line := workspace.Path
+				if workspace.Name != "" {
+					line = fmt.Sprintf("%s: %s", workspace.Name, workspace.Path)
+				}
+				if _, err := fmt.Fprintln(cmd.OutOrStdout(), line); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().Bool(_JSON_FLAG, false, "Print the workspace packages as JSON")
+
+	return cmd
+}
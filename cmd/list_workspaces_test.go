@@ -0,0 +1,101 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/louiss0/javascript-package-delegator/internal/deps"
+	"github.com/louiss0/javascript-package-delegator/mock"
+	"github.com/louiss0/javascript-package-delegator/testutil"
+)
+
+var _ = Describe("List Workspaces Command", func() {
+	assert := assert.New(GinkgoT())
+
+	var (
+		mockRunner *mock.MockCommandRunner
+		factory    *testutil.RootCommandFactory
+		root       string
+	)
+
+	writeFile := func(name, content string) {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		assert.NoError(os.MkdirAll(filepath.Dir(path), 0o755))
+		assert.NoError(os.WriteFile(path, []byte(content), 0o644))
+	}
+
+	listWorkspaces := func(args ...string) (string, error) {
+		return executeCmd(factory.CreateNpmAsDefault(nil), append([]string{"--cwd", root + "/", "list-workspaces"}, args...)...)
+	}
+
+	BeforeEach(func() {
+		mockRunner = mock.NewMockCommandRunner()
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+
+		root = GinkgoT().TempDir()
+		writeFile("packages/ui/package.json", `{"name": "@acme/ui"}`)
+		writeFile("packages/utils/package.json", `{"name": "@acme/utils"}`)
+		writeFile("apps/web/package.json", `{"name": "web"}`)
+		writeFile("apps/docs/README.md", "no manifest here")
+	})
+
+	It("reads the workspaces array of package.json", func() {
+		writeFile("package.json", `{"name": "acme", "workspaces": ["packages/*", "apps/*"]}`)
+
+		output, err := listWorkspaces()
+		assert.NoError(err)
+		assert.Equal("web: apps/web\n@acme/ui: packages/ui\n@acme/utils: packages/utils\n", output)
+		assert.False(mockRunner.HasBeenCalled)
+	})
+
+	It("reads the object form of the package.json workspaces", func() {
+		writeFile("package.json", `{"workspaces": {"packages": ["packages/*"], "nohoist": ["**/react-native"]}}`)
+
+		output, err := listWorkspaces()
+		assert.NoError(err)
+		assert.Equal("@acme/ui: packages/ui\n@acme/utils: packages/utils\n", output)
+	})
+
+	It("prefers pnpm-workspace.yaml and honors its exclusions", func() {
+		writeFile("package.json", `{"workspaces": ["apps/*"]}`)
+		writeFile("pnpm-workspace.yaml", "packages:\n  - 'packages/**'\n  - '!packages/utils'\n")
+
+		output, err := listWorkspaces("--json")
+		assert.NoError(err)
+
+		var workspaces []deps.Workspace
+		assert.NoError(json.Unmarshal([]byte(output), &workspaces))
+		assert.Equal([]deps.Workspace{{Name: "@acme/ui", Path: "packages/ui"}}, workspaces)
+	})
+
+	It("reads the workspace of deno.json", func() {
+		writeFile("deno.json", `{"workspace": ["./add", "./subtract"]}`)
+		writeFile("add/deno.json", `{"name": "@math/add", "exports": "./mod.ts"}`)
+		writeFile("subtract/deno.jsonc", "{\n  // subtraction\n  \"name\": \"@math/subtract\"\n}")
+
+		output, err := listWorkspaces("--json")
+		assert.NoError(err)
+
+		var workspaces []deps.Workspace
+		assert.NoError(json.Unmarshal([]byte(output), &workspaces))
+		assert.Equal([]deps.Workspace{
+			{Name: "@math/add", Path: "add"},
+			{Name: "@math/subtract", Path: "subtract"},
+		}, workspaces)
+	})
+
+	It("errors when no manifest declares workspaces", func() {
+		writeFile("package.json", `{"name": "single"}`)
+
+		_, err := listWorkspaces()
+		assert.ErrorContains(err, "no workspaces are declared")
+	})
+})
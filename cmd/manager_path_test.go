@@ -0,0 +1,79 @@
+package cmd_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/louiss0/javascript-package-delegator/mock"
+	"github.com/louiss0/javascript-package-delegator/testutil"
+)
+
+var _ = Describe("Manager path override", func() {
+	assert := assert.New(GinkgoT())
+
+	var (
+		mockRunner *mock.MockCommandRunner
+		factory    *testutil.RootCommandFactory
+		binDir     string
+	)
+
+	writeManager := func(name string, perm os.FileMode) string {
+		path := filepath.Join(binDir, name)
+		assert.NoError(os.WriteFile(path, []byte("#!/bin/sh\n"), perm))
+		return path
+	}
+
+	BeforeEach(func() {
+		mockRunner = mock.NewMockCommandRunner()
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+		binDir = GinkgoT().TempDir()
+	})
+
+	It("invokes the overridden binary with the pnpm specific argv", func() {
+		managerPath := writeManager("pnpm", 0o755)
+		root := factory.CreatePnpmAsDefault(nil)
+
+		_, err := executeCmd(root, "--manager-path", managerPath, "install", "-D", "vitest")
+		assert.NoError(err)
+		assert.True(mockRunner.HasCommand(managerPath, "add", "vitest", "--save-dev"))
+	})
+
+	It("keeps the yarn specific argv for the detected manager", func() {
+		managerPath := writeManager("yarn-custom", 0o755)
+		root := factory.CreateYarnTwoAsDefault(nil)
+
+		_, err := executeCmd(root, "--manager-path", managerPath, "update", "lodash")
+		assert.NoError(err)
+		assert.True(mockRunner.HasCommand(managerPath, "up", "lodash"))
+	})
+
+	It("rejects a path that does not exist", func() {
+		root := factory.CreatePnpmAsDefault(nil)
+
+		_, err := executeCmd(root, "--manager-path", filepath.Join(binDir, "missing"), "install")
+		assert.Error(err)
+		assert.Contains(err.Error(), "invalid --manager-path")
+		assert.False(mockRunner.HasBeenCalled)
+	})
+
+	It("rejects a file that is not executable", func() {
+		if runtime.GOOS == "windows" {
+			Skip("Windows has no executable bit")
+		}
+		managerPath := writeManager("pnpm", 0o644)
+		root := factory.CreatePnpmAsDefault(nil)
+
+		_, err := executeCmd(root, "--manager-path", managerPath, "install")
+		assert.Error(err)
+		assert.Contains(err.Error(), "is not executable")
+		assert.False(mockRunner.HasBeenCalled)
+	})
+})
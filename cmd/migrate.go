@@ -0,0 +1,183 @@
+// Package cmd provides command-line interface implementations for the JavaScript package delegator.
+package cmd
+
+import (
+	// standard library
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	// external
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/log"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+
+	// internal
+	"github.com/louiss0/javascript-package-delegator/detect"
+)
+
+const (
+	_YES_FLAG    = "yes"
+	_IMPORT_FLAG = "import"
+)
+
+// migrationLockfiles holds the lock file each package manager that can be migrated writes.
+var migrationLockfiles = map[string]string{
+	detect.NPM:  detect.PACKAGE_LOCK_JSON,
+	detect.PNPM: detect.PNPM_LOCK_YAML,
+	detect.YARN: detect.YARN_LOCK,
+}
+
+// supportedMigrations lists the from/to pairs jpd migrate accepts.
+var supportedMigrations = [][2]string{
+	{detect.NPM, detect.PNPM},
+	{detect.PNPM, detect.NPM},
+	{detect.NPM, detect.YARN},
+	{detect.YARN, detect.NPM},
+}
+
+// UIConfirmer asks a yes or no question.
+type UIConfirmer interface {
+	Value() bool
+	Run() error
+}
+
+type confirmUI struct {
+	confirmed bool
+	confirmUI huh.Confirm
+}
+
+func newConfirmUI(title string) UIConfirmer {
+	return &confirmUI{
+		confirmUI: *huh.NewConfirm().
+			Title(title).
+			Affirmative("Yes").
+			Negative("No"),
+	}
+}
+
+func (c confirmUI) Value() bool {
+	return c.confirmed
+}
+
+func (c *confirmUI) Run() error {
+	return c.confirmUI.Value(&c.confirmed).Run()
+}
+
+// NewMigrateCmd creates a new Cobra command that switches a project from one package manager to another.
+func NewMigrateCmd(pathLookup detect.PathLookup, newConfirmUI func(title string) UIConfirmer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate <from> <to>",
+		Short: "Switch a project to another package manager",
+		Long: `Switch a project from one package manager to another.
+The lock file of <from> is deleted and <to> installs the dependencies again, writing its own lock file.
+With --import pnpm builds its lock file from the old one first, so the resolved versions are kept.
+
+Supported migrations: npm <-> pnpm, npm <-> yarn
+
+Examples:
+  jpd migrate npm pnpm           # Replace package-lock.json with pnpm-lock.yaml
+  jpd migrate npm pnpm --import  # Keep the versions resolved by npm
+  jpd migrate yarn npm --yes     # Don't ask before deleting yarn.lock`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			from, to := args[0], args[1]
+			cmdRunner := getCommandRunnerFromCommandContext(cmd)
+			goEnv := getGoEnvFromCommandContext(cmd)
+			de := getDebugExecutorFromCommandContext(cmd)
+
+			if !lo.Contains(supportedMigrations, [2]string{from, to}) {
+				return fmt.Errorf(
+					"migrating from %s to %s is not supported, supported migrations are %s",
+					from, to, strings.Join(lo.Map(supportedMigrations, func(pair [2]string, _ int) string {
+						return pair[0] + " -> " + pair[1]
+					}), ", "),
+				)
+			}
+
+			yes, err := cmd.Flags().GetBool(_YES_FLAG)
+			if err != nil {
+				return err
+			}
+
+			importLockfile, err := cmd.Flags().GetBool(_IMPORT_FLAG)
+			if err != nil {
+				return err
+			}
+			if importLockfile && to != detect.PNPM {
+				return fmt.Errorf("the --%s flag only works when migrating to pnpm", _IMPORT_FLAG)
+			}
+
+			if _, err := pathLookup.LookPath(to); err != nil {
+				return fmt.Errorf("%s is not installed, install it before migrating to it: %w", to, err)
+			}
+
+			targetDir, err := cmd.Flags().GetString(_CWD_FLAG)
+			if err != nil {
+				return fmt.Errorf("failed to parse --%s flag: %w", _CWD_FLAG, err)
+			}
+			if targetDir == "" {
+				if targetDir, err = os.Getwd(); err != nil {
+					return fmt.Errorf("failed to get current working directory: %w", err)
+				}
+			}
+
+			lockfile := migrationLockfiles[from]
+			lockfilePath := filepath.Join(targetDir, lockfile)
+			if _, err := os.Stat(lockfilePath); err != nil {
+				return fmt.Errorf("no %s found in %s to migrate from: %w", lockfile, targetDir, err)
+			}
+
+			if !yes {
+				if err := errIfQuiet(cmd, "confirming the migration"); err != nil {
+					return fmt.Errorf("%w, pass --%s to migrate without asking", err, _YES_FLAG)
+				}
+				confirm := newConfirmUI(fmt.Sprintf("Delete %s and install the dependencies with %s?", lockfile, to))
+				if err := confirm.Run(); err != nil {
+					return err
+				}
+
+				if !confirm.Value() {
+					_, err := fmt.Fprintln(cmd.OutOrStdout(), "Migration cancelled, nothing was changed")
+					return err
+				}
+			}
+
+			run := func(args ...string) error {
+				de.LogJSCommandIfDebugIsTrue(to, args...)
+				cmdRunner.Command(to, args...)
+
+				goEnv.ExecuteIfModeIsProduction(func() {
+					log.Info("Running command", "pm", to, "args", strings.Join(args, " "))
+				})
+				return cmdRunner.Run()
+			}
+
+			// pnpm import reads the old lock file, so it runs before the file is deleted
+			if importLockfile {
+				if err := run("import"); err != nil {
+					return err
+				}
+			}
+
+			if err := os.Remove(lockfilePath); err != nil {
+				return fmt.Errorf("failed to delete %s: %w", lockfile, err)
+			}
+			de.LogDebugMessageIfDebugIsTrue("Deleted lock file", "path", lockfilePath)
+
+			if err := run("install"); err != nil {
+				return err
+			}
+
+			_, err = fmt.Fprintf(cmd.OutOrStdout(), "Migrated from %s to %s\n", from, to)
+			return err
+		},
+	}
+
+	cmd.Flags().BoolP(_YES_FLAG, "y", false, "Delete the old lock file without asking")
+	cmd.Flags().Bool(_IMPORT_FLAG, false, "Build pnpm-lock.yaml from the old lock file with 'pnpm import'")
+
+	return cmd
+}
@@ -0,0 +1,147 @@
+package cmd_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/louiss0/javascript-package-delegator/cmd"
+	"github.com/louiss0/javascript-package-delegator/detect"
+	"github.com/louiss0/javascript-package-delegator/mock"
+	"github.com/louiss0/javascript-package-delegator/testutil"
+)
+
+// fakeConfirmUI answers every question with answer.
+type fakeConfirmUI struct {
+	answer bool
+}
+
+func (f fakeConfirmUI) Value() bool { return f.answer }
+
+func (f fakeConfirmUI) Run() error { return nil }
+
+var _ = Describe("Migrate Command", func() {
+	assert := assert.New(GinkgoT())
+
+	var (
+		mockRunner *mock.MockCommandRunner
+		factory    *testutil.RootCommandFactory
+		pathLookup *mock.MockPathLookup
+		dir        string
+		lockfile   string
+		asked      []string
+	)
+
+	answering := func(answer bool) func(string) cmd.UIConfirmer {
+		return func(title string) cmd.UIConfirmer {
+			asked = append(asked, title)
+			return fakeConfirmUI{answer: answer}
+		}
+	}
+
+	setPath := func(pm, path string) {
+		var err error
+		if path == "" {
+			err = fmt.Errorf("executable file not found in $PATH")
+		}
+		pathLookup.ExpectedLookPathResults[pm] = struct {
+			Path  string
+			Error error
+		}{path, err}
+	}
+
+	BeforeEach(func() {
+		mockRunner = mock.NewMockCommandRunner()
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+
+		pathLookup = mock.NewMockPathLookup()
+		setPath(detect.NPM, "/usr/bin/npm")
+		setPath(detect.PNPM, "/usr/local/bin/pnpm")
+		setPath(detect.YARN, "")
+
+		asked = nil
+		dir = GinkgoT().TempDir()
+		lockfile = filepath.Join(dir, detect.PACKAGE_LOCK_JSON)
+		assert.NoError(os.WriteFile(lockfile, []byte(`{"lockfileVersion": 3}`), 0o644))
+	})
+
+	It("deletes the old lock file and installs with the target package manager", func() {
+		root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.PathLookup = pathLookup
+			deps.NewConfirmUI = answering(true)
+		})
+
+		output, err := executeCmd(root, "migrate", "npm", "pnpm", "--cwd", dir+"/")
+		assert.NoError(err)
+		assert.Equal([]string{"Delete package-lock.json and install the dependencies with pnpm?"}, asked)
+		assert.NoFileExists(lockfile)
+		assert.True(mockRunner.HasCommand("pnpm", "install"))
+		assert.Contains(output, "Migrated from npm to pnpm")
+	})
+
+	It("imports the old lock file before deleting it with --import", func() {
+		root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.PathLookup = pathLookup
+			deps.NewConfirmUI = answering(true)
+		})
+
+		_, err := executeCmd(root, "migrate", "npm", "pnpm", "--import", "--yes", "--cwd", dir+"/")
+		assert.NoError(err)
+		assert.Empty(asked, "--yes must not ask before deleting")
+		assert.True(mockRunner.WasCommandCalled("pnpm", "import"))
+		assert.True(mockRunner.HasCommand("pnpm", "install"))
+		assert.NoFileExists(lockfile)
+	})
+
+	It("keeps the lock file when the deletion is declined", func() {
+		root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.PathLookup = pathLookup
+			deps.NewConfirmUI = answering(false)
+		})
+
+		output, err := executeCmd(root, "migrate", "npm", "pnpm", "--cwd", dir+"/")
+		assert.NoError(err)
+		assert.Contains(output, "Migration cancelled")
+		assert.FileExists(lockfile)
+		assert.False(mockRunner.HasBeenCalled)
+	})
+
+	It("refuses to run when the target package manager is not installed", func() {
+		root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.PathLookup = pathLookup
+			deps.NewConfirmUI = answering(true)
+		})
+
+		_, err := executeCmd(root, "migrate", "npm", "yarn", "--yes", "--cwd", dir+"/")
+		assert.ErrorContains(err, "yarn is not installed")
+		assert.FileExists(lockfile)
+		assert.False(mockRunner.HasBeenCalled)
+	})
+
+	It("rejects unsupported migrations", func() {
+		root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.PathLookup = pathLookup
+			deps.NewConfirmUI = answering(true)
+		})
+
+		_, err := executeCmd(root, "migrate", "pnpm", "yarn", "--yes", "--cwd", dir+"/")
+		assert.ErrorContains(err, "migrating from pnpm to yarn is not supported")
+	})
+
+	It("errors when the old lock file does not exist", func() {
+		root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.PathLookup = pathLookup
+			deps.NewConfirmUI = answering(true)
+		})
+
+		_, err := executeCmd(root, "migrate", "pnpm", "npm", "--yes", "--cwd", dir+"/")
+		assert.ErrorContains(err, "no pnpm-lock.yaml found")
+	})
+})
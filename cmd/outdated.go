@@ -0,0 +1,330 @@
+// Package cmd provides command-line interface implementations for the JavaScript package delegator.
+package cmd
+
+import (
+	// standard library
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strings"
+
+	// external
+	"github.com/charmbracelet/log"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+
+	// internal
+	"github.com/louiss0/javascript-package-delegator/detect"
+)
+
+// OutdatedPackage is one entry of the jpd outdated --json report.
+// A field the package manager doesn't report is left out.
+type OutdatedPackage struct {
+	Name    string `json:"name"`
+	Current string `json:"current,omitempty"`
+	Wanted  string `json:"wanted,omitempty"`
+	Latest  string `json:"latest,omitempty"`
+}
+
+// runOutdated runs the outdated command of pm on a runner of newRunner and returns what it wrote to stdout.
+// The runner is decorated like the root one, so --manager-path and --corepack pick the executable.
+func runOutdated(ctx context.Context, newRunner ParallelCommandRunnerFactory, targetDir, pm string, args ...string) ([]byte, error) {
+	var output bytes.Buffer
+	runner, err := newScriptRunner(ctx, &output, io.Discard, newRunner, targetDir, nil, false, pm, args)
+	if err != nil {
+		return nil, err
+	}
+	err = runner.Run()
+
+	// Package managers exit with 1 when they find outdated packages
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && len(bytes.TrimSpace(output.Bytes())) > 0 {
+		return output.Bytes(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to run %s outdated: %w", pm, err)
+	}
+
+	return output.Bytes(), nil
+}
+
+// BuildOutdatedArgs builds the arguments of the outdated command of pm.
+// With asJSON the arguments ask for the output ParseOutdated reads.
+func BuildOutdatedArgs(pm string, packages []string, asJSON bool) ([]string, error) {
+	var args []string
+	switch pm {
+	case "npm":
+		args = []string{"outdated"}
+		if asJSON {
+			args = append(args, "--json")
+		}
+	case "pnpm":
+		args = []string{"outdated"}
+		if asJSON {
+			args = append(args, "--format", "json")
+		}
+	case "yarn":
+		// Yarn v2+ only has an outdated command through yarn-plugin-outdated
+		args = []string{"outdated"}
+		if asJSON {
+			args = append(args, "--json")
+		}
+	case "bun":
+		// bun outdated only prints a table, it is parsed for --json
+		args = []string{"outdated"}
+	case "deno":
+		if asJSON {
+			return nil, fmt.Errorf("deno outdated has no output the --%s flag can read", _JSON_FLAG)
+		}
+		args = []string{"outdated"}
+	default:
+		return nil, unsupportedPackageManagerError(pm)
+	}
+
+	return append(args, packages...), nil
+}
+
+// ParseOutdated normalizes the outdated output of pm into packages sorted by name.
+func ParseOutdated(pm, yarnVersion string, output []byte) ([]OutdatedPackage, error) {
+	if len(bytes.TrimSpace(output)) == 0 {
+		return []OutdatedPackage{}, nil
+	}
+
+	var (
+		packages []OutdatedPackage
+		err      error
+	)
+	switch pm {
+	case "npm", "pnpm":
+		packages, err = parseOutdatedObject(output)
+	case "yarn":
+		if ParseYarnMajor(yarnVersion) < 2 {
+			packages, err = parseYarnClassicOutdated(output)
+		} else {
+			packages, err = parseOutdatedArray(output)
+		}
+	case "bun":
+		packages, err = parseBunOutdated(output)
+	default:
+		return nil, unsupportedPackageManagerError(pm)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s outdated output: %w", pm, err)
+	}
+
+	sort.SliceStable(packages, func(i, j int) bool {
+		return packages[i].Name < packages[j].Name
+	})
+
+	return packages, nil
+}
+
+// parseOutdatedObject reads the output of npm and pnpm, an object keyed by package name.
+// npm reports a package installed in several places as an array of entries.
+func parseOutdatedObject(output []byte) ([]OutdatedPackage, error) {
+	var entries map[string]json.RawMessage
+	if err := json.Unmarshal(output, &entries); err != nil {
+		return nil, err
+	}
+
+	var packages []OutdatedPackage
+	for name, raw := range entries {
+		var versions []OutdatedPackage
+		if bytes.HasPrefix(bytes.TrimSpace(raw), []byte("[")) {
+			if err := json.Unmarshal(raw, &versions); err != nil {
+				return nil, err
+			}
+		} else {
+			var version OutdatedPackage
+			if err := json.Unmarshal(raw, &version); err != nil {
+				return nil, err
+			}
+			versions = []OutdatedPackage{version}
+		}
+
+		for _, version := range versions {
+			version.Name = name
+			packages = append(packages, version)
+		}
+	}
+
+	return packages, nil
+}
+
+// parseOutdatedArray reads the output of yarn-plugin-outdated, an array of packages.
+func parseOutdatedArray(output []byte) ([]OutdatedPackage, error) {
+	var packages []OutdatedPackage
+	if err := json.Unmarshal(output, &packages); err != nil {
+		return nil, err
+	}
+	return packages, nil
+}
+
+// parseYarnClassicOutdated reads the output of yarn v1, one JSON event per line
+// where the table event holds the packages.
+func parseYarnClassicOutdated(output []byte) ([]OutdatedPackage, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		var event struct {
+			Type string          `json:"type"`
+			Data json.RawMessage `json:"data"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return nil, err
+		}
+		// Only the table event has an object as data, the others carry messages
+		if event.Type != "table" {
+			continue
+		}
+
+		var table struct {
+			Head []string   `json:"head"`
+			Body [][]string `json:"body"`
+		}
+		if err := json.Unmarshal(event.Data, &table); err != nil {
+			return nil, err
+		}
+
+		return tableToOutdatedPackages(table.Head, table.Body, "Wanted"), nil
+	}
+
+	return nil, scanner.Err()
+}
+
+var bunDependencyTypeSuffix = regexp.MustCompile(`\s+\((dev|peer|optional)\)$`)
+
+// parseBunOutdated reads the table bun outdated prints.
+// Its Update column is the version the range allows, what the other package managers call wanted.
+func parseBunOutdated(output []byte) ([]OutdatedPackage, error) {
+	var head []string
+	var body [][]string
+
+	for _, line := range strings.Split(string(output), "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "│") && !strings.HasPrefix(line, "|") {
+			continue
+		}
+
+		cells := lo.Map(strings.FieldsFunc(line, func(r rune) bool { return r == '│' || r == '|' }), func(cell string, _ int) string {
+			return strings.TrimSpace(cell)
+		})
+		if head == nil {
+			head = cells
+			continue
+		}
+		if len(cells) > 0 {
+			cells[0] = bunDependencyTypeSuffix.ReplaceAllString(cells[0], "")
+		}
+		body = append(body, cells)
+	}
+
+	if head == nil {
+		return nil, fmt.Errorf("no table found")
+	}
+
+	return tableToOutdatedPackages(head, body, "Update"), nil
+}
+
+// tableToOutdatedPackages turns table rows into packages using the column names in head.
+func tableToOutdatedPackages(head []string, body [][]string, wantedColumn string) []OutdatedPackage {
+	column := func(row []string, name string) string {
+		i := lo.IndexOf(head, name)
+		if i < 0 || i >= len(row) {
+			return ""
+		}
+		return row[i]
+	}
+
+	return lo.Map(body, func(row []string, _ int) OutdatedPackage {
+		return OutdatedPackage{
+			Name:    column(row, "Package"),
+			Current: column(row, "Current"),
+			Wanted:  column(row, wantedColumn),
+			Latest:  column(row, "Latest"),
+		}
+	})
+}
+
+// NewOutdatedCmd creates a new Cobra command that lists the packages with newer versions.
+func NewOutdatedCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "outdated [packages...]",
+		Short: "List packages that have newer versions",
+		Long: `List the packages that have newer versions using the detected package manager.
+With --json the output of every package manager is turned into the same list:
+
+  [{"name": "react", "current": "18.2.0", "wanted": "18.3.1", "latest": "19.0.0"}]
+
+A field the package manager doesn't report is left out.
+Yarn v2+ needs yarn-plugin-outdated for an outdated command and deno has no --json support.
+
+Examples:
+  jpd outdated               # Print the package manager's own report
+  jpd outdated --json        # Print the normalized report as JSON
+  jpd outdated react --json  # Only report react`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pm, _ := cmd.Flags().GetString(AGENT_FLAG)
+			cmdRunner := getCommandRunnerFromCommandContext(cmd)
+			goEnv := getGoEnvFromCommandContext(cmd)
+			de := getDebugExecutorFromCommandContext(cmd)
+
+			asJSON, err := cmd.Flags().GetBool(_JSON_FLAG)
+			if err != nil {
+				return err
+			}
+
+			yarnVersion := ""
+			if pm == "yarn" {
+				if version, err := detect.DetectYarnVersion(getYarnVersionRunnerCommandContext(cmd)); err == nil {
+					yarnVersion = version
+				}
+			}
+
+			cmdArgs, err := BuildOutdatedArgs(pm, args, asJSON)
+			if err != nil {
+				return err
+			}
+
+			de.LogJSCommandIfDebugIsTrue(pm, cmdArgs...)
+
+			if !asJSON {
+				cmdRunner.Command(pm, cmdArgs...)
+
+				goEnv.ExecuteIfModeIsProduction(func() {
+					log.Info("Running command", "pm", pm, "args", strings.Join(cmdArgs, " "))
+				})
+				return cmdRunner.Run()
+			}
+
+			targetDir, err := cmd.Flags().GetString(_CWD_FLAG)
+			if err != nil {
+				return fmt.Errorf("failed to parse --%s flag: %w", _CWD_FLAG, err)
+			}
+
+			output, err := runOutdated(cmd.Context(), getParallelRunnerFromCommandContext(cmd), targetDir, pm, cmdArgs...)
+			if err != nil {
+				return err
+			}
+
+			packages, err := ParseOutdated(pm, yarnVersion, output)
+			if err != nil {
+				return err
+			}
+
+			encoder := json.NewEncoder(cmd.OutOrStdout())
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(packages)
+		},
+	}
+
+	cmd.Flags().Bool(_JSON_FLAG, false, "Print the outdated packages as JSON in the same shape for every package manager")
+
+	return cmd
+}
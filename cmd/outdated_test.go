@@ -0,0 +1,191 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/louiss0/javascript-package-delegator/cmd"
+	"github.com/louiss0/javascript-package-delegator/detect"
+	"github.com/louiss0/javascript-package-delegator/mock"
+	"github.com/louiss0/javascript-package-delegator/testutil"
+)
+
+var _ = Describe("Outdated Command", func() {
+	assert := assert.New(GinkgoT())
+
+	var (
+		mockRunner *mock.MockCommandRunner
+		factory    *testutil.RootCommandFactory
+		scripts    *testutil.MockScriptRunners
+	)
+
+	stub := func(output string) cmd.ParallelCommandRunnerFactory {
+		scripts = &testutil.MockScriptRunners{Setup: testutil.ScriptOutput(nil, lo.Compact([]string{output})...)}
+		return scripts.NewRunner
+	}
+
+	// calledWith returns the command the package manager was asked for the report with
+	calledWith := func() []string {
+		runners := scripts.Runners()
+		if len(runners) == 0 {
+			return nil
+		}
+		return append([]string{runners[0].CommandCall.Name}, runners[0].CommandCall.Args...)
+	}
+
+	report := func(output string) []cmd.OutdatedPackage {
+		var packages []cmd.OutdatedPackage
+		assert.NoError(json.Unmarshal([]byte(output), &packages))
+		return packages
+	}
+
+	BeforeEach(func() {
+		mockRunner = mock.NewMockCommandRunner()
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+		scripts = &testutil.MockScriptRunners{}
+	})
+
+	It("runs the package manager's own report without --json", func() {
+		root := factory.CreateRootCmdWith(testutil.LockfileDetected(detect.PNPM, detect.PNPM_LOCK_YAML), func(deps *cmd.Dependencies) {
+			deps.NewParallelCommandRunner = stub("")
+		})
+
+		_, err := executeCmd(root, "outdated", "react")
+		assert.NoError(err)
+		assert.True(mockRunner.HasCommand("pnpm", "outdated", "react"))
+		assert.Nil(calledWith())
+	})
+
+	It("normalizes npm outdated --json", func() {
+		root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.NewParallelCommandRunner = stub(`{
+  "react": {"current": "18.2.0", "wanted": "18.3.1", "latest": "19.0.0", "dependent": "app", "location": "node_modules/react"},
+  "chalk": [
+    {"current": "4.1.2", "wanted": "4.1.2", "latest": "5.3.0", "location": "node_modules/chalk"}
+  ],
+  "vite": {"wanted": "5.4.0", "latest": "5.4.0"}
+}`)
+		})
+
+		output, err := executeCmd(root, "outdated", "--json")
+		assert.NoError(err)
+		assert.Equal([]string{"npm", "outdated", "--json"}, calledWith())
+		assert.Equal([]cmd.OutdatedPackage{
+			{Name: "chalk", Current: "4.1.2", Wanted: "4.1.2", Latest: "5.3.0"},
+			{Name: "react", Current: "18.2.0", Wanted: "18.3.1", Latest: "19.0.0"},
+			{Name: "vite", Wanted: "5.4.0", Latest: "5.4.0"},
+		}, report(output))
+		assert.NotContains(output, `"current": ""`, "fields the package manager leaves out must be omitted")
+		assert.False(mockRunner.HasBeenCalled)
+	})
+
+	It("normalizes pnpm outdated --format json", func() {
+		root := factory.CreateRootCmdWith(testutil.LockfileDetected(detect.PNPM, detect.PNPM_LOCK_YAML), func(deps *cmd.Dependencies) {
+			deps.NewParallelCommandRunner = stub(`{
+  "typescript": {"current": "5.3.3", "latest": "5.6.2", "wanted": "5.3.3", "isDeprecated": false, "dependencyType": "devDependencies"}
+}`)
+		})
+
+		output, err := executeCmd(root, "outdated", "--json", "typescript")
+		assert.NoError(err)
+		assert.Equal([]string{"pnpm", "outdated", "--format", "json", "typescript"}, calledWith())
+		assert.Equal([]cmd.OutdatedPackage{
+			{Name: "typescript", Current: "5.3.3", Wanted: "5.3.3", Latest: "5.6.2"},
+		}, report(output))
+	})
+
+	It("normalizes yarn v1 outdated --json", func() {
+		root := factory.CreateRootCmdWith(testutil.LockfileDetected(detect.YARN, detect.YARN_LOCK), func(deps *cmd.Dependencies) {
+			deps.YarnCommandVersionOutputter = mock.NewMockYarnCommandVersionOutputer("1.22.19")
+			deps.NewParallelCommandRunner = stub(
+				`{"type":"info","data":"Color legend : ..."}` + "\n" +
+					`{"type":"table","data":{"head":["Package","Current","Wanted","Latest","Package Type","URL"],"body":[["lodash","4.17.20","4.17.21","4.17.21","dependencies","https://lodash.com/"]]}}` + "\n",
+			)
+		})
+
+		output, err := executeCmd(root, "outdated", "--json")
+		assert.NoError(err)
+		assert.Equal([]string{"yarn", "outdated", "--json"}, calledWith())
+		assert.Equal([]cmd.OutdatedPackage{
+			{Name: "lodash", Current: "4.17.20", Wanted: "4.17.21", Latest: "4.17.21"},
+		}, report(output))
+	})
+
+	It("normalizes the yarn v2+ outdated plugin --json", func() {
+		root := factory.CreateRootCmdWith(testutil.LockfileDetected(detect.YARN, detect.YARN_LOCK), func(deps *cmd.Dependencies) {
+			deps.YarnCommandVersionOutputter = mock.NewMockYarnCommandVersionOutputer("4.1.0")
+			deps.NewParallelCommandRunner = stub(
+				`[{"current":"1.6.0","latest":"1.7.2","name":"axios","severity":"minor","type":"dependencies"}]`,
+			)
+		})
+
+		output, err := executeCmd(root, "outdated", "--json")
+		assert.NoError(err)
+		assert.Equal([]cmd.OutdatedPackage{
+			{Name: "axios", Current: "1.6.0", Latest: "1.7.2"},
+		}, report(output))
+	})
+
+	It("normalizes the bun outdated table", func() {
+		root := factory.CreateRootCmdWith(testutil.LockfileDetected(detect.BUN, detect.BUN_LOCKB), func(deps *cmd.Dependencies) {
+			deps.NewParallelCommandRunner = stub(`bun outdated v1.1.30
+
+┌──────────────────┬─────────┬────────┬────────┐
+│ Package          │ Current │ Update │ Latest │
+├──────────────────┼─────────┼────────┼────────┤
+│ zod              │ 3.22.4  │ 3.23.8 │ 3.23.8 │
+├──────────────────┼─────────┼────────┼────────┤
+│ @types/bun (dev) │ 1.0.0   │ 1.0.0  │ 1.1.10 │
+└──────────────────┴─────────┴────────┴────────┘
+`)
+		})
+
+		output, err := executeCmd(root, "outdated", "--json")
+		assert.NoError(err)
+		assert.Equal([]string{"bun", "outdated"}, calledWith())
+		assert.Equal([]cmd.OutdatedPackage{
+			{Name: "@types/bun", Current: "1.0.0", Wanted: "1.0.0", Latest: "1.1.10"},
+			{Name: "zod", Current: "3.22.4", Wanted: "3.23.8", Latest: "3.23.8"},
+		}, report(output))
+	})
+
+	It("runs the --manager-path binary for --json", func() {
+		managerPath := filepath.Join(GinkgoT().TempDir(), "npm-custom")
+		assert.NoError(os.WriteFile(managerPath, []byte("#!/bin/sh\n"), 0o755))
+		root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.NewParallelCommandRunner = stub("{}")
+		})
+
+		_, err := executeCmd(root, "--manager-path", managerPath, "outdated", "--json")
+		assert.NoError(err)
+		assert.Equal([]string{managerPath, "outdated", "--json"}, calledWith())
+	})
+
+	It("prints an empty list when nothing is outdated", func() {
+		root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.NewParallelCommandRunner = stub("")
+		})
+
+		output, err := executeCmd(root, "outdated", "--json")
+		assert.NoError(err)
+		assert.Equal("[]\n", output)
+	})
+
+	It("rejects --json for deno", func() {
+		root := factory.CreateRootCmdWith(testutil.LockfileDetected(detect.DENO, detect.DENO_LOCK), func(deps *cmd.Dependencies) {
+			deps.NewParallelCommandRunner = stub("")
+		})
+
+		_, err := executeCmd(root, "outdated", "--json")
+		assert.ErrorContains(err, "deno outdated has no output the --json flag can read")
+	})
+})
@@ -0,0 +1,188 @@
+// Package cmd provides command-line interface implementations for the JavaScript package delegator.
+package cmd
+
+import (
+	// standard library
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+
+	// external
+	"github.com/charmbracelet/x/term"
+	"github.com/creack/pty"
+)
+
+const _TTY_FLAG = "tty"
+
+// ptyStarter starts cmd attached to a new pseudo terminal and returns its controlling side.
+type ptyStarter func(cmd *exec.Cmd) (*os.File, error)
+
+// ptyCommandRunner runs commands attached to a pseudo terminal so they see a terminal
+// even when jpd's own output is piped, which keeps their colors and progress bars.
+// The command's stdout and stderr share the terminal and reach jpd's stdout.
+// When no pseudo terminal can be allocated the command runs with pipes instead.
+type ptyCommandRunner struct {
+	*commandRunner
+	startPty ptyStarter
+	name     string
+	args     []string
+}
+
+// NewTTYCommandRunner creates a CommandRunner that runs commands attached to a pseudo terminal.
+func NewTTYCommandRunner() CommandRunner {
+	return newPtyCommandRunner(exec.Command)
+}
+
+func newPtyCommandRunner(execCommandFunc _ExecCommandFunc) CommandRunner {
+	return &ptyCommandRunner{
+		commandRunner: newCommandRunner(execCommandFunc).(*commandRunner),
+		startPty:      pty.Start,
+	}
+}
+
+func (p *ptyCommandRunner) Command(name string, args ...string) {
+	p.name, p.args = name, args
+	p.commandRunner.Command(name, args...)
+}
+
+func (p *ptyCommandRunner) Run() error {
+	if p.cmd == nil {
+		return fmt.Errorf("no command set to run")
+	}
+
+	// pty.Start only attaches the terminal to the streams that are left empty
+	p.cmd.Stdin, p.cmd.Stdout, p.cmd.Stderr = nil, nil, nil
+
+	ptmx, err := p.startPty(p.cmd)
+	if err != nil {
+		// The command is built again because a started exec.Cmd can't be reused
+		p.commandRunner.Command(p.name, p.args...)
+		return p.commandRunner.Run()
+	}
+	defer func() { _ = ptmx.Close() }()
+
+	stdinIsTerminal := false
+	if stdin, ok := p.stdin.(*os.File); ok && term.IsTerminal(stdin.Fd()) {
+		stdinIsTerminal = true
+		_ = pty.InheritSize(stdin, ptmx)
+
+		// The terminal of the command echoes and edits the input, jpd's own terminal must not
+		if state, err := term.MakeRaw(stdin.Fd()); err == nil {
+			defer func() { _ = term.Restore(stdin.Fd(), state) }()
+		}
+	}
+
+	exited := make(chan struct{})
+	forwarded := make(chan struct{})
+	if p.stdin != nil {
+		go func() {
+			defer close(forwarded)
+			// Piped input ends with the end of file character of the terminal, so the command sees it too
+			if ended := stdinPumpFor(p.stdin).copyTo(ptmx, exited); ended && !stdinIsTerminal {
+				_, _ = ptmx.Write([]byte{4})
+			}
+		}()
+	} else {
+		close(forwarded)
+	}
+
+	copied := make(chan struct{})
+	go func() {
+		// Reading fails once the command exits and closes its side of the terminal
+		_, _ = io.Copy(io.MultiWriter(p.stdout, p.tail), ptmx)
+		close(copied)
+	}()
+
+	err = p.cmd.Wait()
+	close(exited)
+	<-forwarded
+	<-copied
+
+	if err != nil {
+		return newCommandExitError(err, p.tail)
+	}
+
+	return nil
+}
+
+// stdinPump reads one stdin on a single goroutine for as long as jpd runs and hands what it read
+// to the command that is running. A command that exited stops taking input, so sequential commands
+// don't race each other for the keystrokes meant for the next one.
+type stdinPump struct {
+	chunks  chan []byte
+	pending []byte
+}
+
+var (
+	stdinPumpsMu sync.Mutex
+	stdinPumps   = map[io.Reader]*stdinPump{}
+)
+
+// stdinPumpFor returns the pump of r, starting it the first time r is asked for.
+func stdinPumpFor(r io.Reader) *stdinPump {
+	stdinPumpsMu.Lock()
+	defer stdinPumpsMu.Unlock()
+
+	if pump, ok := stdinPumps[r]; ok {
+		return pump
+	}
+
+	pump := &stdinPump{chunks: make(chan []byte)}
+	go func() {
+		defer close(pump.chunks)
+		for {
+			buf := make([]byte, 4096)
+			n, err := r.Read(buf)
+			if n > 0 {
+				pump.chunks <- buf[:n]
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	stdinPumps[r] = pump
+	return pump
+}
+
+// copyTo writes what the pump reads to w until done is closed.
+// It reports whether stdin ended, input read after done was closed is kept for the next command.
+func (s *stdinPump) copyTo(w io.Writer, done <-chan struct{}) bool {
+	for {
+		if s.pending == nil {
+			select {
+			case <-done:
+				return false
+			case chunk, ok := <-s.chunks:
+				if !ok {
+					return true
+				}
+				s.pending = chunk
+			}
+		}
+
+		select {
+		case <-done:
+			return false
+		default:
+		}
+
+		if _, err := w.Write(s.pending); err != nil {
+			return false
+		}
+		s.pending = nil
+	}
+}
+
+// useTTYRunner reports whether commands should run attached to a pseudo terminal:
+// when --tty is set or when jpd's own stdout is a terminal.
+func useTTYRunner(tty bool, stdoutIsTerminal func() bool) bool {
+	return tty || (stdoutIsTerminal != nil && stdoutIsTerminal())
+}
+
+// stdoutIsTerminal reports whether jpd's stdout is a terminal.
+func stdoutIsTerminal() bool {
+	return term.IsTerminal(os.Stdout.Fd())
+}
@@ -0,0 +1,80 @@
+//go:build pty
+
+package cmd_test
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/louiss0/javascript-package-delegator/cmd"
+)
+
+// These specs start real processes on a pseudo terminal, run them with: go test -tags pty ./cmd
+var _ = Describe("TTY command runner", func() {
+	assert := assert.New(GinkgoT())
+
+	It("attaches stdout of the command to a terminal", func() {
+		runner := cmd.NewTTYCommandRunner()
+		runner.Command("sh", "-c", "test -t 1")
+		assert.NoError(runner.Run())
+	})
+
+	Context("with piped stdin", func() {
+		var stdin *os.File
+
+		BeforeEach(func() {
+			reader, writer, err := os.Pipe()
+			assert.NoError(err)
+			stdin = writer
+
+			// The runner takes jpd's stdin when it's created
+			previous := os.Stdin
+			os.Stdin = reader
+			DeferCleanup(func() {
+				os.Stdin = previous
+				_ = reader.Close()
+				_ = writer.Close()
+			})
+		})
+
+		It("forwards the input to the command", func() {
+			_, err := stdin.WriteString("y\n")
+			assert.NoError(err)
+
+			runner := cmd.NewTTYCommandRunner()
+			runner.Command("sh", "-c", `read answer && test "$answer" = y`)
+			assert.NoError(runner.Run())
+		})
+
+		It("ends the input of the command when the pipe is closed", func() {
+			_, err := stdin.WriteString("a\nb\n")
+			assert.NoError(err)
+			assert.NoError(stdin.Close())
+
+			runner := cmd.NewTTYCommandRunner()
+			runner.Command("sh", "-c", `test "$(cat | wc -l)" -eq 2`)
+			assert.NoError(runner.Run())
+		})
+
+		It("leaves the input written after a command exited to the next one", func() {
+			runner := cmd.NewTTYCommandRunner()
+			runner.Command("true")
+			assert.NoError(runner.Run())
+
+			_, err := stdin.WriteString("next\n")
+			assert.NoError(err)
+
+			runner.Command("sh", "-c", `read answer && test "$answer" = next`)
+			assert.NoError(runner.Run())
+		})
+	})
+
+	It("keeps the exit code of the command", func() {
+		runner := cmd.NewTTYCommandRunner()
+		runner.Command("sh", "-c", "exit 3")
+		err := runner.Run()
+		assert.Equal(3, cmd.ExitCodeForError(err))
+	})
+})
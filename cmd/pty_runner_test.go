@@ -0,0 +1,72 @@
+package cmd_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/louiss0/javascript-package-delegator/cmd"
+	"github.com/louiss0/javascript-package-delegator/mock"
+	"github.com/louiss0/javascript-package-delegator/testutil"
+)
+
+var _ = Describe("TTY command runner selection", func() {
+	assert := assert.New(GinkgoT())
+
+	var (
+		mockRunner *mock.MockCommandRunner
+		ttyRunner  *mock.MockCommandRunner
+		factory    *testutil.RootCommandFactory
+	)
+
+	BeforeEach(func() {
+		mockRunner = mock.NewMockCommandRunner()
+		ttyRunner = mock.NewMockCommandRunner()
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+	})
+
+	It("runs through the TTY runner with --tty", func() {
+		_, err := executeCmd(factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.NewTTYCommandRunner = func() cmd.CommandRunner {
+				return ttyRunner
+			}
+			deps.StdoutIsTerminal = func() bool {
+				return false
+			}
+		}), "install", "--tty")
+		assert.NoError(err)
+		assert.True(ttyRunner.HasCommand("npm", "install"))
+		assert.Empty(mockRunner.CommandHistory())
+	})
+
+	It("runs through the TTY runner when stdout is a terminal", func() {
+		_, err := executeCmd(factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.NewTTYCommandRunner = func() cmd.CommandRunner {
+				return ttyRunner
+			}
+			deps.StdoutIsTerminal = func() bool {
+				return true
+			}
+		}), "install")
+		assert.NoError(err)
+		assert.True(ttyRunner.HasCommand("npm", "install"))
+		assert.Empty(mockRunner.CommandHistory())
+	})
+
+	It("keeps the pipe runner when stdout is not a terminal", func() {
+		_, err := executeCmd(factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.NewTTYCommandRunner = func() cmd.CommandRunner {
+				return ttyRunner
+			}
+			deps.StdoutIsTerminal = func() bool {
+				return false
+			}
+		}), "install")
+		assert.NoError(err)
+		assert.True(mockRunner.HasCommand("npm", "install"))
+		assert.Empty(ttyRunner.CommandHistory())
+	})
+})
@@ -0,0 +1,40 @@
+// Package cmd provides command-line interface implementations for the JavaScript package delegator.
+package cmd
+
+import (
+	// standard library
+	"fmt"
+
+	// external
+	"github.com/spf13/cobra"
+)
+
+const _QUIET_FLAG = "quiet"
+
+// isQuiet reports whether --quiet is set for cmd.
+func isQuiet(cmd *cobra.Command) bool {
+	quiet, _ := cmd.Flags().GetBool(_QUIET_FLAG)
+	return quiet
+}
+
+// errIfQuiet returns an error instead of letting cmd open the prompt described by prompt when --quiet is set.
+func errIfQuiet(cmd *cobra.Command, prompt string) error {
+	if !isQuiet(cmd) {
+		return nil
+	}
+	return fmt.Errorf("%s needs a prompt, which the --%s flag disables", prompt, _QUIET_FLAG)
+}
+
+// printNote prints a "Note: " line explaining how jpd handled a flag, unless --quiet is set.
+func printNote(cmd *cobra.Command, format string, a ...any) error {
+	if isQuiet(cmd) {
+		return nil
+	}
+	_, err := fmt.Fprintf(cmd.OutOrStdout(), "Note: "+format+"\n", a...)
+	return err
+}
+
+// noPackageManagerError explains how to pick a package manager when --quiet stops jpd from asking to install one.
+func noPackageManagerError(err error) error {
+	return fmt.Errorf("no package manager was detected, pass --%s or set %s: %w", AGENT_FLAG, JPD_AGENT_ENV_VAR, err)
+}
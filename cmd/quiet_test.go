@@ -0,0 +1,102 @@
+package cmd_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/louiss0/javascript-package-delegator/cmd"
+	"github.com/louiss0/javascript-package-delegator/detect"
+	"github.com/louiss0/javascript-package-delegator/mock"
+	"github.com/louiss0/javascript-package-delegator/testutil"
+)
+
+var _ = Describe("Quiet flag", func() {
+	assert := assert.New(GinkgoT())
+
+	var (
+		mockRunner *mock.MockCommandRunner
+		factory    *testutil.RootCommandFactory
+		prompted   bool
+	)
+
+	// undetectedDependencies detects lockfile, whose package manager isn't installed,
+	// and finds no other package manager in PATH.
+	undetectedDependencies := func(lockfile string) cmd.Dependencies {
+		return cmd.Dependencies{
+			CommandRunnerGetter: func() cmd.CommandRunner {
+				return mockRunner
+			},
+			NewDebugExecutor: func(bool) cmd.DebugExecutor {
+				return factory.DebugExecutor()
+			},
+			DetectLockfile: func(targetDir string) (string, error) {
+				if lockfile == "" {
+					return "", os.ErrNotExist
+				}
+				return lockfile, nil
+			},
+			DetectJSPackageManagerBasedOnLockFile: func(detectedLockFile string) (string, error) {
+				return "", detect.ErrNoPackageManager
+			},
+			DetectJSPackageManager: func() (string, error) {
+				return "", detect.ErrNoPackageManager
+			},
+			NewCommandTextUI: func(lockfile string) cmd.CommandUITexter {
+				prompted = true
+				return mock.NewMockCommandTextUI(lockfile)
+			},
+			YarnCommandVersionOutputter: mock.NewMockYarnCommandVersionOutputer(""),
+		}
+	}
+
+	BeforeEach(func() {
+		mockRunner = mock.NewMockCommandRunner()
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+		prompted = false
+
+		GinkgoT().Setenv(cmd.JPD_AGENT_ENV_VAR, "")
+		assert.NoError(os.Unsetenv(cmd.JPD_AGENT_ENV_VAR))
+	})
+
+	DescribeTable("errors instead of prompting to install a package manager",
+		func(lockfile string) {
+			root := cmd.NewRootCmdForTesting(undetectedDependencies(lockfile))
+
+			_, err := executeCmd(root, "install", "--quiet")
+			assert.ErrorContains(err, "no package manager was detected, pass --agent or set JPD_AGENT")
+			assert.ErrorContains(err, "installing a package manager needs a prompt, which the --quiet flag disables")
+			assert.False(prompted, "the CommandUITexter must not be created")
+			assert.False(mockRunner.HasBeenCalled)
+		},
+		Entry("without a lock file", ""),
+		Entry("with the lock file of a missing package manager", detect.YARN_LOCK),
+	)
+
+	It("hides notes but keeps running the command", func() {
+		output, err := executeCmd(factory.CreateNpmAsDefault(nil), "install", "lodash", "--hoist", "-q")
+		assert.NoError(err)
+		assert.Empty(output)
+		assert.True(mockRunner.HasCommand("npm", "install", "lodash"))
+	})
+
+	It("errors instead of asking which script to run", func() {
+		targetDir := GinkgoT().TempDir()
+		assert.NoError(os.WriteFile(filepath.Join(targetDir, "package.json"), []byte(`{"scripts": {"dev": "vite"}}`), 0644))
+
+		_, err := executeCmd(factory.CreateNpmAsDefault(nil), "--cwd", targetDir+"/", "run", "--quiet")
+		assert.ErrorContains(err, "choosing a script needs a prompt, which the --quiet flag disables")
+		assert.False(mockRunner.HasBeenCalled)
+	})
+
+	It("cannot be combined with --debug", func() {
+		_, err := executeCmd(factory.CreateNpmAsDefault(nil), "install", "--quiet", "--debug")
+		assert.ErrorContains(err, "the --quiet flag cannot be combined with --debug")
+	})
+})
@@ -0,0 +1,99 @@
+package cmd_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	tmock "github.com/stretchr/testify/mock"
+
+	"github.com/louiss0/javascript-package-delegator/mock"
+	"github.com/louiss0/javascript-package-delegator/testutil"
+)
+
+var _ = Describe("NDJSON reporter", func() {
+	assert := assert.New(GinkgoT())
+
+	var (
+		mockRunner *mock.MockCommandRunner
+		factory    *testutil.RootCommandFactory
+	)
+
+	// executeReported runs the command and returns every event written to stderr.
+	executeReported := func(root *cobra.Command, args ...string) ([]map[string]any, error) {
+		stderr := new(bytes.Buffer)
+		// Keep cobra's own error output out of the event stream
+		root.SilenceErrors = true
+		root.SilenceUsage = true
+		root.SetOut(new(bytes.Buffer))
+		root.SetErr(stderr)
+		root.SetArgs(args)
+		err := root.Execute()
+
+		var events []map[string]any
+		for _, line := range strings.Split(strings.TrimSpace(stderr.String()), "\n") {
+			if line == "" {
+				continue
+			}
+			var event map[string]any
+			assert.NoError(json.Unmarshal([]byte(line), &event), "every stderr line must be JSON: %q", line)
+			events = append(events, event)
+		}
+		return events, err
+	}
+
+	BeforeEach(func() {
+		mockRunner = mock.NewMockCommandRunner()
+		mockRunner.On("Run", "npm", []string{"install", "broken-pkg"}, tmock.Anything).
+			Return(fakeExitError{code: 7}).Maybe()
+
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+	})
+
+	It("emits an exec and a done event for a successful command", func() {
+		dir := GinkgoT().TempDir() + "/"
+
+		events, err := executeReported(factory.CreateNpmAsDefault(nil), "--reporter", "ndjson", "--cwd", dir, "run", "dev")
+		assert.NoError(err)
+		assert.Len(events, 2)
+		assert.Equal(map[string]any{
+			"event": "exec",
+			"pm":    "npm",
+			"argv":  []any{"run", "dev"},
+			"cwd":   dir,
+		}, events[0])
+		assert.Equal("done", events[1]["event"])
+		assert.Equal(float64(0), events[1]["exitCode"])
+		assert.GreaterOrEqual(events[1]["durationMs"], float64(0))
+	})
+
+	It("emits the done event with the exit code when the command fails", func() {
+		events, err := executeReported(factory.CreateNpmAsDefault(nil), "--reporter=ndjson", "install", "broken-pkg")
+		assert.Error(err)
+		assert.Len(events, 2)
+		assert.Equal("exec", events[0]["event"])
+		assert.Equal([]any{"install", "broken-pkg"}, events[0]["argv"])
+		assert.Equal("done", events[1]["event"])
+		assert.Equal(float64(7), events[1]["exitCode"])
+	})
+
+	It("emits nothing without the flag", func() {
+		events, err := executeReported(factory.CreateNpmAsDefault(nil), "run", "dev")
+		assert.NoError(err)
+		assert.Empty(events)
+	})
+
+	It("rejects unknown reporters", func() {
+		_, err := executeReported(factory.CreateNpmAsDefault(nil), "--reporter", "xml", "run", "dev")
+		assert.Error(err)
+		assert.Contains(err.Error(), "the --reporter flag must be one of [ndjson]")
+		assert.False(mockRunner.HasBeenCalled)
+	})
+})
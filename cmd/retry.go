@@ -0,0 +1,91 @@
+// Package cmd provides command-line interface implementations for the JavaScript package delegator.
+package cmd
+
+import (
+	// standard library
+	"fmt"
+	"time"
+
+	// external
+	"github.com/spf13/cobra"
+)
+
+const (
+	_RETRIES_FLAG  = "retries"
+	_ATTEMPTS_FLAG = "attempts"
+)
+
+// retryBaseDelay is the wait before the first retry, it doubles for every retry after it.
+const retryBaseDelay = time.Second
+
+// attemptDelay is the fixed wait between two attempts of run --attempts, a flaky script needs no backoff.
+const attemptDelay = time.Second
+
+// RetrySleeper waits between two attempts of a failed command.
+type RetrySleeper func(time.Duration)
+
+// addRetriesFlag registers --retries on a command that runs the package manager.
+func addRetriesFlag(cmd *cobra.Command) {
+	cmd.Flags().Int(_RETRIES_FLAG, 0, "Run the command again up to n times with exponential backoff when it fails")
+}
+
+// parseRetries validates a --retries value.
+func parseRetries(value int) (int, error) {
+	if value < 0 {
+		return 0, fmt.Errorf("the --%s flag must not be negative, got %d", _RETRIES_FLAG, value)
+	}
+	return value, nil
+}
+
+// runWithRetries runs the command already prepared on cmdRunner and runs it again
+// up to retries times when it fails, waiting twice as long before every new attempt.
+// Only failures of the runner are retried, JPD validates everything before this point.
+func runWithRetries(cmd *cobra.Command, cmdRunner CommandRunner, retries int) error {
+	de := getDebugExecutorFromCommandContext(cmd)
+	sleep := getRetrySleeperFromCommandContext(cmd)
+
+	delay := retryBaseDelay
+	for attempt := 1; ; attempt++ {
+		err := cmdRunner.Run()
+		if err == nil || attempt > retries {
+			return err
+		}
+
+		de.LogDebugMessageIfDebugIsTrue(
+			"Command failed, retrying",
+			"attempt", attempt,
+			"delay", delay.String(),
+			"error", err.Error(),
+		)
+		sleep(delay)
+		delay *= 2
+	}
+}
+
+// runAttempts calls run up to attempts times, until one of the attempts succeeds.
+// The failure of the last attempt is returned when none of them do.
+func runAttempts(cmd *cobra.Command, attempts int, run func() error) error {
+	if attempts <= 1 {
+		return run()
+	}
+
+	de := getDebugExecutorFromCommandContext(cmd)
+	sleep := getRetrySleeperFromCommandContext(cmd)
+
+	for attempt := 1; ; attempt++ {
+		de.LogDebugMessageIfDebugIsTrue("Running the script", "attempt", attempt, "attempts", attempts)
+
+		err := run()
+		if err == nil || attempt >= attempts {
+			return err
+		}
+
+		de.LogDebugMessageIfDebugIsTrue(
+			"Script failed, running it again",
+			"attempt", attempt,
+			"delay", attemptDelay.String(),
+			"error", err.Error(),
+		)
+		sleep(attemptDelay)
+	}
+}
@@ -0,0 +1,184 @@
+package cmd_test
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/stretchr/testify/assert"
+	tmock "github.com/stretchr/testify/mock"
+
+	"github.com/louiss0/javascript-package-delegator/cmd"
+	"github.com/louiss0/javascript-package-delegator/mock"
+	"github.com/louiss0/javascript-package-delegator/testutil"
+)
+
+var _ = Describe("Retries", func() {
+	assert := assert.New(GinkgoT())
+
+	var (
+		mockRunner *mock.MockCommandRunner
+		factory    *testutil.RootCommandFactory
+		delays     []time.Duration
+	)
+
+	// failTwiceThenSucceed makes the first two runs of argv fail.
+	failTwiceThenSucceed := func(pm string, argv ...string) {
+		mockRunner.On("Run", pm, argv, tmock.Anything).Return(fakeExitError{code: 1}).Twice()
+		mockRunner.On("Run", pm, argv, tmock.Anything).Return(nil).Once()
+	}
+
+	BeforeEach(func() {
+		mockRunner = mock.NewMockCommandRunner()
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		delays = nil
+	})
+
+	setup := func() {
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+	}
+
+	recordDelay := func(delay time.Duration) {
+		delays = append(delays, delay)
+	}
+
+	It("retries a failing install with exponential backoff until it succeeds", func() {
+		failTwiceThenSucceed("npm", "install", "react")
+		setup()
+		root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.RetrySleeper = recordDelay
+		})
+
+		_, err := executeCmd(root, "install", "react", "--retries", "3")
+		assert.NoError(err)
+		mockRunner.AssertNumberOfCalls(GinkgoT(), "Run", 3)
+		assert.Equal([]time.Duration{time.Second, 2 * time.Second}, delays)
+	})
+
+	It("returns the last failure once the retries are used up", func() {
+		failTwiceThenSucceed("npm", "install", "react")
+		setup()
+		root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.RetrySleeper = recordDelay
+		})
+
+		_, err := executeCmd(root, "install", "react", "--retries", "1")
+		assert.Error(err)
+		mockRunner.AssertNumberOfCalls(GinkgoT(), "Run", 2)
+		assert.Equal([]time.Duration{time.Second}, delays)
+	})
+
+	It("does not retry by default", func() {
+		failTwiceThenSucceed("npm", "install", "react")
+		setup()
+		root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.RetrySleeper = recordDelay
+		})
+
+		_, err := executeCmd(root, "install", "react")
+		assert.Error(err)
+		mockRunner.AssertNumberOfCalls(GinkgoT(), "Run", 1)
+		assert.Empty(delays)
+	})
+
+	It("retries clean-install", func() {
+		failTwiceThenSucceed("npm", "ci")
+		setup()
+		root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.RetrySleeper = recordDelay
+		})
+
+		_, err := executeCmd(root, "clean-install", "--retries", "2")
+		assert.NoError(err)
+		mockRunner.AssertNumberOfCalls(GinkgoT(), "Run", 3)
+	})
+
+	It("retries create", func() {
+		failTwiceThenSucceed("npm", "create", "vite", "--", "my-app")
+		setup()
+		root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.RetrySleeper = recordDelay
+		})
+
+		_, err := executeCmd(root, "create", "--retries", "2", "vite", "my-app")
+		assert.NoError(err)
+		mockRunner.AssertNumberOfCalls(GinkgoT(), "Run", 3)
+	})
+
+	It("does not retry JPD validation errors", func() {
+		setup()
+		root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.RetrySleeper = recordDelay
+		})
+
+		_, err := executeCmd(root, "install", "react", "--retries", "-1")
+		assert.ErrorContains(err, "the --retries flag must not be negative")
+		assert.False(mockRunner.HasBeenCalled)
+	})
+
+	Context("run --attempts", func() {
+		var dir string
+
+		BeforeEach(func() {
+			dir = GinkgoT().TempDir()
+			assert.NoError(os.WriteFile(filepath.Join(dir, "package.json"), []byte(`{"scripts": {"e2e": "playwright test"}}`), 0o644))
+		})
+
+		It("runs a flaky script again until an attempt passes", func() {
+			failTwiceThenSucceed("npm", "run", "e2e")
+			setup()
+			root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+				deps.RetrySleeper = recordDelay
+			})
+
+			_, err := executeCmd(root, "--cwd", dir+"/", "run", "e2e", "--attempts", "3")
+			assert.NoError(err)
+			mockRunner.AssertNumberOfCalls(GinkgoT(), "Run", 3)
+			assert.Equal([]time.Duration{time.Second, time.Second}, delays)
+			for attempt := 1; attempt <= 3; attempt++ {
+				factory.DebugExecutor().AssertCalled(GinkgoT(), "LogDebugMessageIfDebugIsTrue", "Running the script", "attempt", attempt, "attempts", 3)
+			}
+		})
+
+		It("returns the failure of the last attempt", func() {
+			failTwiceThenSucceed("npm", "run", "e2e")
+			setup()
+			root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+				deps.RetrySleeper = recordDelay
+			})
+
+			_, err := executeCmd(root, "--cwd", dir+"/", "run", "e2e", "--attempts", "2")
+			assert.Error(err)
+			mockRunner.AssertNumberOfCalls(GinkgoT(), "Run", 2)
+			assert.Equal([]time.Duration{time.Second}, delays)
+		})
+
+		It("runs the script once by default", func() {
+			failTwiceThenSucceed("npm", "run", "e2e")
+			setup()
+			root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+				deps.RetrySleeper = recordDelay
+			})
+
+			_, err := executeCmd(root, "--cwd", dir+"/", "run", "e2e")
+			assert.Error(err)
+			mockRunner.AssertNumberOfCalls(GinkgoT(), "Run", 1)
+			assert.Empty(delays)
+		})
+
+		It("returns an error for less than one attempt", func() {
+			setup()
+			root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+				deps.RetrySleeper = recordDelay
+			})
+
+			_, err := executeCmd(root, "--cwd", dir+"/", "run", "e2e", "--attempts", "0")
+			assert.ErrorContains(err, "the --attempts flag must be at least 1, got: 0")
+			assert.False(mockRunner.HasBeenCalled)
+		})
+	})
+})
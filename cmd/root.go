@@ -26,12 +26,18 @@ package cmd
 import (
 	// standard library
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"regexp"
+	"runtime"
 	"strings"
+	"sync"
+	"time"
 
 	// external
 	"github.com/charmbracelet/fang"
@@ -56,6 +62,11 @@ const (
 	_GO_ENV                 = "go_env"                 // Used for storing GoEnv in context
 	_YARN_VERSION_OUTPUTTER = "yarn_version_outputter" // Key for YarnCommandVersionOutputter
 	_DEBUG_EXECUTOR         = "debug_executor"
+	_DETECTION_CACHE        = "detection_cache" // Key for the per invocation detectionCache
+	_NODE_VERSION_OUTPUTTER = "node_version_outputter"
+	_RETRY_SLEEPER          = "retry_sleeper"
+	_AGENT_SOURCE           = "agent_source" // Key for where the agent was resolved from
+	_PARALLEL_RUNNER        = "parallel_runner"
 )
 
 const (
@@ -64,6 +75,10 @@ const (
 	AGENT_FLAG         = "agent"
 	_CWD_FLAG          = "cwd"
 	_DEBUG_FLAG        = "debug"
+	_MANAGER_PATH_FLAG = "manager-path"
+	_NO_ANCESTOR_FLAG  = "no-ancestor-search"
+	_REPORTER_FLAG     = "reporter"
+	REPORTER_NDJSON    = "ndjson"
 )
 
 // CommandRunner Interface and its implementation
@@ -79,6 +94,12 @@ type CommandRunner interface {
 	// This method calls the underlying `exec.Run()` to execute the command from `exec.Cmd`!
 	Run() error
 	SetTargetDir(string) error
+	// SetEnv adds KEY=VALUE entries to the environment of the commands that run next.
+	// Entries win over variables of the same name inherited from JPD's own environment.
+	SetEnv([]string)
+	// ClearEnv stops the commands that run next from inheriting JPD's own environment,
+	// they only get the entries passed to SetEnv.
+	ClearEnv()
 }
 
 type _ExecCommandFunc func(string, ...string) *exec.Cmd
@@ -87,24 +108,79 @@ type commandRunner struct {
 	execCommandFunc _ExecCommandFunc
 	cmd             *exec.Cmd
 	targetDir       string
+	env             []string
+	clearEnv        bool
+	stdin           io.Reader
+	stdout          io.Writer
+	stderr          io.Writer
+	tail            *stderrTail
+}
+
+// stderrTail keeps the end of what a command wrote to stderr so its failure can be explained.
+type stderrTail struct {
+	buf []byte
+	max int
+}
+
+func (t *stderrTail) Write(p []byte) (int, error) {
+	t.buf = append(t.buf, p...)
+	if len(t.buf) > t.max {
+		t.buf = t.buf[len(t.buf)-t.max:]
+	}
+	return len(p), nil
 }
 
 func newCommandRunner(execCommandFunc _ExecCommandFunc) CommandRunner {
 	return &commandRunner{
 		execCommandFunc: execCommandFunc,
+		stdin:           os.Stdin,
+		stdout:          os.Stdout,
+		stderr:          os.Stderr,
 	}
 }
 
 func (e *commandRunner) Command(name string, args ...string) {
 	e.cmd = e.execCommandFunc(name, args...)
-	e.cmd.Stdin = os.Stdin   // Ensure stdin is connected for interactive commands
-	e.cmd.Stdout = os.Stdout // Ensure output goes to stdout
-	e.cmd.Stderr = os.Stderr // Ensure errors go to stderr
+	e.cmd.Stdin = e.stdin   // Ensure stdin is connected for interactive commands
+	e.cmd.Stdout = e.stdout // Ensure output goes to stdout
+	e.tail = &stderrTail{max: 4096}
+	e.cmd.Stderr = io.MultiWriter(e.stderr, e.tail) // Ensure errors go to stderr
 
 	// Apply any previously set target directory
 	if e.targetDir != "" {
 		e.cmd.Dir = e.targetDir
 	}
+
+	if len(e.env) > 0 || e.clearEnv {
+		e.cmd.Env = e.commandEnv()
+	}
+}
+
+// commandEnv returns the environment of the next command, the env entries on top of JPD's own
+// environment or on their own once it has been cleared.
+func (e *commandRunner) commandEnv() []string {
+	if e.clearEnv {
+		return append([]string{}, e.env...)
+	}
+	return append(os.Environ(), e.env...)
+}
+
+func (e *commandRunner) SetEnv(env []string) {
+	e.env = env
+
+	// If a command has already been created, update it immediately
+	if e.cmd != nil {
+		e.cmd.Env = e.commandEnv()
+	}
+}
+
+func (e *commandRunner) ClearEnv() {
+	e.clearEnv = true
+
+	// If a command has already been created, update it immediately
+	if e.cmd != nil {
+		e.cmd.Env = e.commandEnv()
+	}
 }
 
 func (e *commandRunner) SetTargetDir(dir string) error {
@@ -132,7 +208,240 @@ func (e *commandRunner) Run() error {
 	if e.cmd == nil {
 		return fmt.Errorf("no command set to run")
 	}
-	return e.cmd.Run()
+
+	if err := e.cmd.Run(); err != nil {
+		return newCommandExitError(err, e.tail)
+	}
+
+	return nil
+}
+
+// newCommandExitError wraps the error of a delegated command with the end of its stderr.
+func newCommandExitError(err error, tail *stderrTail) *CommandExitError {
+	// Keep the child's exit code so Execute can hand it back to the shell
+	code := EXIT_CODE_COMMAND_FAILURE
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() > 0 {
+		code = exitErr.ExitCode()
+	}
+	return &CommandExitError{Code: code, Err: err, Stderr: string(tail.buf)}
+}
+
+// Exit codes returned by Execute
+const (
+	EXIT_CODE_COMMAND_FAILURE = 1 // The delegated command failed without an exit code of its own
+	EXIT_CODE_USAGE_ERROR     = 2 // JPD rejected the flags, arguments or project setup
+)
+
+// CommandExitError reports that the delegated package manager command failed.
+// The error message is left untouched so callers see the same text as before.
+type CommandExitError struct {
+	Code   int
+	Err    error
+	Stderr string // The end of what the command wrote to stderr
+}
+
+func (e *CommandExitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *CommandExitError) Unwrap() error {
+	return e.Err
+}
+
+// ExitCode returns the exit code of the delegated command.
+func (e *CommandExitError) ExitCode() int {
+	return e.Code
+}
+
+// ExitCodeForError maps an error returned by the root command to a process exit code.
+// Failures of the delegated command keep their exit code, every other error is a JPD error.
+func ExitCodeForError(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	var exitCoder interface{ ExitCode() int }
+	if errors.As(err, &exitCoder) {
+		return lo.Ternary(exitCoder.ExitCode() > 0, exitCoder.ExitCode(), EXIT_CODE_COMMAND_FAILURE)
+	}
+
+	return EXIT_CODE_USAGE_ERROR
+}
+
+// commandExitCode returns the exit code of a command that returned err, 0 when it succeeded.
+func commandExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	var exitCoder interface{ ExitCode() int }
+	if errors.As(err, &exitCoder) && exitCoder.ExitCode() > 0 {
+		return exitCoder.ExitCode()
+	}
+	return EXIT_CODE_COMMAND_FAILURE
+}
+
+// managerPathCommandRunner invokes a specific package manager binary instead of resolving it from PATH.
+// Only commands for the resolved agent are redirected, so the manager specific argv is untouched.
+type managerPathCommandRunner struct {
+	CommandRunner
+	managerPath string
+	agent       func() string
+}
+
+func (m managerPathCommandRunner) Command(name string, args ...string) {
+	if agent := m.agent(); agent != "" && name == agent {
+		name = m.managerPath
+	}
+	m.CommandRunner.Command(name, args...)
+}
+
+// reportingCommandRunner writes an NDJSON event before and after every command it runs,
+// so tools wrapping JPD can follow what was executed without parsing its output.
+type reportingCommandRunner struct {
+	CommandRunner
+	w         io.Writer
+	pm        string
+	argv      []string
+	targetDir string
+}
+
+type reporterExecEvent struct {
+	Event string   `json:"event"`
+	PM    string   `json:"pm"`
+	Argv  []string `json:"argv"`
+	Cwd   string   `json:"cwd"`
+}
+
+type reporterDoneEvent struct {
+	Event      string `json:"event"`
+	ExitCode   int    `json:"exitCode"`
+	DurationMs int64  `json:"durationMs"`
+}
+
+func (r *reportingCommandRunner) Command(name string, args ...string) {
+	r.pm = name
+	r.argv = append([]string{}, args...)
+	r.CommandRunner.Command(name, args...)
+}
+
+func (r *reportingCommandRunner) SetTargetDir(dir string) error {
+	if err := r.CommandRunner.SetTargetDir(dir); err != nil {
+		return err
+	}
+	r.targetDir = dir
+	return nil
+}
+
+func (r *reportingCommandRunner) Run() (err error) {
+	cwd := r.targetDir
+	if cwd == "" {
+		cwd, _ = os.Getwd()
+	}
+
+	encoder := json.NewEncoder(r.w)
+	if encodeErr := encoder.Encode(reporterExecEvent{Event: "exec", PM: r.pm, Argv: r.argv, Cwd: cwd}); encodeErr != nil {
+		return fmt.Errorf("failed to write reporter event: %w", encodeErr)
+	}
+
+	start := time.Now()
+	// The done event is written even when the command fails
+	defer func() {
+		_ = encoder.Encode(reporterDoneEvent{Event: "done", ExitCode: commandExitCode(err), DurationMs: time.Since(start).Milliseconds()})
+	}()
+
+	return r.CommandRunner.Run()
+}
+
+// validateManagerPath makes sure the manager path points to an executable file.
+func validateManagerPath(managerPath string) error {
+	fileInfo, err := os.Stat(managerPath)
+	if err != nil {
+		return fmt.Errorf("invalid --%s: %w", _MANAGER_PATH_FLAG, err)
+	}
+
+	if fileInfo.IsDir() {
+		return fmt.Errorf("invalid --%s: %s is a directory", _MANAGER_PATH_FLAG, managerPath)
+	}
+
+	// Windows has no executable bit, the extension decides instead
+	if runtime.GOOS != "windows" && fileInfo.Mode().Perm()&0o111 == 0 {
+		return fmt.Errorf("invalid --%s: %s is not executable", _MANAGER_PATH_FLAG, managerPath)
+	}
+
+	return nil
+}
+
+type detectionResult struct {
+	value string
+	err   error
+}
+
+// detectionCache memoizes detection results for the duration of one root command execution.
+// Results are keyed by target directory so the detectors run at most once per directory.
+type detectionCache struct {
+	mu              sync.Mutex
+	lockfiles       map[string]detectionResult
+	packageManagers map[string]detectionResult
+	yarnVersions    map[string]detectionResult
+}
+
+func newDetectionCache() *detectionCache {
+	return &detectionCache{
+		lockfiles:       map[string]detectionResult{},
+		packageManagers: map[string]detectionResult{},
+		yarnVersions:    map[string]detectionResult{},
+	}
+}
+
+func (d *detectionCache) remember(results map[string]detectionResult, key string, detect func() (string, error)) (string, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if result, ok := results[key]; ok {
+		return result.value, result.err
+	}
+
+	value, err := detect()
+	results[key] = detectionResult{value: value, err: err}
+	return value, err
+}
+
+// Lockfile returns the lock file found in targetDir.
+func (d *detectionCache) Lockfile(targetDir string, detectLockfile func(targetDir string) (string, error)) (string, error) {
+	return d.remember(d.lockfiles, targetDir, func() (string, error) {
+		return detectLockfile(targetDir)
+	})
+}
+
+// PackageManager returns the package manager detected for the lock file of targetDir.
+func (d *detectionCache) PackageManager(targetDir, lockfile string, detectPM func(lockfile string) (string, error)) (string, error) {
+	return d.remember(d.packageManagers, targetDir, func() (string, error) {
+		return detectPM(lockfile)
+	})
+}
+
+// YarnVersionOutputter wraps outputter so yarn is asked for its version once for targetDir.
+func (d *detectionCache) YarnVersionOutputter(targetDir string, outputter detect.YarnCommandVersionOutputter) detect.YarnCommandVersionOutputter {
+	return cachedYarnVersionOutputter{cache: d, targetDir: targetDir, outputter: outputter}
+}
+
+// yarnVersionHint reports a yarn version known from the project instead of asking yarn for it.
+type yarnVersionHint string
+
+func (h yarnVersionHint) Output() (string, error) {
+	return string(h), nil
+}
+
+type cachedYarnVersionOutputter struct {
+	cache     *detectionCache
+	targetDir string
+	outputter detect.YarnCommandVersionOutputter
+}
+
+func (c cachedYarnVersionOutputter) Output() (string, error) {
+	return c.cache.remember(c.cache.yarnVersions, c.targetDir, c.outputter.Output)
 }
 
 // Dependencies holds the external dependencies for testing and real execution
@@ -159,17 +468,32 @@ type DependencyMultiSelectUI = DependencyUIMultiSelector
 
 type Dependencies struct {
 	CommandRunnerGetter                   func() CommandRunner
+	NewTTYCommandRunner                   func() CommandRunner
+	StdoutIsTerminal                      func() bool
 	DetectJSPackageManagerBasedOnLockFile func(detectedLockFile string) (packageManager string, err error)
 	YarnCommandVersionOutputter           detect.YarnCommandVersionOutputter
 	NewCommandTextUI                      func(lockfile string) CommandUITexter
 	DetectLockfile                        func(targetDir string) (lockfile string, err error)
+	DetectLockfileInAncestors             func(startDir string) (lockfileDir string, lockfile string, err error)
 	DetectJSPackageManager                func() (string, error)
+	DetectYarnConfig                      func(targetDir string) (config string, err error)
 	DetectVolta                           func() bool
+	DetectCorepack                        func() bool
+	PathLookup                            detect.PathLookup
 	NewPackageMultiSelectUI               func([]services.PackageInfo) MultiUISelecter
 	NewTaskSelectorUI                     func(options []string) TaskUISelector
+	NewFileWatcher                        func(dir string) (FileWatcher, error)
+	NewParallelCommandRunner              ParallelCommandRunnerFactory
 	NewDependencyMultiSelectUI            func(options []string) DependencyUIMultiSelector
+	NewUpdateMultiSelectUI                func(options []string) MultiUISelecter
+	NpmSearchOutputter                    NpmSearchOutputter
+	NodeVersionOutputter                  NodeVersionOutputter
+	PackageManagerVersionOutputter        PackageManagerVersionOutputter
+	RetrySleeper                          RetrySleeper
 	NewCreateAppSearcher                  func() CreateAppSearcher
+	NewPeerDependencyFetcher              func() PeerDependencyFetcher
 	NewCreateAppSelector                  func([]services.PackageInfo) CreateAppSelector
+	NewConfirmUI                          func(title string) UIConfirmer
 	NewDebugExecutor                      func(bool) DebugExecutor
 }
 
@@ -280,6 +604,7 @@ func NewRootCmdForTesting(deps Dependencies) *cobra.Command {
 // newRootCmdImpl is the internal implementation
 func newRootCmdImpl(deps Dependencies) *cobra.Command {
 	cwdFlag := custom_flags.NewFolderPathFlag(_CWD_FLAG)
+	managerPathFlag := custom_flags.NewFilePathFlag(_MANAGER_PATH_FLAG)
 
 	cmd := &cobra.Command{
 		Use:     "jpd",
@@ -303,7 +628,21 @@ Available commands:
 		update     - Update packages (equivalent to 'nup')
 		uninstall  - Uninstall packages (equivalent to 'nun')
 		clean-install - Clean install with frozen lockfile (equivalent to 'nci')
-		agent      - Show detected package manager (equivalent to 'na')`,
+		agent      - Show detected package manager (equivalent to 'na')
+		doctor     - Report the environment jpd sees
+		config     - Read and write package manager configuration
+		which      - Print the path of the package manager executable
+		add-script - Add a script to package.json or a task to deno.json
+		info       - Show registry metadata of a package
+		migrate    - Switch a project to another package manager
+		outdated   - List packages that have newer versions
+		list-workspaces - Print the workspace packages of the monorepo
+		scripts    - Print the scripts of package.json or the tasks of deno.json
+		env        - Print the configuration jpd resolved and where it came from
+		bump       - Increase the version of the package
+		detect     - Print the detected lock file and package manager
+		audit      - Check the dependencies for known vulnerabilities
+		alias      - Manage custom command aliases`,
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			versionFlag, err := cmd.Flags().GetBool("version")
@@ -335,6 +674,16 @@ Available commands:
 				log.Error(err.Error()) // Log error, but don't stop execution unless critical
 			}
 
+			colorMode, err := c.Flags().GetString(_COLOR_FLAG)
+			if err != nil {
+				return err
+			}
+			colorProfile, err := resolveColorProfile(colorMode, c.ErrOrStderr(), os.Environ())
+			if err != nil {
+				return err
+			}
+			applyColorProfile(colorProfile)
+
 			goEnv := env.NewGoEnv() // Instantiate GoEnv
 
 			// Store dependencies and other derived values in the command context
@@ -342,6 +691,71 @@ Available commands:
 
 			commandRunner := deps.CommandRunnerGetter()
 
+			tty, err := c.Flags().GetBool(_TTY_FLAG)
+			if err != nil {
+				return err
+			}
+
+			// Tests leave NewTTYCommandRunner out, so the injected runner is kept
+			if deps.NewTTYCommandRunner != nil && useTTYRunner(tty, deps.StdoutIsTerminal) {
+				commandRunner = deps.NewTTYCommandRunner()
+			}
+
+			// Runners created later on, like the ones of run --parallel, get the same wrappers
+			var runnerDecorators []func(CommandRunner) CommandRunner
+			decorateRunner := func(decorate func(CommandRunner) CommandRunner) {
+				commandRunner = decorate(commandRunner)
+				runnerDecorators = append(runnerDecorators, decorate)
+			}
+
+			if managerPath := managerPathFlag.String(); managerPath != "" {
+				if err := validateManagerPath(managerPath); err != nil {
+					return err
+				}
+
+				decorateRunner(func(runner CommandRunner) CommandRunner {
+					return managerPathCommandRunner{
+						CommandRunner: runner,
+						managerPath:   managerPath,
+						agent: func() string {
+							agent, _ := c.Flags().GetString(AGENT_FLAG)
+							return agent
+						},
+					}
+				})
+			}
+
+			reporter, err := c.Flags().GetString(_REPORTER_FLAG)
+			if err != nil {
+				return err
+			}
+
+			switch reporter {
+			case "":
+			case REPORTER_NDJSON:
+				decorateRunner(func(runner CommandRunner) CommandRunner {
+					return &reportingCommandRunner{
+						CommandRunner: runner,
+						w:             c.ErrOrStderr(),
+					}
+				})
+			default:
+				return fmt.Errorf("the --%s flag must be one of [%s]", _REPORTER_FLAG, REPORTER_NDJSON)
+			}
+
+			// Relative directories are resolved against the process directory,
+			// so detection, execution and subcommands reading --cwd all see the same absolute path
+			if cwd := cwdFlag.String(); cwd != "" && !filepath.IsAbs(cwd) {
+				absCwd, err := filepath.Abs(cwd)
+				if err != nil {
+					return fmt.Errorf("failed to resolve the --%s flag: %w", _CWD_FLAG, err)
+				}
+
+				if err := c.Flags().Set(_CWD_FLAG, withTrailingSeparator(absCwd)); err != nil {
+					return err
+				}
+			}
+
 			if cwd := cwdFlag.String(); cwd != "" {
 
 				err := commandRunner.SetTargetDir(cwd)
@@ -356,17 +770,98 @@ Available commands:
 				return err
 			}
 
-			if debug {
+			quiet, err := c.Flags().GetBool(_QUIET_FLAG)
+			if err != nil {
+				return err
+			}
+
+			if debug && quiet {
+				return fmt.Errorf("the --%s flag cannot be combined with --%s", _QUIET_FLAG, _DEBUG_FLAG)
+			}
+
+			switch {
+			case debug:
 				log.SetLevel(log.DebugLevel)
+			case quiet:
+				// JPD's info and warning logs are hidden, errors are still reported
+				log.SetLevel(log.ErrorLevel)
+			default:
+				log.SetLevel(log.InfoLevel)
 			}
 
 			debugExecutor := deps.NewDebugExecutor(debug)
 
+			// Determine the target directory from cwd flag or use current working directory
+			targetDir := cwdFlag.String()
+			if targetDir == "" {
+				cwd, err := os.Getwd()
+				if err != nil {
+					return err
+				}
+				targetDir = cwd
+			}
+
+			useCorepack, err := c.Flags().GetBool(_COREPACK_FLAG)
+			if err != nil {
+				return err
+			}
+
+			if useCorepack {
+				if managerPathFlag.String() != "" {
+					return fmt.Errorf("the --%s flag cannot be combined with --%s", _COREPACK_FLAG, _MANAGER_PATH_FLAG)
+				}
+				if deps.DetectCorepack == nil || !deps.DetectCorepack() {
+					return fmt.Errorf("the --%s flag requires corepack to be in PATH", _COREPACK_FLAG)
+				}
+
+				packageManager, err := readPackageManagerFieldFrom(targetDir)
+				if err != nil {
+					return err
+				}
+
+				// Corepack only knows which version to run when the project pins one
+				if packageManager != "" {
+					decorateRunner(func(runner CommandRunner) CommandRunner {
+						return corepackCommandRunner{
+							CommandRunner: runner,
+							agent: func() string {
+								agent, _ := c.Flags().GetString(AGENT_FLAG)
+								return agent
+							},
+						}
+					})
+				} else {
+					debugExecutor.LogDebugMessageIfDebugIsTrue("The packageManager field is not set, corepack is not used")
+				}
+			}
+
+			nodeVersionOutputter := deps.NodeVersionOutputter
+			if nodeVersionOutputter == nil {
+				nodeVersionOutputter = runNodeVersion
+			}
+
+			retrySleeper := deps.RetrySleeper
+			if retrySleeper == nil {
+				retrySleeper = time.Sleep
+			}
+
+			newParallelRunner := deps.NewParallelCommandRunner
+			if newParallelRunner == nil {
+				newParallelRunner = newParallelCommandRunner
+			}
+
+			// Detection results are shared by everything that runs during this invocation
+			cache := newDetectionCache()
+
 			lo.ForEach([][2]any{
 				{_GO_ENV, goEnv},
 				{COMMAND_RUNNER_KEY, commandRunner},
-				{_YARN_VERSION_OUTPUTTER, deps.YarnCommandVersionOutputter},
+				{_YARN_VERSION_OUTPUTTER, cache.YarnVersionOutputter(targetDir, deps.YarnCommandVersionOutputter)},
 				{_DEBUG_EXECUTOR, debugExecutor},
+				{_DETECTION_CACHE, cache},
+				{_NODE_VERSION_OUTPUTTER, nodeVersionOutputter},
+				{_RETRY_SLEEPER, retrySleeper},
+				{_PARALLEL_RUNNER, newParallelRunner.withDecorators(runnerDecorators...)},
 			}, func(item [2]any, index int) {
 				c_ctx = context.WithValue(
 					c_ctx,
@@ -377,20 +872,51 @@ Available commands:
 
 			persistentFlags := c.Flags()
 
-			// Determine the target directory from cwd flag or use current working directory
-			targetDir := cwdFlag.String()
-			if targetDir == "" {
-				cwd, err := os.Getwd()
-				if err != nil {
-					return err
+			// Always run detection logic first (for --cwd support)
+			var detectedPM, detectedSource string
+			lockFile, err := cache.Lockfile(targetDir, deps.DetectLockfile)
+
+			noAncestorSearch, flagErr := c.Flags().GetBool(_NO_ANCESTOR_FLAG)
+			if flagErr != nil {
+				return flagErr
+			}
+
+			// Workspace packages usually have no lock file of their own, the nearest ancestor's is used instead
+			if err != nil && !noAncestorSearch && deps.DetectLockfileInAncestors != nil {
+				absTargetDir, absErr := filepath.Abs(targetDir)
+				if absErr != nil {
+					return absErr
+				}
+
+				lockfileDir, ancestorLockFile, ancestorErr := deps.DetectLockfileInAncestors(filepath.Dir(absTargetDir))
+				if ancestorErr == nil {
+					debugExecutor.LogDebugMessageIfDebugIsTrue(
+						"Lock file is detected in an ancestor directory",
+						"path", filepath.Join(lockfileDir, ancestorLockFile),
+					)
+					lockFile, err = ancestorLockFile, nil
 				}
-				targetDir = cwd
 			}
 
-			// Always run detection logic first (for --cwd support)
-			var detectedPM string
-			lockFile, err := deps.DetectLockfile(targetDir)
-			if err != nil {
+			// A yarn config file points at yarn before the project has a lock file
+			yarnConfig := ""
+			if err != nil && deps.DetectYarnConfig != nil {
+				if config, configErr := deps.DetectYarnConfig(targetDir); configErr == nil {
+					yarnConfig = config
+				}
+			}
+
+			if yarnConfig != "" {
+				debugExecutor.LogDebugMessageIfDebugIsTrue("Yarn config file is detected", "config", yarnConfig)
+				detectedPM, detectedSource = detect.YARN, AGENT_SOURCE_YARN_CONFIG
+
+				// Only yarn v2+ reads .yarnrc.yml, so its version is known without running yarn --version
+				if yarnConfig == detect.YARNRC_YML {
+					if version, versionErr := detect.YarnVersionFromConfig(targetDir); versionErr == nil {
+						c_ctx = context.WithValue(c_ctx, _YARN_VERSION_OUTPUTTER, yarnVersionHint(version))
+					}
+				}
+			} else if err != nil {
 				debugExecutor.LogDebugMessageIfDebugIsTrue("Lock file is not detected")
 
 				pm, err := deps.DetectJSPackageManager()
@@ -412,6 +938,9 @@ Available commands:
 						detectedPM = agent
 					} else {
 						// No agent specified and no PM detected, prompt for installation
+						if err := errIfQuiet(c, "installing a package manager"); err != nil {
+							return noPackageManagerError(err)
+						}
 						commandTextUI := deps.NewCommandTextUI("")
 
 						if err := commandTextUI.Run(); err != nil {
@@ -437,13 +966,13 @@ Available commands:
 					}
 				} else {
 					debugExecutor.LogDebugMessageIfDebugIsTrue("Package manager detected from path", "pm", pm)
-					detectedPM = pm
+					detectedPM, detectedSource = pm, AGENT_SOURCE_PATH
 				}
 			} else {
 				debugExecutor.LogDebugMessageIfDebugIsTrue("Lock file is detected", "lockfile", lockFile)
 
 				// Package manager detection and potential installation logic
-				pm, err := deps.DetectJSPackageManagerBasedOnLockFile(lockFile) // Use injected detector
+				pm, err := cache.PackageManager(targetDir, lockFile, deps.DetectJSPackageManagerBasedOnLockFile) // Use injected detector
 				if err != nil {
 
 					if errors.Is(err, detect.ErrNoPackageManager) {
@@ -461,7 +990,7 @@ Available commands:
 							goEnv.ExecuteIfModeIsProduction(func() {
 								log.Info("Found alternative package manager", "pm", pm)
 							})
-							detectedPM = pm
+							detectedPM, detectedSource = pm, AGENT_SOURCE_PATH
 						} else {
 							// Check if agent flag or env var is set before prompting for install
 							agent, err := persistentFlags.GetString(AGENT_FLAG)
@@ -477,6 +1006,9 @@ Available commands:
 								detectedPM = agent
 							} else {
 								// No package manager found at all, prompt for installation
+								if err := errIfQuiet(c, "installing a package manager"); err != nil {
+									return noPackageManagerError(err)
+								}
 								goEnv.ExecuteIfModeIsProduction(func() {
 									log.Warn("No package manager found on the system")
 									log.Warn("You'll be asked to provide a command to install one")
@@ -514,7 +1046,7 @@ Available commands:
 					}
 				} else {
 					debugExecutor.LogDebugMessageIfDebugIsTrue("Package manager is detected based on lock file", "pm", pm)
-					detectedPM = pm
+					detectedPM, detectedSource = pm, AGENT_SOURCE_LOCKFILE
 				}
 			}
 
@@ -525,12 +1057,16 @@ Available commands:
 			}
 
 			if agent != "" {
+				if err := checkAgentPolicy(agent); err != nil {
+					return err
+				}
+
 				debugExecutor.LogDebugMessageIfDebugIsTrue(
 					"Agent flag is set",
 					"agent", agent,
 				)
 				_ = persistentFlags.Set(AGENT_FLAG, agent)
-				c.SetContext(c_ctx)
+				c.SetContext(context.WithValue(c_ctx, _AGENT_SOURCE, AGENT_SOURCE_FLAG))
 				return nil
 			}
 
@@ -546,6 +1082,10 @@ Available commands:
 					)
 				}
 
+				if err := checkAgentPolicy(agent); err != nil {
+					return err
+				}
+
 				goEnv.ExecuteIfModeIsProduction(func() {
 					log.Info("Using package manager", "agent", agent)
 				})
@@ -555,13 +1095,18 @@ Available commands:
 					"agent", agent,
 				)
 				_ = persistentFlags.Set(AGENT_FLAG, agent)
-				c.SetContext(c_ctx)
+				c.SetContext(context.WithValue(c_ctx, _AGENT_SOURCE, AGENT_SOURCE_ENV))
 				return nil
 			}
 
 			// Use detected package manager if no agent override
 			if detectedPM != "" {
+				if err := checkAgentPolicy(detectedPM); err != nil {
+					return err
+				}
+
 				_ = persistentFlags.Set(AGENT_FLAG, detectedPM)
+				c_ctx = context.WithValue(c_ctx, _AGENT_SOURCE, detectedSource)
 			}
 			c.SetContext(c_ctx)
 			return nil
@@ -569,8 +1114,22 @@ Available commands:
 	}
 
 	// Add all subcommands
-	cmd.AddCommand(NewInstallCmd(deps.DetectVolta, deps.NewPackageMultiSelectUI))
-	cmd.AddCommand(NewRunCmd(deps.NewTaskSelectorUI))
+	newPeerDependencyFetcher := deps.NewPeerDependencyFetcher
+	if newPeerDependencyFetcher == nil {
+		newPeerDependencyFetcher = func() PeerDependencyFetcher {
+			return services.NewNpmRegistryService()
+		}
+	}
+	npmSearch := deps.NpmSearchOutputter
+	if npmSearch == nil {
+		npmSearch = runNpmSearch
+	}
+	cmd.AddCommand(NewInstallCmd(deps.DetectVolta, deps.NewPackageMultiSelectUI, newPeerDependencyFetcher, npmSearch))
+	newFileWatcher := deps.NewFileWatcher
+	if newFileWatcher == nil {
+		newFileWatcher = NewPollingFileWatcher
+	}
+	cmd.AddCommand(NewRunCmd(deps.NewTaskSelectorUI, newFileWatcher))
 	cmd.AddCommand(NewStartCmd())
 	cmd.AddCommand(NewExecCmd())
 	cmd.AddCommand(NewDlxCmd())
@@ -584,11 +1143,42 @@ Available commands:
 	if createAppSelector == nil {
 		createAppSelector = NewCreateAppSelector
 	}
-	cmd.AddCommand(NewCreateCmd(createAppSearcher, createAppSelector))
-	cmd.AddCommand(NewUpdateCmd())
+	newConfirm := deps.NewConfirmUI
+	if newConfirm == nil {
+		newConfirm = newConfirmUI
+	}
+	cmd.AddCommand(NewCreateCmd(createAppSearcher, createAppSelector, newConfirm))
+	newUpdateSelectUI := deps.NewUpdateMultiSelectUI
+	if newUpdateSelectUI == nil {
+		newUpdateSelectUI = newUpdateMultiSelectUI
+	}
+	cmd.AddCommand(NewUpdateCmd(newUpdateSelectUI))
 	cmd.AddCommand(NewUninstallCmd(deps.NewDependencyMultiSelectUI))
 	cmd.AddCommand(NewCleanInstallCmd(deps.DetectVolta))
 	cmd.AddCommand(NewAgentCmd())
+	pathLookup := deps.PathLookup
+	if pathLookup == nil {
+		pathLookup = detect.RealPathLookup{}
+	}
+	packageManagerVersion := deps.PackageManagerVersionOutputter
+	if packageManagerVersion == nil {
+		packageManagerVersion = runPackageManagerVersion
+	}
+	cmd.AddCommand(NewDoctorCmd(pathLookup, deps.DetectVolta, deps.DetectLockfile, packageManagerVersion))
+	cmd.AddCommand(NewConfigCmd())
+	cmd.AddCommand(NewWhichCmd(pathLookup))
+	cmd.AddCommand(NewAddScriptCmd())
+	cmd.AddCommand(NewInfoCmd())
+	cmd.AddCommand(NewSelfTestCmd(pathLookup))
+	cmd.AddCommand(NewMigrateCmd(pathLookup, newConfirm))
+	cmd.AddCommand(NewOutdatedCmd())
+	cmd.AddCommand(NewListWorkspacesCmd())
+	cmd.AddCommand(NewScriptsCmd())
+	cmd.AddCommand(NewEnvCmd(deps.DetectVolta, deps.DetectLockfile))
+	cmd.AddCommand(NewBumpCmd())
+	cmd.AddCommand(NewDetectCmd(deps.DetectLockfile, deps.DetectJSPackageManagerBasedOnLockFile))
+	cmd.AddCommand(NewAuditCmd())
+	cmd.AddCommand(NewAliasCmd())
 	completionCmd := NewCompletionCmd()
 	integrateCmd := NewIntegrateCmd()
 	cmd.AddCommand(completionCmd)
@@ -601,6 +1191,16 @@ Available commands:
 
 	cmd.PersistentFlags().VarP(cwdFlag, _CWD_FLAG, "C", "Set the working directory for commands (must end with '/' unless it's just '/')")
 
+	cmd.PersistentFlags().Bool(_NO_ANCESTOR_FLAG, false, "Only look for a lock file in the working directory, not its parents")
+
+	cmd.PersistentFlags().Var(managerPathFlag, _MANAGER_PATH_FLAG, "Run the detected package manager from this binary instead of PATH")
+
+	cmd.PersistentFlags().Bool(_COREPACK_FLAG, false, "Run npm, pnpm and yarn through corepack when package.json sets the packageManager field")
+	cmd.PersistentFlags().String(_COLOR_FLAG, COLOR_AUTO, "Color JPD's own output (auto, always, never), auto respects NO_COLOR and FORCE_COLOR")
+	cmd.PersistentFlags().String(_REPORTER_FLAG, "", "Write an NDJSON event to stderr for every executed command (ndjson)")
+	cmd.PersistentFlags().Bool(_TTY_FLAG, false, "Run the package manager attached to a pseudo terminal, the default when stdout is a terminal")
+	cmd.PersistentFlags().BoolP(_QUIET_FLAG, "q", false, "Hide JPD's own messages and fail instead of prompting, the package manager's output is kept")
+
 	_ = cmd.RegisterFlagCompletionFunc(
 		AGENT_FLAG,
 		cobra.FixedCompletions(detect.SupportedJSPackageManagers[:], cobra.ShellCompDirectiveNoFileComp),
@@ -627,6 +1227,8 @@ func init() {
 			CommandRunnerGetter: func() CommandRunner {
 				return newCommandRunner(exec.Command)
 			}, // Use the newExecutor constructor
+			NewTTYCommandRunner: NewTTYCommandRunner,
+			StdoutIsTerminal:    stdoutIsTerminal,
 			DetectJSPackageManagerBasedOnLockFile: func(detectedLockFile string) (packageManager string, err error) {
 				return detect.DetectJSPackageManagerBasedOnLockFile(detectedLockFile, detect.RealPathLookup{})
 			},
@@ -635,18 +1237,37 @@ func init() {
 			DetectVolta: func() bool {
 				return detect.DetectVolta(detect.RealPathLookup{})
 			},
+			DetectCorepack: func() bool {
+				return detect.DetectCorepack(detect.RealPathLookup{})
+			},
 			DetectLockfile: func(targetDir string) (lockfile string, err error) {
 				return detect.DetectLockfileIn(targetDir, detect.RealFileSystem{})
 			},
+			DetectLockfileInAncestors: func(startDir string) (lockfileDir string, lockfile string, err error) {
+				return detect.DetectLockfileInAncestors(startDir, detect.RealFileSystem{})
+			},
 			DetectJSPackageManager: func() (string, error) {
 				return detect.DetectJSPackageManager(detect.RealPathLookup{})
 			},
-			NewPackageMultiSelectUI:    newPackageMultiSelectUI,
-			NewTaskSelectorUI:          newTaskSelectorUI,
-			NewDependencyMultiSelectUI: newDependencySelectorUI,
+			DetectYarnConfig: func(targetDir string) (config string, err error) {
+				return detect.DetectYarnConfigIn(targetDir, detect.RealFileSystem{})
+			},
+			PathLookup:                     detect.RealPathLookup{},
+			NewPackageMultiSelectUI:        newPackageMultiSelectUI,
+			NewTaskSelectorUI:              newTaskSelectorUI,
+			NewDependencyMultiSelectUI:     newDependencySelectorUI,
+			NewUpdateMultiSelectUI:         newUpdateMultiSelectUI,
+			NpmSearchOutputter:             runNpmSearch,
+			NodeVersionOutputter:           runNodeVersion,
+			PackageManagerVersionOutputter: runPackageManagerVersion,
+			RetrySleeper:                   time.Sleep,
+			NewParallelCommandRunner:       newParallelCommandRunner,
 			NewCreateAppSearcher: func() CreateAppSearcher {
 				return services.NewNpmRegistryService()
 			},
+			NewPeerDependencyFetcher: func() PeerDependencyFetcher {
+				return services.NewNpmRegistryService()
+			},
 			NewCreateAppSelector: NewCreateAppSelector,
 			NewDebugExecutor:     newDebugExecutor,
 		},
@@ -656,14 +1277,23 @@ func init() {
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
-	err := fang.Execute(
+	// Aliases of the jpd config file are expanded before cobra picks the subcommand
+	args, err := ExpandConfigAliases(rootCmd, os.Args[1:])
+	if err != nil {
+		log.Error(err.Error())
+		os.Exit(EXIT_CODE_USAGE_ERROR)
+	}
+	rootCmd.SetArgs(args)
+
+	err = fang.Execute(
 		context.Background(),
 		rootCmd,
 		fang.WithoutCompletions(),
 		fang.WithVersion(build_info.CLI_VERSION.String()),
+		fang.WithErrorHandler(NewErrorHandler(rootCmd)),
 	)
 	if err != nil {
-		os.Exit(1)
+		os.Exit(ExitCodeForError(err))
 	}
 }
 
@@ -682,7 +1312,42 @@ func getYarnVersionRunnerCommandContext(cmd *cobra.Command) detect.YarnCommandVe
 	return cmd.Context().Value(_YARN_VERSION_OUTPUTTER).(detect.YarnCommandVersionOutputter)
 }
 
+func getDetectionCacheFromCommandContext(cmd *cobra.Command) *detectionCache {
+	return cmd.Context().Value(_DETECTION_CACHE).(*detectionCache)
+}
+
+func getNodeVersionOutputterFromCommandContext(cmd *cobra.Command) NodeVersionOutputter {
+	return cmd.Context().Value(_NODE_VERSION_OUTPUTTER).(NodeVersionOutputter)
+}
+
+// getAgentSourceFromCommandContext returns where the agent was resolved from, AGENT_SOURCE_NONE when no agent was resolved.
+func getAgentSourceFromCommandContext(cmd *cobra.Command) string {
+	source, ok := cmd.Context().Value(_AGENT_SOURCE).(string)
+	if !ok || source == "" {
+		return AGENT_SOURCE_NONE
+	}
+	return source
+}
+
 func getGoEnvFromCommandContext(cmd *cobra.Command) env.GoEnv {
 	goEnv := cmd.Context().Value(_GO_ENV).(env.GoEnv)
 	return goEnv
 }
+
+func getRetrySleeperFromCommandContext(cmd *cobra.Command) RetrySleeper {
+	return cmd.Context().Value(_RETRY_SLEEPER).(RetrySleeper)
+}
+
+// getParallelRunnerFromCommandContext returns the factory of runners that are started next to the command runner,
+// wrapped like the command runner itself.
+func getParallelRunnerFromCommandContext(cmd *cobra.Command) ParallelCommandRunnerFactory {
+	return cmd.Context().Value(_PARALLEL_RUNNER).(ParallelCommandRunnerFactory)
+}
+
+// withTrailingSeparator appends a path separator to dir, the --cwd flag only accepts directories ending with one.
+func withTrailingSeparator(dir string) string {
+	if strings.HasSuffix(dir, string(filepath.Separator)) {
+		return dir
+	}
+	return dir + string(filepath.Separator)
+}
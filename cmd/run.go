@@ -24,17 +24,31 @@ THE SOFTWARE.
 package cmd
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"runtime"
+	"sort"
 	"strings"
+	"syscall"
+	"time"
 
 	// "github.com/charmbracelet/bubbles/table"
 	"github.com/charmbracelet/huh"
 	"github.com/charmbracelet/log"
+	"github.com/joho/godotenv"
 	"github.com/samber/lo"
 	"github.com/spf13/cobra"
+
+	"github.com/louiss0/javascript-package-delegator/detect"
+	"github.com/louiss0/javascript-package-delegator/internal/deps"
+	"github.com/louiss0/javascript-package-delegator/internal/watch"
 )
 
 type taskSelectorUI struct {
@@ -60,26 +74,92 @@ func (t *taskSelectorUI) Run() error {
 	return t.selectUI.Value(&t.selectedValue).Run()
 }
 
-func NewRunCmd(newTaskSelectorUI func(options []string) TaskUISelector) *cobra.Command {
+// FileWatcher reports the paths that changed under a watched directory.
+// The events channel is closed when the watcher stops.
+type FileWatcher interface {
+	Events() <-chan string
+	// Reset drops the changes that weren't reported yet, the ones the script made while it ran.
+	Reset()
+	Close() error
+}
+
+// NewPollingFileWatcher watches dir by polling it, skipping node_modules and .git.
+func NewPollingFileWatcher(dir string) (FileWatcher, error) {
+	return watch.NewPollingWatcher(dir, 500*time.Millisecond)
+}
+
+func NewRunCmd(
+	newTaskSelectorUI func(options []string) TaskUISelector,
+	newFileWatcher func(dir string) (FileWatcher, error),
+) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "run [script] [args...]",
 		Short: "Run scripts using the detected package manager",
 		Long: `Run package.json scripts using the appropriate package manager.
 Equivalent to 'nr' command - detects npm, yarn, pnpm, or bun and runs the script.
+The node_modules/.bin directory of the project is put in front of PATH for every package manager.
 
 Examples:
   javascript-package-delegator run             # List available scripts
   javascript-package-delegator run dev         # Run dev script
   javascript-package-delegator run build --prod # Run build script with args
-  javascript-package-delegator run test -- --watch # Run test with npm-style args`,
+  javascript-package-delegator run test -- --watch # Run test with npm-style args
+  javascript-package-delegator run test -- --filter foo # Runs 'deno task test --filter foo' on deno
+  javascript-package-delegator run --list      # Print scripts without running anything
+  javascript-package-delegator run --list --json # Print scripts as JSON
+  javascript-package-delegator run --list-json # Print scripts as JSON with the command that runs each one
+  javascript-package-delegator run build --watch # Run build again whenever a file changes
+  javascript-package-delegator run dev --env-file .env --env-file .env.local # Load env files first
+  javascript-package-delegator run test --with-node-options "--max-old-space-size=4096" # Set NODE_OPTIONS for the script
+  javascript-package-delegator run build --npm-env-compat # Set npm_package_name and npm_package_version on pnpm, yarn, bun and deno too
+  javascript-package-delegator run build --clean-env --env-file .env.ci # Run with PATH, HOME and the env file only
+  javascript-package-delegator run test --timeout 60s # Stop the test script if it runs longer than a minute
+  javascript-package-delegator run build --shell bash # Run a script that assumes bash with bash (npm and pnpm)
+  javascript-package-delegator run build --task-runner just # Run the build recipe of the justfile with 'just build'
+  javascript-package-delegator run e2e --attempts 3 # Run a flaky e2e script up to 3 times until it passes
+  javascript-package-delegator run --parallel dev:server dev:client # Run several scripts at once
+  javascript-package-delegator run --parallel --concurrency 2 lint test build # Run at most two of them at a time
+  javascript-package-delegator run --concurrently "vite" "tsc -w" # Run shell commands at once, quote each one
+  javascript-package-delegator run build --json-logs # Log the start and end of the script as JSON lines on stderr
+  javascript-package-delegator run build --auto-install # Install first when the lockfile changed after the last install
+  javascript-package-delegator run build --capture --capture-limit 65536 # Print the first 64 KiB of the output once build exits
+  javascript-package-delegator run build --print-command # Print the command instead of running it
+  javascript-package-delegator run build --production # Run build with NODE_ENV=production
+  javascript-package-delegator run build --cascade # Run prebuild, build and postbuild on pnpm and yarn v2+ too
+  javascript-package-delegator run build -r   # Run build in every workspace package
+  javascript-package-delegator run build --silent # Only show the output of the script itself
+  javascript-package-delegator run build --before clean --after notify # Run clean, build and notify in that order
+  javascript-package-delegator run build --before clean --after notify --resume-from build # Run build and notify, skipping clean
+  javascript-package-delegator run lint -w    # Run the lint script of the workspace root from a package`,
 		Aliases: []string{"r"},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			pm, _ := cmd.Flags().GetString(AGENT_FLAG)
 			cmdRunner := getCommandRunnerFromCommandContext(cmd)
+			// Scripts that need a runner of their own get one wrapped like cmdRunner
+			newDecoratedRunner := getParallelRunnerFromCommandContext(cmd)
 
 			goEnv := getGoEnvFromCommandContext(cmd)
 			de := getDebugExecutorFromCommandContext(cmd)
 
+			// The package manager stays the one detect picked unless --task-runner names another one
+			taskRunner, err := cmd.Flags().GetString(_TASK_RUNNER_FLAG)
+			if err != nil {
+				return err
+			}
+			if cmd.Flags().Changed(_TASK_RUNNER_FLAG) {
+				if err := validateTaskRunner(taskRunner); err != nil {
+					return err
+				}
+				if !isExternalTaskRunner(taskRunner) {
+					pm = taskRunner
+				} else if conflict, found := lo.Find(taskRunnerConflicts, func(flag string) bool {
+					return cmd.Flags().Changed(flag)
+				}); found {
+					// The flag only means something to a package manager
+					return fmt.Errorf("the --%s flag cannot be combined with --%s %s", conflict, _TASK_RUNNER_FLAG, taskRunner)
+				}
+			}
+
 			// Resolve target directory from --cwd flag, fallback to current working directory
 			targetDir, err := cmd.Flags().GetString(_CWD_FLAG)
 			if err != nil {
@@ -92,6 +172,262 @@ Examples:
 				}
 			}
 
+			workspaceRoot, err := cmd.Flags().GetBool(_WORKSPACE_ROOT_FLAG)
+			if err != nil {
+				return err
+			}
+			if workspaceRoot {
+				root, err := deps.FindWorkspaceRoot(targetDir)
+				if err != nil {
+					return fmt.Errorf("the --%s flag found no workspace root from %s: %w", _WORKSPACE_ROOT_FLAG, targetDir, err)
+				}
+				if err := cmdRunner.SetTargetDir(root); err != nil {
+					return err
+				}
+				targetDir = root
+			}
+
+			list, err := cmd.Flags().GetBool(_LIST_FLAG)
+			if err != nil {
+				return err
+			}
+
+			if list {
+				asJSON, err := cmd.Flags().GetBool(_JSON_FLAG)
+				if err != nil {
+					return err
+				}
+				return listScripts(cmd.OutOrStdout(), pm, targetDir, asJSON)
+			}
+
+			listJSON, err := cmd.Flags().GetBool(_LIST_JSON_FLAG)
+			if err != nil {
+				return err
+			}
+
+			if listJSON {
+				return listScriptCommands(cmd.OutOrStdout(), pm, targetDir)
+			}
+
+			if err := checkNodeEngineIfRequested(cmd, targetDir); err != nil {
+				return err
+			}
+
+			printCommand, err := cmd.Flags().GetBool(_PRINT_COMMAND_FLAG)
+			if err != nil {
+				return err
+			}
+			if printCommand && len(args) == 0 {
+				return fmt.Errorf("the --%s flag requires a script name", _PRINT_COMMAND_FLAG)
+			}
+
+			// Printing the command doesn't run anything, so node_modules may stay stale
+			if !printCommand {
+				if err := checkLockfileFreshness(cmd, pm, targetDir); err != nil {
+					return err
+				}
+			}
+
+			envFiles, err := cmd.Flags().GetStringArray(_ENV_FILE_FLAG)
+			if err != nil {
+				return err
+			}
+			var env []string
+			if len(envFiles) > 0 {
+				env, err = readEnvFiles(envFiles)
+				if err != nil {
+					return err
+				}
+			}
+
+			cleanEnv, err := cmd.Flags().GetBool(_CLEAN_ENV_FLAG)
+			if err != nil {
+				return err
+			}
+			if cleanEnv {
+				env = withEssentialEnv(env)
+			}
+
+			nodeEnv, err := readNodeEnvMode(cmd)
+			if err != nil {
+				return err
+			}
+
+			// An env file that sets NODE_ENV wins over --production and --development
+			if nodeEnv != "" && !lo.ContainsBy(env, func(entry string) bool {
+				return strings.HasPrefix(entry, _NODE_ENV+"=")
+			}) {
+				env = append(env, _NODE_ENV+"="+nodeEnv)
+				sort.Strings(env)
+			}
+
+			nodeOptions, err := cmd.Flags().GetStringArray(_WITH_NODE_OPTIONS_FLAG)
+			if err != nil {
+				return err
+			}
+			if len(nodeOptions) > 0 {
+				inherited := lo.Ternary(cleanEnv, "", os.Getenv(_NODE_OPTIONS))
+				env = withNodeOptions(env, inherited, nodeOptions)
+			}
+
+			env, err = withNodeModulesBin(env, targetDir)
+			if err != nil {
+				return err
+			}
+
+			npmEnvCompat, err := cmd.Flags().GetBool(_NPM_ENV_COMPAT_FLAG)
+			if err != nil {
+				return err
+			}
+			if npmEnvCompat {
+				env, err = withNpmPackageEnv(env, targetDir)
+				if err != nil {
+					return err
+				}
+			}
+
+			if cleanEnv {
+				cmdRunner.ClearEnv()
+			}
+			if len(env) > 0 {
+				cmdRunner.SetEnv(env)
+			}
+
+			if isExternalTaskRunner(taskRunner) {
+				if len(args) == 0 {
+					return fmt.Errorf("the --%s flag requires a script name when it is %s", _TASK_RUNNER_FLAG, taskRunner)
+				}
+
+				runnerArgs := buildTaskRunnerArgs(taskRunner, args[0], args[1:])
+				if printCommand {
+					_, err := fmt.Fprintln(cmd.OutOrStdout(), shellJoin(append([]string{taskRunner}, runnerArgs...)))
+					return err
+				}
+
+				de.LogJSCommandIfDebugIsTrue(taskRunner, runnerArgs...)
+				goEnv.ExecuteIfModeIsProduction(func() {
+					log.Info("Running command", "runner", taskRunner, "args", strings.Join(runnerArgs, " "))
+				})
+
+				cmdRunner.Command(taskRunner, runnerArgs...)
+				if jsonLogs, _ := cmd.Flags().GetBool(_JSON_LOGS_FLAG); jsonLogs {
+					return runWithJSONLogs(cmd.ErrOrStderr(), taskRunner, runnerArgs, cmdRunner.Run)
+				}
+				return cmdRunner.Run()
+			}
+
+			silent, err := cmd.Flags().GetBool(_SILENT_FLAG)
+			if err != nil {
+				return err
+			}
+
+			shell, err := cmd.Flags().GetString(_SHELL_FLAG)
+			if err != nil {
+				return err
+			}
+			if cmd.Flags().Changed(_SHELL_FLAG) && strings.TrimSpace(shell) == "" {
+				return fmt.Errorf("the --%s flag needs the name or path of a shell", _SHELL_FLAG)
+			}
+
+			concurrently, err := cmd.Flags().GetBool(_CONCURRENTLY_FLAG)
+			if err != nil {
+				return err
+			}
+
+			if concurrently {
+				if len(args) == 0 {
+					return fmt.Errorf("the --%s flag requires at least one command", _CONCURRENTLY_FLAG)
+				}
+
+				continueOnError, err := cmd.Flags().GetBool(_CONTINUE_ON_ERROR_FLAG)
+				if err != nil {
+					return err
+				}
+
+				concurrency, err := cmd.Flags().GetInt(_CONCURRENCY_FLAG)
+				if err != nil {
+					return err
+				}
+
+				commands := lo.Map(args, func(command string, _ int) parallelScript {
+					program, shellArgs := shellCommand(command)
+					de.LogJSCommandIfDebugIsTrue(program, shellArgs...)
+					return parallelScript{name: command, program: program, args: shellArgs}
+				})
+
+				// Ctrl+C stops every command instead of leaving some of them behind
+				ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+				defer stop()
+
+				return runScriptsInParallel(
+					ctx,
+					cmd.OutOrStdout(),
+					cmd.ErrOrStderr(),
+					newDecoratedRunner,
+					targetDir,
+					env,
+					cleanEnv,
+					commands,
+					concurrency,
+					continueOnError,
+				)
+			}
+
+			parallel, err := cmd.Flags().GetBool(_PARALLEL_FLAG)
+			if err != nil {
+				return err
+			}
+
+			if parallel {
+				if printCommand {
+					return fmt.Errorf("the --%s flag cannot be combined with --%s", _PRINT_COMMAND_FLAG, _PARALLEL_FLAG)
+				}
+				if len(args) == 0 {
+					return fmt.Errorf("the --%s flag requires at least one script name", _PARALLEL_FLAG)
+				}
+				if watch, _ := cmd.Flags().GetBool(_WATCH_FLAG); watch {
+					return fmt.Errorf("the --%s flag cannot be combined with --%s", _PARALLEL_FLAG, _WATCH_FLAG)
+				}
+
+				// Every script is checked before any of them starts
+				if err := checkScriptsExist(pm, targetDir, args); err != nil {
+					return err
+				}
+
+				scripts := make([]parallelScript, 0, len(args))
+				for _, name := range args {
+					scriptArgs, err := buildRunArgs(pm, name, nil, runOptions{Silent: silent, Shell: shell})
+					if err != nil {
+						return err
+					}
+					de.LogJSCommandIfDebugIsTrue(pm, scriptArgs...)
+					scripts = append(scripts, parallelScript{name: name, program: pm, args: scriptArgs})
+				}
+
+				continueOnError, err := cmd.Flags().GetBool(_CONTINUE_ON_ERROR_FLAG)
+				if err != nil {
+					return err
+				}
+
+				concurrency, err := cmd.Flags().GetInt(_CONCURRENCY_FLAG)
+				if err != nil {
+					return err
+				}
+
+				return runScriptsInParallel(
+					cmd.Context(),
+					cmd.OutOrStdout(),
+					cmd.ErrOrStderr(),
+					newDecoratedRunner,
+					targetDir,
+					env,
+					cleanEnv,
+					scripts,
+					concurrency,
+					continueOnError,
+				)
+			}
+
 			// If no script name provided, list available scripts
 
 			var selectedPackage string
@@ -115,6 +451,9 @@ Examples:
 						)
 					}
 
+					if err := errIfQuiet(cmd, "choosing a task"); err != nil {
+						return err
+					}
 					taskSelectorUI := newTaskSelectorUI(lo.Keys(pkg.Tasks))
 
 					if err := taskSelectorUI.Run(); err != nil {
@@ -146,6 +485,9 @@ Examples:
 						return err
 					}
 
+					if err := errIfQuiet(cmd, "choosing a script"); err != nil {
+						return err
+					}
 					taskSelectorUI := newTaskSelectorUI(lo.Keys(pkg.Scripts))
 
 					if err := taskSelectorUI.Run(); err != nil {
@@ -173,14 +515,48 @@ Examples:
 				scriptArgs = args[1:]
 			}
 
+			recursive, err := cmd.Flags().GetBool(_RECURSIVE_FLAG)
+			if err != nil {
+				return err
+			}
+
+			before, after, err := readRunHooks(cmd, pm, targetDir)
+			if err != nil {
+				return err
+			}
+
+			resumeFrom, err := cmd.Flags().GetString(_RESUME_FROM_FLAG)
+			if err != nil {
+				return err
+			}
+			before, after, skipScript, err := resumeRunHooks(before, scriptName, after, resumeFrom)
+			if err != nil {
+				return err
+			}
+			if resumeFrom != "" {
+				de.LogDebugMessageIfDebugIsTrue("Resuming the sequence", "from", resumeFrom)
+			}
+
+			yarnVersion := ""
+			if pm == "yarn" {
+				if version, err := detect.DetectYarnVersion(getYarnVersionRunnerCommandContext(cmd)); err == nil {
+					yarnVersion = version
+				}
+			}
+
 			// Check if script exists when --if-present flag is used
+			// The workspace packages have their own scripts, so a recursive run leaves the check to the package manager
 			ifPresent, _ := cmd.Flags().GetBool("if-present")
-			if ifPresent {
-				pkg, err := readPackageJSONAndUnmarshalScriptsFrom(targetDir)
+			if ifPresent && !recursive {
+				// deno task fails on a missing task, so deno.json is checked the same way package.json is
+				scripts, err := readRunScripts(pm, targetDir)
 				if err != nil {
-					return err
+					return withMissingManifestHint(pm, targetDir, scriptName, err)
 				}
-				if _, exists := pkg.Scripts[scriptName]; !exists {
+				if _, exists := scripts[scriptName]; !exists {
+					if printCommand {
+						return nil
+					}
 					goEnv.ExecuteIfModeIsProduction(func() {
 						log.Info("Script not found, skipping", "script", scriptName)
 					})
@@ -188,72 +564,846 @@ Examples:
 				}
 			}
 
+			// npm and the others only report a missing script, they don't say the workspace root has it
+			if !ifPresent && !recursive {
+				if err := checkScriptOnlyInWorkspaceRoot(pm, targetDir, scriptName); err != nil {
+					return err
+				}
+			}
+
+			cascade, err := cmd.Flags().GetBool(_CASCADE_FLAG)
+			if err != nil {
+				return err
+			}
+			if cascade {
+				// Running the lifecycle scripts here as well would run them twice
+				if runsLifecycleScripts(pm, yarnVersion) {
+					if !printCommand {
+						err := printNote(
+							cmd,
+							"%s runs the pre and post scripts on its own, --%s is ignored",
+							pm, _CASCADE_FLAG,
+						)
+						if err != nil {
+							return err
+						}
+					}
+				} else {
+					return runWithHooks(cmd, pm, before, after, skipScript, runOptions{Silent: silent, Shell: shell, YarnVersion: yarnVersion}, func() error {
+						return runCascade(cmd, pm, targetDir, scriptName, scriptArgs, runOptions{Silent: silent, Shell: shell}, printCommand)
+					})
+				}
+			}
+
+			// Build command based on package manager
+			cmdArgs, err := buildRunArgs(pm, scriptName, scriptArgs, runOptions{
+				IfPresent:   ifPresent,
+				Recursive:   recursive,
+				Silent:      silent,
+				Shell:       shell,
+				YarnVersion: yarnVersion,
+			})
+			if err != nil {
+				return err
+			}
+
+			// The command is only printed, so it can be used in command substitution
+			if printCommand {
+				_, err := fmt.Fprintln(cmd.OutOrStdout(), shellJoin(append([]string{pm}, cmdArgs...)))
+				return err
+			}
+
 			goEnv.ExecuteIfModeIsProduction(func() {
 				log.Info("Using package manager", "pm", pm)
 			})
 
-			// Build command based on package manager
-			var cmdArgs []string
-			switch pm {
-			case "npm":
-				cmdArgs = []string{"run", scriptName}
-				if len(scriptArgs) > 0 {
-					cmdArgs = append(cmdArgs, "--")
-					cmdArgs = append(cmdArgs, scriptArgs...)
-				}
-				if ifPresent {
-					cmdArgs = append([]string{"run", "--if-present", scriptName}, scriptArgs...)
-				}
+			timeout, err := cmd.Flags().GetDuration(_TIMEOUT_FLAG)
+			if err != nil {
+				return err
+			}
+			if timeout < 0 {
+				return fmt.Errorf("the --%s flag must not be negative, got: %s", _TIMEOUT_FLAG, timeout)
+			}
 
-			case "yarn":
-				cmdArgs = []string{"run", scriptName}
-				cmdArgs = append(cmdArgs, scriptArgs...)
+			attempts, err := cmd.Flags().GetInt(_ATTEMPTS_FLAG)
+			if err != nil {
+				return err
+			}
+			if attempts < 1 {
+				return fmt.Errorf("the --%s flag must be at least 1, got: %d", _ATTEMPTS_FLAG, attempts)
+			}
 
-			case "pnpm":
-				cmdArgs = []string{"run", scriptName}
-				if len(scriptArgs) > 0 {
-					cmdArgs = append(cmdArgs, "--")
-					cmdArgs = append(cmdArgs, scriptArgs...)
-				}
-				if ifPresent {
-					cmdArgs = append([]string{"run", "--if-present", scriptName}, scriptArgs...)
+			jsonLogs, err := cmd.Flags().GetBool(_JSON_LOGS_FLAG)
+			if err != nil {
+				return err
+			}
+
+			capture, err := cmd.Flags().GetBool(_CAPTURE_FLAG)
+			if err != nil {
+				return err
+			}
+			captureLimit, err := cmd.Flags().GetInt(_CAPTURE_LIMIT_FLAG)
+			if err != nil {
+				return err
+			}
+			if cmd.Flags().Changed(_CAPTURE_LIMIT_FLAG) && !capture {
+				return fmt.Errorf("the --%s flag requires --%s", _CAPTURE_LIMIT_FLAG, _CAPTURE_FLAG)
+			}
+			if captureLimit <= 0 {
+				return fmt.Errorf("the --%s flag must be greater than 0, got: %d", _CAPTURE_LIMIT_FLAG, captureLimit)
+			}
+
+			runScriptOnce := func() error {
+				de.LogJSCommandIfDebugIsTrue(pm, cmdArgs...)
+
+				goEnv.ExecuteIfModeIsProduction(func() {
+					log.Info("Running command", "pm", pm, "args", strings.Join(cmdArgs, " "))
+				})
+
+				// A captured script writes into a buffer instead of the terminal, so it needs a runner of its own
+				if capture {
+					output, dropped, err := CaptureScript(
+						cmd.Context(),
+						newDecoratedRunner,
+						targetDir,
+						env,
+						cleanEnv,
+						timeout,
+						captureLimit,
+						pm,
+						cmdArgs,
+					)
+					if printErr := printCapturedOutput(cmd.OutOrStdout(), output, dropped, captureLimit); printErr != nil {
+						return printErr
+					}
+					return withMissingManifestHint(pm, targetDir, scriptName, err)
 				}
 
-			case "bun":
-				cmdArgs = []string{"run", scriptName}
-				cmdArgs = append(cmdArgs, scriptArgs...)
+				// A timed script needs a runner bound to a deadline, the one --parallel starts its scripts with
+				if timeout > 0 {
+					err := runScriptWithTimeout(
+						cmd.Context(),
+						cmd.OutOrStdout(),
+						cmd.ErrOrStderr(),
+						newDecoratedRunner,
+						targetDir,
+						env,
+						cleanEnv,
+						timeout,
+						pm,
+						cmdArgs,
+					)
+					return withMissingManifestHint(pm, targetDir, scriptName, err)
+				}
 
-			case "deno":
-				cmdArgs = []string{"task", scriptName}
+				cmdRunner.Command(pm, cmdArgs...)
+				return withMissingManifestHint(pm, targetDir, scriptName, cmdRunner.Run())
+			}
 
-				if lo.Contains(scriptArgs, "--eval") {
-					return fmt.Errorf("don't pass --eval here use the exec command instead")
+			if jsonLogs {
+				runScriptUnlogged := runScriptOnce
+				runScriptOnce = func() error {
+					return runWithJSONLogs(cmd.ErrOrStderr(), pm, cmdArgs, runScriptUnlogged)
 				}
+			}
 
-				cmdArgs = append(cmdArgs, scriptArgs...)
+			// Only the script is run again, the --before and --after scripts run once
+			runScript := func() error {
+				return runAttempts(cmd, attempts, runScriptOnce)
+			}
 
-			default:
-				return fmt.Errorf("unsupported package manager: %s", pm)
+			watchFlag, err := cmd.Flags().GetBool(_WATCH_FLAG)
+			if err != nil {
+				return err
 			}
 
-			// Execute the command
-			cmdRunner.Command(pm, cmdArgs...)
-			de.LogJSCommandIfDebugIsTrue(pm, cmdArgs...)
+			if !watchFlag {
+				return runWithHooks(cmd, pm, before, after, skipScript, runOptions{Silent: silent, Shell: shell, YarnVersion: yarnVersion}, runScript)
+			}
 
-			goEnv.ExecuteIfModeIsProduction(func() {
-				log.Info("Running command", "pm", pm, "args", strings.Join(cmdArgs, " "))
-			})
+			watcher, err := newFileWatcher(targetDir)
+			if err != nil {
+				return fmt.Errorf("failed to watch %s: %w", targetDir, err)
+			}
+			defer func() { _ = watcher.Close() }()
+
+			// Stop watching on Ctrl+C instead of leaving the loop behind
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
 
-			return cmdRunner.Run()
+			return watchAndRun(ctx, cmd.OutOrStdout(), watcher, targetDir, scriptName, runScript)
 		},
 	}
 
 	// Add flags
 	cmd.Flags().Bool("if-present", false, "Run script only if it exists")
+	cmd.Flags().Bool(_LIST_FLAG, false, "Print the available scripts without running one")
+	cmd.Flags().Bool(_JSON_FLAG, false, "Print the --list output as JSON")
+	cmd.Flags().Bool(_LIST_JSON_FLAG, false, "Print the scripts as JSON with the command jpd runs for each of them")
+	cmd.MarkFlagsMutuallyExclusive(_LIST_FLAG, _LIST_JSON_FLAG)
+	cmd.Flags().Bool(_WATCH_FLAG, false, "Run the script again whenever a file changes")
+	cmd.Flags().StringArray(_ENV_FILE_FLAG, nil, "Load environment variables from a dotenv file (repeatable, later files win)")
+	cmd.Flags().Bool(_CLEAN_ENV_FLAG, false, "Run the script with PATH and a few essentials instead of the whole environment, --env-file adds to them")
+	cmd.Flags().StringArray(_WITH_NODE_OPTIONS_FLAG, nil, "Add options to NODE_OPTIONS for the script (repeatable, joined with spaces)")
+	cmd.Flags().Bool(_NPM_ENV_COMPAT_FLAG, false, "Give the script the npm_package_name, npm_package_version and npm_package_json variables npm sets, whatever the package manager")
+	cmd.Flags().Bool(_PARALLEL_FLAG, false, "Run every named script at the same time, prefixing their output with the script name")
+	cmd.Flags().Bool(_CONCURRENTLY_FLAG, false, "Run every argument as a shell command at the same time, prefixing their output with the command")
+	cmd.Flags().Bool(_CONTINUE_ON_ERROR_FLAG, false, "Keep the other --parallel scripts running when one of them fails")
+	cmd.Flags().Int(_CONCURRENCY_FLAG, runtime.NumCPU(), "Run at most n --parallel scripts at the same time")
+	cmd.Flags().Duration(_TIMEOUT_FLAG, 0, "Kill the script when it runs longer than this duration, the --before scripts are not timed (e.g. 60s, 5m)")
+	cmd.MarkFlagsMutuallyExclusive(_TIMEOUT_FLAG, _PARALLEL_FLAG)
+	cmd.Flags().Int(_ATTEMPTS_FLAG, 1, "Run a failing script again until it passes, at most n times in total (for flaky scripts in CI)")
+	cmd.MarkFlagsMutuallyExclusive(_ATTEMPTS_FLAG, _PARALLEL_FLAG)
+	cmd.Flags().Bool(_PRINT_COMMAND_FLAG, false, "Print the resolved command without running it")
+	cmd.Flags().Bool(_AUTO_INSTALL_FLAG, false, "Install first when the lockfile changed after the last install instead of only warning")
+	cmd.Flags().Bool(_JSON_LOGS_FLAG, false, "Write a JSON line with the command, times and exit code to stderr before and after the script")
+	cmd.MarkFlagsMutuallyExclusive(_JSON_LOGS_FLAG, _PARALLEL_FLAG)
+	cmd.MarkFlagsMutuallyExclusive(_JSON_LOGS_FLAG, _PRINT_COMMAND_FLAG)
+	cmd.Flags().Bool(_PRODUCTION_FLAG, false, "Run the script with NODE_ENV=production")
+	cmd.Flags().Bool(_DEVELOPMENT_FLAG, false, "Run the script with NODE_ENV=development")
+	cmd.MarkFlagsMutuallyExclusive(_PRODUCTION_FLAG, _DEVELOPMENT_FLAG)
+	cmd.Flags().Bool(_CASCADE_FLAG, false, "Run the pre and post scripts of the script around it like npm does")
+	cmd.MarkFlagsMutuallyExclusive(_CASCADE_FLAG, _PARALLEL_FLAG)
+	cmd.MarkFlagsMutuallyExclusive(_CASCADE_FLAG, _WATCH_FLAG)
+	cmd.MarkFlagsMutuallyExclusive(_CASCADE_FLAG, _TIMEOUT_FLAG)
+	cmd.MarkFlagsMutuallyExclusive(_CASCADE_FLAG, _ATTEMPTS_FLAG)
+	cmd.Flags().BoolP(_RECURSIVE_FLAG, "r", false, "Run the script in every workspace package (pnpm -r, yarn v2+ workspaces foreach, npm --workspaces, bun --filter '*')")
+	cmd.MarkFlagsMutuallyExclusive(_RECURSIVE_FLAG, _PARALLEL_FLAG)
+	cmd.MarkFlagsMutuallyExclusive(_RECURSIVE_FLAG, _CASCADE_FLAG)
+	cmd.Flags().String(_SHELL_FLAG, "", "Run the script under this shell (npm --script-shell, pnpm --config.script-shell)")
+	cmd.Flags().String(_TASK_RUNNER_FLAG, "", "Run the script with npm, deno, just or task instead of the detected package manager")
+	cmd.Flags().Bool(_SILENT_FLAG, false, "Hide the package manager's own output around the script (deno task --quiet)")
+	cmd.Flags().BoolP(_WORKSPACE_ROOT_FLAG, "w", false, "Run the script from the workspace root of the monorepo")
+	cmd.Flags().StringArray(_BEFORE_FLAG, nil, "Run this script before the script (repeatable, runs in the order given)")
+	cmd.Flags().StringArray(_AFTER_FLAG, nil, "Run this script after the script succeeds (repeatable, runs in the order given)")
+	cmd.Flags().String(_RESUME_FROM_FLAG, "", "Start the --before, script, --after sequence at this script, skipping the ones before it")
+	for _, hook := range []string{_BEFORE_FLAG, _AFTER_FLAG, _RESUME_FROM_FLAG} {
+		for _, flag := range []string{_PARALLEL_FLAG, _WATCH_FLAG, _PRINT_COMMAND_FLAG} {
+			cmd.MarkFlagsMutuallyExclusive(hook, flag)
+		}
+	}
+	cmd.Flags().Bool(_CAPTURE_FLAG, false, "Keep the output of the script and print it once the script exits instead of streaming it")
+	cmd.Flags().Int(_CAPTURE_LIMIT_FLAG, defaultCaptureLimit, "Keep at most n bytes of --capture output, the rest is dropped with a notice")
+	for _, flag := range []string{_PARALLEL_FLAG, _WATCH_FLAG, _PRINT_COMMAND_FLAG, _CASCADE_FLAG} {
+		cmd.MarkFlagsMutuallyExclusive(_CAPTURE_FLAG, flag)
+	}
+	for _, flag := range concurrentlyConflicts {
+		cmd.MarkFlagsMutuallyExclusive(_CONCURRENTLY_FLAG, flag)
+	}
+	addEngineCheckFlag(cmd)
 
 	return cmd
 }
 
+// runOptions holds the run flags that change the argv of the package manager.
+type runOptions struct {
+	IfPresent   bool
+	Recursive   bool
+	Silent      bool
+	Shell       string
+	YarnVersion string
+}
+
+// insertAfter returns args with flag inserted after the first argument equal to target.
+func insertAfter(args []string, target, flag string) []string {
+	i := lo.IndexOf(args, target)
+	return lo.Splice(args, i+1, flag)
+}
+
+// buildRunArgs builds the arguments that make pm run scriptName with scriptArgs.
+func buildRunArgs(pm, scriptName string, scriptArgs []string, opts runOptions) ([]string, error) {
+	var cmdArgs []string
+	switch pm {
+	case "npm":
+		cmdArgs = []string{"run", scriptName}
+		if len(scriptArgs) > 0 {
+			cmdArgs = append(cmdArgs, "--")
+			cmdArgs = append(cmdArgs, scriptArgs...)
+		}
+		if opts.IfPresent {
+			cmdArgs = append([]string{"run", "--if-present", scriptName}, scriptArgs...)
+		}
+		if opts.Recursive {
+			cmdArgs = append([]string{"--workspaces"}, cmdArgs...)
+		}
+		if opts.Silent {
+			cmdArgs = insertAfter(cmdArgs, scriptName, "--silent")
+		}
+		if opts.Shell != "" {
+			cmdArgs = lo.Splice(cmdArgs, lo.IndexOf(cmdArgs, scriptName)+1, "--script-shell", opts.Shell)
+		}
+
+	case "yarn":
+		cmdArgs = []string{"run", scriptName}
+		cmdArgs = append(cmdArgs, scriptArgs...)
+		if opts.Recursive {
+			if ParseYarnMajor(opts.YarnVersion) < 2 {
+				return nil, fmt.Errorf(
+					"the --%s flag requires yarn v2 or newer, yarn v1 runs a script in every workspace with 'yarn workspaces run %s'",
+					_RECURSIVE_FLAG, scriptName,
+				)
+			}
+			// Yarn v4 refuses foreach without a selection of workspaces
+			cmdArgs = append([]string{"workspaces", "foreach", "--all"}, cmdArgs...)
+		}
+		if opts.Silent {
+			cmdArgs = append([]string{"--silent"}, cmdArgs...)
+		}
+		if opts.Shell != "" {
+			return nil, fmt.Errorf(
+				"yarn has no --%s flag, yarn v1 reads script-shell from .yarnrc ('yarn config set script-shell %s') and yarn v2+ always runs scripts with its own shell",
+				_SHELL_FLAG, opts.Shell,
+			)
+		}
+
+	case "pnpm":
+		cmdArgs = []string{"run", scriptName}
+		if len(scriptArgs) > 0 {
+			cmdArgs = append(cmdArgs, "--")
+			cmdArgs = append(cmdArgs, scriptArgs...)
+		}
+		if opts.IfPresent {
+			cmdArgs = append([]string{"run", "--if-present", scriptName}, scriptArgs...)
+		}
+		if opts.Recursive {
+			cmdArgs = append([]string{"-r"}, cmdArgs...)
+		}
+		if opts.Silent {
+			cmdArgs = insertAfter(cmdArgs, "run", "--silent")
+		}
+		if opts.Shell != "" {
+			cmdArgs = insertAfter(cmdArgs, "run", "--config.script-shell="+opts.Shell)
+		}
+
+	case "bun":
+		cmdArgs = []string{"run", scriptName}
+		cmdArgs = append(cmdArgs, scriptArgs...)
+		if opts.Recursive {
+			cmdArgs = append([]string{"--filter", "*"}, cmdArgs...)
+		}
+		if opts.Silent {
+			cmdArgs = insertAfter(cmdArgs, "run", "--silent")
+		}
+		if opts.Shell != "" {
+			return nil, fmt.Errorf("bun runs scripts with its own shell, it has no --%s flag", _SHELL_FLAG)
+		}
+
+	case "deno":
+		if opts.Recursive {
+			return nil, fmt.Errorf("deno does not support the --%s flag", _RECURSIVE_FLAG)
+		}
+		if opts.Shell != "" {
+			return nil, fmt.Errorf("deno task runs tasks with its own shell, it has no --%s flag", _SHELL_FLAG)
+		}
+
+		cmdArgs = []string{"task", scriptName}
+		if opts.Silent {
+			cmdArgs = []string{"task", "--quiet", scriptName}
+		}
+
+		// deno task forwards everything after the task name, so the separator npm needs would reach the task
+		if len(scriptArgs) > 0 && scriptArgs[0] == "--" {
+			scriptArgs = scriptArgs[1:]
+		}
+
+		if lo.ContainsBy(scriptArgs, func(arg string) bool {
+			return arg == "--eval" || strings.HasPrefix(arg, "--eval=")
+		}) {
+			return nil, fmt.Errorf("don't pass --eval here use the exec command instead")
+		}
+
+		cmdArgs = append(cmdArgs, scriptArgs...)
+
+	default:
+		return nil, unsupportedPackageManagerError(pm)
+	}
+
+	return cmdArgs, nil
+}
+
+const (
+	_LIST_FLAG          = "list"
+	_WATCH_FLAG         = "watch"
+	_ENV_FILE_FLAG      = "env-file"
+	_PRINT_COMMAND_FLAG = "print-command"
+	_DEVELOPMENT_FLAG   = "development"
+	_NODE_ENV           = "NODE_ENV"
+	_NODE_OPTIONS       = "NODE_OPTIONS"
+	_PATH_ENV           = "PATH"
+	_CASCADE_FLAG       = "cascade"
+	_RECURSIVE_FLAG     = "recursive"
+	_SILENT_FLAG        = "silent"
+	_SHELL_FLAG         = "shell"
+	_LIST_JSON_FLAG     = "list-json"
+
+	_WITH_NODE_OPTIONS_FLAG = "with-node-options"
+	_CLEAN_ENV_FLAG         = "clean-env"
+	_NPM_ENV_COMPAT_FLAG    = "npm-env-compat"
+)
+
+// essentialEnvVariables are the variables --clean-env keeps, what shells and package managers need to work.
+var essentialEnvVariables = []string{
+	"PATH", "HOME", "USER", "LOGNAME", "SHELL", "TERM", "LANG", "TMPDIR",
+	// Windows
+	"SYSTEMROOT", "COMSPEC", "PATHEXT", "TEMP", "TMP", "USERPROFILE", "APPDATA", "LOCALAPPDATA",
+}
+
+// readRunScripts reads the scripts pm can run in targetDir, the tasks of deno.json for deno
+// and the scripts of package.json for the others.
+func readRunScripts(pm, targetDir string) (map[string]string, error) {
+	if pm == "deno" {
+		denoJSON, err := readDenoJSONFrom(targetDir)
+		if err != nil {
+			return nil, err
+		}
+		return denoJSON.Tasks, nil
+	}
+
+	pkg, err := readPackageJSONAndUnmarshalScriptsFrom(targetDir)
+	if err != nil {
+		return nil, err
+	}
+	return pkg.Scripts, nil
+}
+
+// withMissingManifestHint points at jpd exec when running name in targetDir failed and targetDir has no manifest,
+// name is then more likely a binary than a script.
+func withMissingManifestHint(pm, targetDir, name string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	manifests := []string{"package.json"}
+	if pm == "deno" {
+		manifests = []string{detect.DENO_JSON, detect.DENO_JSONC}
+	}
+	for _, manifest := range manifests {
+		if _, statErr := os.Stat(filepath.Join(targetDir, manifest)); statErr == nil {
+			return err
+		}
+	}
+
+	return fmt.Errorf("no %s found; did you mean `jpd exec %s`?: %w", manifests[0], name, err)
+}
+
+// checkScriptOnlyInWorkspaceRoot returns an error pointing at -w when scriptName is missing from the
+// manifest in targetDir but defined by the workspace root above it. Every other case, including
+// manifests that can't be read, is left to the package manager.
+func checkScriptOnlyInWorkspaceRoot(pm, targetDir, scriptName string) error {
+	scripts, err := readRunScripts(pm, targetDir)
+	if err != nil {
+		return nil
+	}
+	if _, exists := scripts[scriptName]; exists {
+		return nil
+	}
+
+	dir, err := filepath.Abs(targetDir)
+	if err != nil {
+		return nil
+	}
+	root, err := deps.FindWorkspaceRoot(dir)
+	if err != nil || root == dir {
+		return nil
+	}
+
+	rootScripts, err := readRunScripts(pm, root)
+	if err != nil {
+		return nil
+	}
+	if _, exists := rootScripts[scriptName]; !exists {
+		return nil
+	}
+
+	return fmt.Errorf(
+		"script '%s' not found here; it exists in the workspace root %s, run it with -w/--%s",
+		scriptName, root, _WORKSPACE_ROOT_FLAG,
+	)
+}
+
+// cascadeScripts returns pre<scriptName>, scriptName and post<scriptName>
+// in the order they run, leaving out the lifecycle scripts scripts doesn't have.
+func cascadeScripts(scriptName string, scripts map[string]string) []string {
+	return lo.Filter([]string{"pre" + scriptName, scriptName, "post" + scriptName}, func(name string, _ int) bool {
+		_, exists := scripts[name]
+		return name == scriptName || exists
+	})
+}
+
+// runCascade runs pre<scriptName>, scriptName with scriptArgs and post<scriptName> one after the other,
+// stopping at the first one that fails. Every script is built with opts
+// and with printCommand the commands are only printed.
+func runCascade(cmd *cobra.Command, pm, targetDir, scriptName string, scriptArgs []string, opts runOptions, printCommand bool) error {
+	cmdRunner := getCommandRunnerFromCommandContext(cmd)
+	goEnv := getGoEnvFromCommandContext(cmd)
+	de := getDebugExecutorFromCommandContext(cmd)
+
+	scripts, err := readRunScripts(pm, targetDir)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range cascadeScripts(scriptName, scripts) {
+		// Only the script itself receives the arguments, like npm does
+		cmdArgs, err := buildRunArgs(pm, name, lo.Ternary(name == scriptName, scriptArgs, nil), opts)
+		if err != nil {
+			return err
+		}
+
+		if printCommand {
+			if _, err := fmt.Fprintln(cmd.OutOrStdout(), shellJoin(append([]string{pm}, cmdArgs...))); err != nil {
+				return err
+			}
+			continue
+		}
+
+		cmdRunner.Command(pm, cmdArgs...)
+		de.LogJSCommandIfDebugIsTrue(pm, cmdArgs...)
+
+		goEnv.ExecuteIfModeIsProduction(func() {
+			log.Info("Running command", "pm", pm, "args", strings.Join(cmdArgs, " "))
+		})
+
+		if err := cmdRunner.Run(); err != nil {
+			return fmt.Errorf("the %s script failed: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// runsLifecycleScripts reports whether pm runs the pre and post scripts of a script on its own.
+func runsLifecycleScripts(pm, yarnVersion string) bool {
+	switch pm {
+	case "npm", "bun":
+		return true
+	case "yarn":
+		return ParseYarnMajor(yarnVersion) < 2
+	default:
+		return false
+	}
+}
+
+// withEssentialEnv returns env with the essential variables of JPD's own environment added,
+// the entries env already has win over them.
+func withEssentialEnv(env []string) []string {
+	for _, name := range essentialEnvVariables {
+		value, ok := os.LookupEnv(name)
+		if !ok || lo.ContainsBy(env, func(entry string) bool {
+			return strings.HasPrefix(entry, name+"=")
+		}) {
+			continue
+		}
+		env = append(env, name+"="+value)
+	}
+	sort.Strings(env)
+	return env
+}
+
+// withNodeModulesBin returns env with the node_modules/.bin directory of targetDir put in front of PATH,
+// so every package manager and the scripts jpd starts itself find the binaries of the project.
+// env is returned as is when the project has no node_modules/.bin yet.
+func withNodeModulesBin(env []string, targetDir string) ([]string, error) {
+	binDir, err := filepath.Abs(filepath.Join(targetDir, "node_modules", ".bin"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve node_modules/.bin: %w", err)
+	}
+	if info, err := os.Stat(binDir); err != nil || !info.IsDir() {
+		return env, nil
+	}
+
+	prefix := _PATH_ENV + "="
+	_, i, found := lo.FindIndexOf(env, func(entry string) bool {
+		return strings.HasPrefix(entry, prefix)
+	})
+	path := os.Getenv(_PATH_ENV)
+	if found {
+		path = strings.TrimPrefix(env[i], prefix)
+	}
+
+	if lo.Contains(filepath.SplitList(path), binDir) {
+		return env, nil
+	}
+	value := prefix + lo.Ternary(path == "", binDir, binDir+string(os.PathListSeparator)+path)
+
+	if found {
+		env[i] = value
+		return env, nil
+	}
+
+	env = append(env, value)
+	sort.Strings(env)
+	return env, nil
+}
+
+// withNodeOptions returns env with options added to NODE_OPTIONS, separated by spaces.
+// They come after the options an env file or inherited already sets, so node reads them last.
+func withNodeOptions(env []string, inherited string, options []string) []string {
+	prefix := _NODE_OPTIONS + "="
+
+	existing := inherited
+	_, i, found := lo.FindIndexOf(env, func(entry string) bool {
+		return strings.HasPrefix(entry, prefix)
+	})
+	if found {
+		existing = strings.TrimPrefix(env[i], prefix)
+	}
+
+	value := strings.Join(lo.Compact(lo.Map(append([]string{existing}, options...), func(option string, _ int) string {
+		return strings.TrimSpace(option)
+	})), " ")
+
+	if found {
+		env[i] = prefix + value
+		return env
+	}
+
+	env = append(env, prefix+value)
+	sort.Strings(env)
+	return env
+}
+
+// withNpmPackageEnv returns env with the npm_package_* variables npm gives its scripts, read from the package.json of targetDir.
+// The entries env already has win over them, an empty name or version is left out like npm does.
+func withNpmPackageEnv(env []string, targetDir string) ([]string, error) {
+	manifestPath, err := filepath.Abs(filepath.Join(targetDir, "package.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve package.json: %w", err)
+	}
+
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("the --%s flag needs a package.json: %w", _NPM_ENV_COMPAT_FLAG, err)
+	}
+
+	var manifest struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse package.json: %w", err)
+	}
+
+	variables := lo.PickBy(map[string]string{
+		"npm_package_name":    manifest.Name,
+		"npm_package_version": manifest.Version,
+		"npm_package_json":    manifestPath,
+	}, func(_ string, value string) bool {
+		return value != ""
+	})
+
+	for name, value := range variables {
+		if lo.ContainsBy(env, func(entry string) bool {
+			return strings.HasPrefix(entry, name+"=")
+		}) {
+			continue
+		}
+		env = append(env, name+"="+value)
+	}
+	sort.Strings(env)
+	return env, nil
+}
+
+// readNodeEnvMode returns the NODE_ENV value requested with --production or --development.
+func readNodeEnvMode(cmd *cobra.Command) (string, error) {
+	production, err := cmd.Flags().GetBool(_PRODUCTION_FLAG)
+	if err != nil {
+		return "", err
+	}
+	if production {
+		return "production", nil
+	}
+
+	development, err := cmd.Flags().GetBool(_DEVELOPMENT_FLAG)
+	if err != nil {
+		return "", err
+	}
+	if development {
+		return "development", nil
+	}
+
+	return "", nil
+}
+
+// shellJoin joins argv into one line a POSIX shell splits back into the same arguments.
+func shellJoin(argv []string) string {
+	return strings.Join(lo.Map(argv, func(arg string, _ int) string {
+		if arg != "" && !strings.ContainsFunc(arg, func(r rune) bool {
+			return !strings.ContainsRune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789_@%+=:,./-", r)
+		}) {
+			return arg
+		}
+		return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+	}), " ")
+}
+
+// readEnvFiles parses dotenv files in order and returns their variables as sorted KEY=VALUE entries.
+// A variable set by a later file replaces the one from an earlier file.
+func readEnvFiles(paths []string) ([]string, error) {
+	vars := map[string]string{}
+
+	for _, path := range paths {
+		fileVars, err := godotenv.Read(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read env file %s: %w", path, err)
+		}
+
+		for key, value := range fileVars {
+			vars[key] = value
+		}
+	}
+
+	env := lo.MapToSlice(vars, func(key, value string) string {
+		return key + "=" + value
+	})
+	sort.Strings(env)
+
+	return env, nil
+}
+
+// watchDebounce is how long the files must stay unchanged before the script runs again.
+var watchDebounce = 300 * time.Millisecond
+
+// watchAndRun runs the script, then runs it again every time the watcher reports a change.
+// Bursts of changes are collapsed into one run and failures don't stop the loop.
+// Changes made while the script runs, like its build output, don't start another run.
+// It returns when ctx is cancelled or the watcher stops.
+func watchAndRun(ctx context.Context, w io.Writer, watcher FileWatcher, root, scriptName string, run func() error) error {
+	runAndReport := func() {
+		if err := run(); err != nil {
+			_, _ = fmt.Fprintf(w, "Note: %s failed: %v, waiting for changes\n", scriptName, err)
+		}
+		watcher.Reset()
+	}
+
+	rerun := func() {
+		_, _ = fmt.Fprintf(w, "Change detected, running %s again\n", scriptName)
+		runAndReport()
+	}
+
+	runAndReport()
+
+	var settled <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case path, ok := <-watcher.Events():
+			if !ok {
+				if settled != nil {
+					rerun()
+				}
+				return nil
+			}
+
+			if isIgnoredWatchPath(root, path) {
+				continue
+			}
+
+			settled = time.After(watchDebounce)
+
+		case <-settled:
+			settled = nil
+			rerun()
+		}
+	}
+}
+
+// isIgnoredWatchPath reports whether path lives in a directory that watch mode ignores.
+func isIgnoredWatchPath(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		rel = path
+	}
+
+	return lo.SomeBy(strings.Split(filepath.ToSlash(rel), "/"), func(part string) bool {
+		return lo.Contains(watch.IgnoredDirs, part)
+	})
+}
+
+// listScripts prints the scripts of package.json, or the tasks of deno.json for deno,
+// as "name: command" lines sorted by name.
+func listScripts(w io.Writer, pm, targetDir string, asJSON bool) error {
+	var (
+		scripts  map[string]string
+		manifest string
+	)
+
+	if pm == "deno" {
+		pkg, err := readDenoJSONFrom(targetDir)
+		if err != nil {
+			return err
+		}
+		scripts, manifest = pkg.Tasks, "deno.json"
+	} else {
+		pkg, err := readPackageJSONAndUnmarshalScriptsFrom(targetDir)
+		if err != nil {
+			return err
+		}
+		scripts, manifest = pkg.Scripts, "package.json"
+	}
+
+	if asJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(lo.Ternary(scripts != nil, scripts, map[string]string{}))
+	}
+
+	if len(scripts) == 0 {
+		_, err := fmt.Fprintf(w, "No scripts found in %s\n", manifest)
+		return err
+	}
+
+	names := lo.Keys(scripts)
+	sort.Strings(names)
+
+	for _, name := range names {
+		if _, err := fmt.Fprintf(w, "%s: %s\n", name, scripts[name]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RunScriptCommand is a script printed by run --list-json along with the command jpd runs for it.
+type RunScriptCommand struct {
+	Name    string `json:"name"`
+	Script  string `json:"script"`
+	Command string `json:"command"`
+}
+
+// listScriptCommands prints the scripts of package.json, or the tasks of deno.json for deno,
+// as a JSON array sorted by name. Each entry holds the command jpd runs for the script.
+func listScriptCommands(w io.Writer, pm, targetDir string) error {
+	scripts, err := readRunScripts(pm, targetDir)
+	if err != nil {
+		return err
+	}
+
+	names := lo.Keys(scripts)
+	sort.Strings(names)
+
+	entries := make([]RunScriptCommand, 0, len(names))
+	for _, name := range names {
+		cmdArgs, err := buildRunArgs(pm, name, nil, runOptions{})
+		if err != nil {
+			return err
+		}
+
+		entries = append(entries, RunScriptCommand{
+			Name:    name,
+			Script:  scripts[name],
+			Command: shellJoin(append([]string{pm}, cmdArgs...)),
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(entries)
+}
+
 type PackageJSONScripts struct {
 	Scripts map[string]string `json:"scripts"`
 }
@@ -278,17 +1428,23 @@ type DenoJSON struct {
 	Tasks map[string]string `json:"tasks"`
 }
 
-// readDenoJSONFrom reads deno.json from the specified directory
+// readDenoJSONFrom reads deno.json from the specified directory,
+// falling back to deno.jsonc with its comments stripped when deno.json doesn't exist.
 func readDenoJSONFrom(baseDir string) (*DenoJSON, error) {
-	denoJSONPath := filepath.Join(baseDir, "deno.json")
-	data, err := os.ReadFile(denoJSONPath)
+	manifest := detect.DENO_JSON
+	data, err := os.ReadFile(filepath.Join(baseDir, detect.DENO_JSON))
+	if errors.Is(err, fs.ErrNotExist) {
+		if jsoncData, jsoncErr := os.ReadFile(filepath.Join(baseDir, detect.DENO_JSONC)); jsoncErr == nil {
+			manifest, data, err = detect.DENO_JSONC, deps.NormalizeJSONCToJSON(jsoncData), nil
+		}
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to read deno.json: %w", err)
 	}
 
 	var pkg DenoJSON
 	if err := json.Unmarshal(data, &pkg); err != nil {
-		return nil, fmt.Errorf("failed to parse deno.json: %w", err)
+		return nil, fmt.Errorf("failed to parse %s: %w", manifest, err)
 	}
 
 	return &pkg, nil
@@ -315,6 +1471,34 @@ func ParsePackageNames(depWithVersions []string) []string {
 	return names
 }
 
+// DedupePackageSpecs collapses the specs that name the same package into one, in the place of the first of them.
+// A spec with a version wins over a bare name, the last version wins over earlier ones.
+// Specs whose version holds a ':' or '/' (git URLs, aliases) are kept as they are.
+func DedupePackageSpecs(specs []string) []string {
+	names := ParsePackageNames(specs)
+	deduped := make([]string, 0, len(specs))
+	positions := map[string]int{}
+	for i, spec := range specs {
+		name := names[i]
+		version := strings.TrimPrefix(spec, name)
+		if strings.ContainsAny(version, ":/") {
+			deduped = append(deduped, spec)
+			continue
+		}
+
+		position, seen := positions[name]
+		if !seen {
+			positions[name] = len(deduped)
+			deduped = append(deduped, spec)
+			continue
+		}
+		if version != "" {
+			deduped[position] = spec
+		}
+	}
+	return deduped
+}
+
 // IsYarnPnpProject checks if the current directory is a Yarn PnP project
 // by looking for .pnp.cjs or .pnp.data.json files.
 func IsYarnPnpProject(cwd string) bool {
@@ -0,0 +1,94 @@
+// Package cmd provides command-line interface implementations for the JavaScript package delegator.
+package cmd
+
+import (
+	// standard library
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+const (
+	_CAPTURE_FLAG       = "capture"
+	_CAPTURE_LIMIT_FLAG = "capture-limit"
+)
+
+// defaultCaptureLimit is how many bytes of output run --capture keeps unless --capture-limit says otherwise.
+const defaultCaptureLimit = 1 << 20
+
+// cappedBuffer keeps the first limit bytes written to it and counts the ones it drops.
+// stdout and stderr of the script share it, so it is safe to write to from both.
+type cappedBuffer struct {
+	mu      sync.Mutex
+	buf     bytes.Buffer
+	limit   int
+	dropped int
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	kept := min(len(p), b.limit-b.buf.Len())
+	b.buf.Write(p[:kept])
+	b.dropped += len(p) - kept
+
+	// The script must not fail because jpd stopped keeping its output
+	return len(p), nil
+}
+
+// CaptureScript runs pm with args on a runner of its own and returns what it wrote to stdout and stderr,
+// in the order it was written. Only the first limit bytes are kept, dropped counts the rest.
+// A timeout of 0 lets the script run as long as it needs.
+func CaptureScript(
+	ctx context.Context,
+	newRunner ParallelCommandRunnerFactory,
+	targetDir string,
+	env []string,
+	cleanEnv bool,
+	timeout time.Duration,
+	limit int,
+	pm string,
+	args []string,
+) (output []byte, dropped int, err error) {
+	if limit <= 0 {
+		return nil, 0, fmt.Errorf("the capture limit must be greater than 0, got: %d", limit)
+	}
+
+	captured := &cappedBuffer{limit: limit}
+
+	if timeout > 0 {
+		err = runScriptWithTimeout(ctx, captured, captured, newRunner, targetDir, env, cleanEnv, timeout, pm, args)
+	} else {
+		var runner CommandRunner
+		runner, err = newScriptRunner(ctx, captured, captured, newRunner, targetDir, env, cleanEnv, pm, args)
+		if err != nil {
+			return nil, 0, err
+		}
+		err = runner.Run()
+	}
+
+	return captured.buf.Bytes(), captured.dropped, err
+}
+
+// printCapturedOutput writes the output run --capture kept to w,
+// followed by a notice saying how much was dropped once it passed limit.
+func printCapturedOutput(w io.Writer, output []byte, dropped, limit int) error {
+	if _, err := w.Write(output); err != nil {
+		return err
+	}
+	if dropped == 0 {
+		return nil
+	}
+
+	if len(output) > 0 && output[len(output)-1] != '\n' {
+		if _, err := fmt.Fprintln(w); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "[output truncated after %d bytes, %d more bytes were dropped]\n", limit, dropped)
+	return err
+}
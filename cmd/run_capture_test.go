@@ -0,0 +1,118 @@
+package cmd_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/louiss0/javascript-package-delegator/cmd"
+	"github.com/louiss0/javascript-package-delegator/mock"
+	"github.com/louiss0/javascript-package-delegator/testutil"
+)
+
+var _ = Describe("Run --capture", func() {
+	assert := assert.New(GinkgoT())
+
+	var (
+		mockRunner *mock.MockCommandRunner
+		factory    *testutil.RootCommandFactory
+		dir        string
+		scripts    *testutil.MockScriptRunners
+		scriptErr  error
+	)
+
+	// script returns the command the script runner was given
+	script := func() mock.CommandCall {
+		return scripts.Runners()[0].CommandCall
+	}
+
+	executeCapture := func(args ...string) (string, error) {
+		root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.NewParallelCommandRunner = scripts.NewRunner
+		})
+		stdout := new(bytes.Buffer)
+		root.SilenceErrors = true
+		root.SilenceUsage = true
+		root.SetOut(stdout)
+		root.SetErr(new(bytes.Buffer))
+		root.SetArgs(append([]string{"run", "--cwd", dir + "/"}, args...))
+		err := root.Execute()
+		return stdout.String(), err
+	}
+
+	BeforeEach(func() {
+		mockRunner = mock.NewMockCommandRunner()
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+
+		dir = GinkgoT().TempDir()
+		assert.NoError(os.WriteFile(filepath.Join(dir, "package.json"), []byte(`{"scripts": {"build": "vite build"}}`), 0o644))
+
+		scriptErr = nil
+		scripts = &testutil.MockScriptRunners{Setup: func(runner *mock.MockCommandRunner, stdout, stderr io.Writer) {
+			testutil.ScriptOutput(scriptErr, "vite v5.0.0 building for production...", "warning: large chunk", "built in 1.2s")(runner, stdout, stderr)
+		}}
+	})
+
+	It("prints the combined stdout and stderr of the script once it exits", func() {
+		output, err := executeCapture("--capture", "build")
+		assert.NoError(err)
+		assert.Equal("vite v5.0.0 building for production...\nwarning: large chunk\nbuilt in 1.2s\n", output)
+		assert.Equal("npm", script().Name)
+		assert.Equal([]string{"run", "build"}, script().Args)
+		assert.False(mockRunner.HasBeenCalled)
+	})
+
+	It("cuts the output off at --capture-limit with a notice", func() {
+		output, err := executeCapture("--capture", "--capture-limit", "10", "build")
+		assert.NoError(err)
+		assert.Equal("vite v5.0.\n[output truncated after 10 bytes, 64 more bytes were dropped]\n", output)
+	})
+
+	It("prints what was captured when the script fails", func() {
+		scriptErr = errors.New("exit status 1")
+
+		output, err := executeCapture("--capture", "build")
+		assert.ErrorContains(err, "exit status 1")
+		assert.True(strings.HasPrefix(output, "vite v5.0.0 building for production...\n"))
+	})
+
+	It("captures the script run by the --manager-path binary", func() {
+		managerPath := filepath.Join(GinkgoT().TempDir(), "npm-custom")
+		assert.NoError(os.WriteFile(managerPath, []byte("#!/bin/sh\n"), 0o755))
+
+		_, err := executeCapture("--manager-path", managerPath, "--capture", "build")
+		assert.NoError(err)
+		assert.Equal(managerPath, script().Name)
+		assert.Equal([]string{"run", "build"}, script().Args)
+	})
+
+	It("returns the output from CaptureScript", func() {
+		output, dropped, err := cmd.CaptureScript(context.Background(), scripts.NewRunner, dir, nil, false, 0, 20, "pnpm", []string{"run", "build"})
+		assert.NoError(err)
+		assert.Equal("vite v5.0.0 building", string(output))
+		assert.Equal(54, dropped)
+		assert.Equal("pnpm", script().Name)
+	})
+
+	It("rejects --capture-limit without --capture", func() {
+		_, err := executeCapture("--capture-limit", "10", "build")
+		assert.ErrorContains(err, "the --capture-limit flag requires --capture")
+		assert.Empty(scripts.Runners())
+	})
+
+	It("rejects a limit that isn't positive", func() {
+		_, err := executeCapture("--capture", "--capture-limit", "0", "build")
+		assert.ErrorContains(err, "the --capture-limit flag must be greater than 0, got: 0")
+	})
+})
@@ -0,0 +1,83 @@
+package cmd_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/louiss0/javascript-package-delegator/mock"
+	"github.com/louiss0/javascript-package-delegator/testutil"
+)
+
+var _ = Describe("Run Command --cascade", func() {
+	assert := assert.New(GinkgoT())
+
+	var (
+		mockRunner *mock.MockCommandRunner
+		factory    *testutil.RootCommandFactory
+		targetDir  string
+	)
+
+	writeScripts := func(scripts string) {
+		assert.NoError(os.WriteFile(filepath.Join(targetDir, "package.json"), []byte(`{"scripts": `+scripts+`}`), 0644))
+	}
+
+	BeforeEach(func() {
+		mockRunner = mock.NewMockCommandRunner()
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+
+		targetDir = GinkgoT().TempDir()
+		writeScripts(`{"prebuild": "rimraf dist", "build": "vite build", "postbuild": "size-limit"}`)
+	})
+
+	It("runs the pre and post scripts around the script on pnpm", func() {
+		_, err := executeCmd(factory.CreatePnpmAsDefault(nil), "--cwd", targetDir+"/", "run", "build", "--cascade", "--", "--mode", "production")
+		assert.NoError(err)
+		assert.Equal([]mock.CommandCall{
+			{Name: "pnpm", Args: []string{"run", "prebuild"}},
+			{Name: "pnpm", Args: []string{"run", "build", "--", "--mode", "production"}},
+			{Name: "pnpm", Args: []string{"run", "postbuild"}},
+		}, mockRunner.CommandHistory())
+	})
+
+	It("skips the lifecycle scripts the manifest doesn't have on yarn v2+", func() {
+		writeScripts(`{"prebuild": "rimraf dist", "build": "vite build"}`)
+
+		_, err := executeCmd(factory.CreateYarnTwoAsDefault(nil), "--cwd", targetDir+"/", "run", "build", "--cascade")
+		assert.NoError(err)
+		assert.Equal([]mock.CommandCall{
+			{Name: "yarn", Args: []string{"run", "prebuild"}},
+			{Name: "yarn", Args: []string{"run", "build"}},
+		}, mockRunner.CommandHistory())
+	})
+
+	It("stops at the first script that fails", func() {
+		mockRunner.InvalidCommands = []string{"pnpm"}
+
+		_, err := executeCmd(factory.CreatePnpmAsDefault(nil), "--cwd", targetDir+"/", "run", "build", "--cascade")
+		assert.ErrorContains(err, "the prebuild script failed")
+		assert.Len(mockRunner.CommandHistory(), 1)
+	})
+
+	It("prints every command with --print-command", func() {
+		output, err := executeCmd(factory.CreatePnpmAsDefault(nil), "--cwd", targetDir+"/", "run", "build", "--cascade", "--print-command")
+		assert.NoError(err)
+		assert.Equal("pnpm run prebuild\npnpm run build\npnpm run postbuild\n", output)
+		assert.False(mockRunner.HasBeenCalled)
+	})
+
+	It("runs the script once on npm, which runs the lifecycle scripts itself", func() {
+		output, err := executeCmd(factory.CreateNpmAsDefault(nil), "--cwd", targetDir+"/", "run", "build", "--cascade")
+		assert.NoError(err)
+		assert.Contains(output, "Note: npm runs the pre and post scripts on its own, --cascade is ignored")
+		assert.Equal([]mock.CommandCall{
+			{Name: "npm", Args: []string{"run", "build"}},
+		}, mockRunner.CommandHistory())
+	})
+})
@@ -0,0 +1,25 @@
+// Package cmd provides command-line interface implementations for the JavaScript package delegator.
+package cmd
+
+import (
+	// standard library
+	"runtime"
+)
+
+const _CONCURRENTLY_FLAG = "concurrently"
+
+// concurrentlyConflicts are the run flags that only apply to scripts, not to the shell commands of --concurrently.
+var concurrentlyConflicts = []string{
+	_PARALLEL_FLAG, _WATCH_FLAG, _PRINT_COMMAND_FLAG, _CASCADE_FLAG, _RECURSIVE_FLAG, _TIMEOUT_FLAG,
+	_ATTEMPTS_FLAG, _JSON_LOGS_FLAG, _SILENT_FLAG, _SHELL_FLAG, _TASK_RUNNER_FLAG, _BEFORE_FLAG, _AFTER_FLAG,
+	_RESUME_FROM_FLAG, _CAPTURE_FLAG, _CAPTURE_LIMIT_FLAG, _LIST_FLAG, _LIST_JSON_FLAG, "if-present",
+}
+
+// shellCommand returns the program and arguments that run command in the shell of the platform,
+// so a command keeps its quoting, pipes and variables like it does in a package.json script.
+func shellCommand(command string) (string, []string) {
+	if runtime.GOOS == "windows" {
+		return "cmd", []string{"/C", command}
+	}
+	return "sh", []string{"-c", command}
+}
@@ -0,0 +1,134 @@
+package cmd_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"runtime"
+	"sort"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/louiss0/javascript-package-delegator/cmd"
+	"github.com/louiss0/javascript-package-delegator/mock"
+	"github.com/louiss0/javascript-package-delegator/testutil"
+)
+
+var _ = Describe("Run --concurrently", func() {
+	assert := assert.New(GinkgoT())
+
+	var (
+		mockRunner *mock.MockCommandRunner
+		factory    *testutil.RootCommandFactory
+		dir        string
+		shell      []string
+	)
+
+	executeConcurrently := func(root *cobra.Command, ctx context.Context, args ...string) (string, error) {
+		stdout := new(bytes.Buffer)
+		root.SilenceErrors = true
+		root.SilenceUsage = true
+		root.SetOut(stdout)
+		root.SetErr(new(bytes.Buffer))
+		root.SetArgs(append([]string{"run", "--cwd", dir + "/", "--concurrently", "--concurrency", "8"}, args...))
+		err := root.ExecuteContext(ctx)
+		return stdout.String(), err
+	}
+
+	BeforeEach(func() {
+		mockRunner = mock.NewMockCommandRunner()
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+
+		dir = GinkgoT().TempDir()
+		shell = []string{"sh", "-c"}
+		if runtime.GOOS == "windows" {
+			shell = []string{"cmd", "/C"}
+		}
+	})
+
+	It("launches every command in the shell at the same time with prefixed output", func() {
+		recorder := &testutil.BlockingScripts{Together: 2, FinishIn: time.Millisecond, Output: "hello from %s\nready"}
+		root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.NewParallelCommandRunner = recorder.NewRunner
+		})
+
+		output, err := executeConcurrently(root, context.Background(), "vite", "tsc -w")
+		assert.NoError(err)
+
+		commands := recorder.Commands()
+		sort.Slice(commands, func(i, j int) bool {
+			return commands[i][2] < commands[j][2]
+		})
+		assert.Equal([][]string{
+			append(shell, "tsc -w"),
+			append(shell, "vite"),
+		}, commands)
+
+		assert.Contains(output, "[vite] hello from vite\n")
+		assert.Contains(output, "[vite] ready\n")
+		assert.Contains(output, "[tsc -w] hello from tsc -w\n")
+		assert.Contains(output, "[tsc -w] ready\n")
+		assert.False(mockRunner.HasBeenCalled)
+	})
+
+	It("stops the other commands when one fails", func() {
+		recorder := &testutil.BlockingScripts{
+			Together: 2,
+			FinishIn: 200 * time.Millisecond,
+			Output:   "hello from %s\nready",
+			Fail:     map[string]error{"tsc -w": fmt.Errorf("exit status 2")},
+		}
+		root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.NewParallelCommandRunner = recorder.NewRunner
+		})
+
+		_, err := executeConcurrently(root, context.Background(), "vite", "tsc -w")
+		assert.ErrorContains(err, "tsc -w failed: exit status 2")
+		assert.Equal([]string{"vite"}, recorder.Stopped())
+	})
+
+	It("stops every command once it is interrupted", func() {
+		recorder := &testutil.BlockingScripts{Together: 2}
+		root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.NewParallelCommandRunner = recorder.NewRunner
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			<-recorder.AllStarted()
+			cancel()
+		}()
+
+		_, err := executeConcurrently(root, ctx, "vite", "tsc -w")
+		assert.ErrorIs(err, context.Canceled)
+		assert.ElementsMatch([]string{"vite", "tsc -w"}, recorder.Stopped())
+	})
+
+	It("returns an error without commands", func() {
+		recorder := &testutil.BlockingScripts{}
+		root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.NewParallelCommandRunner = recorder.NewRunner
+		})
+
+		_, err := executeConcurrently(root, context.Background())
+		assert.ErrorContains(err, "the --concurrently flag requires at least one command")
+	})
+
+	It("can't be combined with --parallel", func() {
+		recorder := &testutil.BlockingScripts{Together: 2, FinishIn: time.Millisecond, Output: "hello from %s\nready"}
+		root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.NewParallelCommandRunner = recorder.NewRunner
+		})
+
+		_, err := executeConcurrently(root, context.Background(), "--parallel", "vite", "tsc -w")
+		assert.ErrorContains(err, "[concurrently parallel] were all set")
+		assert.Empty(recorder.Commands())
+	})
+})
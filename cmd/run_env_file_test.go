@@ -0,0 +1,316 @@
+package cmd_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/louiss0/javascript-package-delegator/cmd"
+	"github.com/louiss0/javascript-package-delegator/mock"
+	"github.com/louiss0/javascript-package-delegator/testutil"
+)
+
+var _ = Describe("Run Command environment", func() {
+	assert := assert.New(GinkgoT())
+
+	var (
+		mockRunner *mock.MockCommandRunner
+		factory    *testutil.RootCommandFactory
+		targetDir  string
+	)
+
+	writeFile := func(name, content string) string {
+		path := filepath.Join(targetDir, name)
+		assert.NoError(os.WriteFile(path, []byte(content), 0644))
+		return path
+	}
+
+	BeforeEach(func() {
+		mockRunner = mock.NewMockCommandRunner()
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+
+		targetDir = GinkgoT().TempDir()
+		writeFile("package.json", `{"scripts": {"dev": "vite"}}`)
+	})
+
+	It("passes the variables of the env file to the script", func() {
+		envFile := writeFile(".env", `# Local settings
+PORT=3000
+GREETING="hello world" # quoted values keep their spaces
+export API_URL='http://localhost:8080'
+`)
+
+		_, err := executeCmd(factory.CreateNpmAsDefault(nil), "--cwd", targetDir+"/", "run", "dev", "--env-file", envFile)
+		assert.NoError(err)
+		assert.True(mockRunner.HasCommand("npm", "run", "dev"))
+		assert.Equal([]string{"API_URL=http://localhost:8080", "GREETING=hello world", "PORT=3000"}, mockRunner.Env)
+	})
+
+	It("applies multiple env files in order", func() {
+		base := writeFile(".env", "PORT=3000\nMODE=development\n")
+		local := writeFile(".env.local", "PORT=4000\n")
+
+		_, err := executeCmd(factory.CreateNpmAsDefault(nil), "--cwd", targetDir+"/", "run", "dev", "--env-file", base, "--env-file", local)
+		assert.NoError(err)
+		assert.Equal([]string{"MODE=development", "PORT=4000"}, mockRunner.Env)
+	})
+
+	It("errors clearly when the env file is missing", func() {
+		missing := filepath.Join(targetDir, ".env.missing")
+
+		_, err := executeCmd(factory.CreateNpmAsDefault(nil), "--cwd", targetDir+"/", "run", "dev", "--env-file", missing)
+		assert.Error(err)
+		assert.Contains(err.Error(), "failed to read env file "+missing)
+		assert.False(mockRunner.HasBeenCalled)
+	})
+
+	It("sets NODE_ENV=production with --production", func() {
+		_, err := executeCmd(factory.CreateNpmAsDefault(nil), "--cwd", targetDir+"/", "run", "dev", "--production")
+		assert.NoError(err)
+		assert.True(mockRunner.HasCommand("npm", "run", "dev"))
+		assert.Equal([]string{"NODE_ENV=production"}, mockRunner.Env)
+	})
+
+	It("sets NODE_ENV=development with --development next to the env file variables", func() {
+		envFile := writeFile(".env", "PORT=3000\n")
+
+		_, err := executeCmd(factory.CreateNpmAsDefault(nil), "--cwd", targetDir+"/", "run", "dev", "--development", "--env-file", envFile)
+		assert.NoError(err)
+		assert.True(mockRunner.HasCommand("npm", "run", "dev"))
+		assert.Equal([]string{"NODE_ENV=development", "PORT=3000"}, mockRunner.Env)
+	})
+
+	It("lets an env file that sets NODE_ENV win over --production", func() {
+		envFile := writeFile(".env", "NODE_ENV=test\n")
+
+		_, err := executeCmd(factory.CreateNpmAsDefault(nil), "--cwd", targetDir+"/", "run", "dev", "--production", "--env-file", envFile)
+		assert.NoError(err)
+		assert.Equal([]string{"NODE_ENV=test"}, mockRunner.Env)
+	})
+
+	It("rejects --production together with --development", func() {
+		_, err := executeCmd(factory.CreateNpmAsDefault(nil), "--cwd", targetDir+"/", "run", "dev", "--production", "--development")
+		assert.Error(err)
+		assert.False(mockRunner.HasBeenCalled)
+	})
+
+	It("leaves the environment alone without the flag", func() {
+		_, err := executeCmd(factory.CreateNpmAsDefault(nil), "--cwd", targetDir+"/", "run", "dev")
+		assert.NoError(err)
+		assert.Nil(mockRunner.Env)
+	})
+
+	Context("--with-node-options", func() {
+		BeforeEach(func() {
+			GinkgoT().Setenv("NODE_OPTIONS", "")
+			assert.NoError(os.Unsetenv("NODE_OPTIONS"))
+		})
+
+		It("sets NODE_OPTIONS for the script", func() {
+			_, err := executeCmd(factory.CreateNpmAsDefault(nil), "--cwd", targetDir+"/", "run", "dev", "--with-node-options", "--max-old-space-size=4096")
+			assert.NoError(err)
+			assert.True(mockRunner.HasCommand("npm", "run", "dev"))
+			assert.Equal([]string{"NODE_OPTIONS=--max-old-space-size=4096"}, mockRunner.Env)
+		})
+
+		It("joins several values with spaces", func() {
+			_, err := executeCmd(
+				factory.CreateNpmAsDefault(nil), "--cwd", targetDir+"/", "run", "dev", "--production",
+				"--with-node-options", "--max-old-space-size=4096", "--with-node-options", "--inspect --enable-source-maps",
+			)
+			assert.NoError(err)
+			assert.Equal([]string{
+				"NODE_ENV=production",
+				"NODE_OPTIONS=--max-old-space-size=4096 --inspect --enable-source-maps",
+			}, mockRunner.Env)
+		})
+
+		It("keeps the NODE_OPTIONS of an env file before the flag values", func() {
+			envFile := writeFile(".env", "NODE_OPTIONS=--no-warnings\nPORT=3000\n")
+
+			_, err := executeCmd(factory.CreateNpmAsDefault(nil), "--cwd", targetDir+"/", "run", "dev", "--env-file", envFile, "--with-node-options", "--inspect")
+			assert.NoError(err)
+			assert.Equal([]string{"NODE_OPTIONS=--no-warnings --inspect", "PORT=3000"}, mockRunner.Env)
+		})
+
+		It("keeps the NODE_OPTIONS jpd was started with", func() {
+			GinkgoT().Setenv("NODE_OPTIONS", "--trace-warnings")
+
+			_, err := executeCmd(factory.CreateNpmAsDefault(nil), "--cwd", targetDir+"/", "run", "dev", "--with-node-options", "--inspect")
+			assert.NoError(err)
+			assert.Equal([]string{"NODE_OPTIONS=--trace-warnings --inspect"}, mockRunner.Env)
+		})
+	})
+
+	Context("--clean-env", func() {
+		BeforeEach(func() {
+			GinkgoT().Setenv("PATH", "/usr/local/bin:/usr/bin")
+			GinkgoT().Setenv("HOME", "/home/jpd")
+			GinkgoT().Setenv("JPD_SECRET_TOKEN", "leaked")
+			GinkgoT().Setenv("NODE_OPTIONS", "--inspect")
+		})
+
+		It("runs the script with only the essential variables", func() {
+			_, err := executeCmd(factory.CreateNpmAsDefault(nil), "--cwd", targetDir+"/", "run", "dev", "--clean-env")
+			assert.NoError(err)
+			assert.True(mockRunner.HasCommand("npm", "run", "dev"))
+			assert.True(mockRunner.EnvCleared)
+			assert.Contains(mockRunner.Env, "PATH=/usr/local/bin:/usr/bin")
+			assert.Contains(mockRunner.Env, "HOME=/home/jpd")
+			for _, entry := range mockRunner.Env {
+				assert.NotContains(entry, "JPD_SECRET_TOKEN")
+				assert.NotContains(entry, "NODE_OPTIONS")
+			}
+		})
+
+		It("adds the variables of the env file", func() {
+			envFile := writeFile(".env", "PORT=3000\nHOME=/srv/app\n")
+
+			_, err := executeCmd(factory.CreateNpmAsDefault(nil), "--cwd", targetDir+"/", "run", "dev", "--clean-env", "--env-file", envFile)
+			assert.NoError(err)
+			assert.True(mockRunner.EnvCleared)
+			assert.Contains(mockRunner.Env, "PORT=3000")
+			assert.Contains(mockRunner.Env, "HOME=/srv/app")
+			assert.NotContains(mockRunner.Env, "HOME=/home/jpd")
+			assert.Contains(mockRunner.Env, "PATH=/usr/local/bin:/usr/bin")
+		})
+
+		It("leaves out the inherited NODE_OPTIONS when adding to them", func() {
+			_, err := executeCmd(factory.CreateNpmAsDefault(nil), "--cwd", targetDir+"/", "run", "dev", "--clean-env", "--with-node-options", "--no-warnings")
+			assert.NoError(err)
+			assert.Contains(mockRunner.Env, "NODE_OPTIONS=--no-warnings")
+		})
+
+		It("inherits the environment without the flag", func() {
+			_, err := executeCmd(factory.CreateNpmAsDefault(nil), "--cwd", targetDir+"/", "run", "dev")
+			assert.NoError(err)
+			assert.False(mockRunner.EnvCleared)
+			assert.Nil(mockRunner.Env)
+		})
+	})
+
+	Context("node_modules/.bin", func() {
+		var binDir string
+
+		BeforeEach(func() {
+			binDir = filepath.Join(targetDir, "node_modules", ".bin")
+			assert.NoError(os.MkdirAll(binDir, 0o755))
+			GinkgoT().Setenv("PATH", "/usr/local/bin:/usr/bin")
+		})
+
+		It("puts the .bin directory in front of PATH", func() {
+			_, err := executeCmd(factory.CreatePnpmAsDefault(nil), "--cwd", targetDir+"/", "run", "dev")
+			assert.NoError(err)
+			assert.True(mockRunner.HasCommand("pnpm", "run", "dev"))
+			assert.Equal([]string{"PATH=" + binDir + string(os.PathListSeparator) + "/usr/local/bin:/usr/bin"}, mockRunner.Env)
+		})
+
+		It("puts the .bin directory in front of the PATH of an env file", func() {
+			envFile := writeFile(".env", "PATH=/opt/tools\nPORT=3000\n")
+
+			_, err := executeCmd(factory.CreateNpmAsDefault(nil), "--cwd", targetDir+"/", "run", "dev", "--env-file", envFile)
+			assert.NoError(err)
+			assert.Equal([]string{"PATH=" + binDir + string(os.PathListSeparator) + "/opt/tools", "PORT=3000"}, mockRunner.Env)
+		})
+
+		It("keeps the .bin directory under --clean-env", func() {
+			_, err := executeCmd(factory.CreateNpmAsDefault(nil), "--cwd", targetDir+"/", "run", "dev", "--clean-env")
+			assert.NoError(err)
+			assert.True(mockRunner.EnvCleared)
+			assert.Contains(mockRunner.Env, "PATH="+binDir+string(os.PathListSeparator)+"/usr/local/bin:/usr/bin")
+		})
+
+		It("doesn't add the .bin directory twice", func() {
+			GinkgoT().Setenv("PATH", binDir+string(os.PathListSeparator)+"/usr/bin")
+
+			_, err := executeCmd(factory.CreateNpmAsDefault(nil), "--cwd", targetDir+"/", "run", "dev")
+			assert.NoError(err)
+			assert.Nil(mockRunner.Env)
+		})
+
+		It("gives every script of --parallel the .bin directory", func() {
+			writeFile("package.json", `{"scripts": {"dev": "vite", "test": "vitest"}}`)
+			scripts := &testutil.MockScriptRunners{}
+
+			_, err := executeCmd(factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+				deps.NewParallelCommandRunner = scripts.NewRunner
+			}), "--cwd", targetDir+"/", "run", "--parallel", "dev", "test")
+			assert.NoError(err)
+			assert.Len(scripts.Runners(), 2)
+			for _, runner := range scripts.Runners() {
+				script, env := runner.CommandCall.Args[len(runner.CommandCall.Args)-1], runner.Env
+				assert.Len(env, 1, script)
+				assert.True(strings.HasPrefix(env[0], "PATH="+binDir+string(os.PathListSeparator)), "%s got %v", script, env)
+			}
+		})
+	})
+
+	Context("--npm-env-compat", func() {
+		var manifestPath string
+
+		BeforeEach(func() {
+			manifestPath = writeFile("package.json", `{"name": "@acme/web", "version": "1.4.0", "scripts": {"dev": "vite", "test": "vitest"}}`)
+		})
+
+		It("gives the script the npm_package variables on pnpm", func() {
+			_, err := executeCmd(factory.CreatePnpmAsDefault(nil), "--cwd", targetDir+"/", "run", "dev", "--npm-env-compat")
+			assert.NoError(err)
+			assert.True(mockRunner.HasCommand("pnpm", "run", "dev"))
+			assert.Equal([]string{
+				"npm_package_json=" + manifestPath,
+				"npm_package_name=@acme/web",
+				"npm_package_version=1.4.0",
+			}, mockRunner.Env)
+		})
+
+		It("lets an env file override them", func() {
+			envFile := writeFile(".env", "npm_package_version=0.0.0-dev\n")
+
+			_, err := executeCmd(factory.CreateBunAsDefault(nil), "--cwd", targetDir+"/", "run", "dev", "--npm-env-compat", "--env-file", envFile)
+			assert.NoError(err)
+			assert.Contains(mockRunner.Env, "npm_package_version=0.0.0-dev")
+			assert.Contains(mockRunner.Env, "npm_package_name=@acme/web")
+		})
+
+		It("leaves out a missing version", func() {
+			writeFile("package.json", `{"name": "web", "scripts": {"dev": "vite"}}`)
+
+			_, err := executeCmd(factory.CreateYarnTwoAsDefault(nil), "--cwd", targetDir+"/", "run", "dev", "--npm-env-compat")
+			assert.NoError(err)
+			assert.Contains(mockRunner.Env, "npm_package_name=web")
+			assert.False(lo.ContainsBy(mockRunner.Env, func(entry string) bool {
+				return strings.HasPrefix(entry, "npm_package_version=")
+			}), "got %v", mockRunner.Env)
+		})
+
+		It("gives every script of --parallel the variables", func() {
+			scripts := &testutil.MockScriptRunners{}
+
+			_, err := executeCmd(factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+				deps.NewParallelCommandRunner = scripts.NewRunner
+			}), "--cwd", targetDir+"/", "run", "--parallel", "--npm-env-compat", "dev", "test")
+			assert.NoError(err)
+			assert.Len(scripts.Runners(), 2)
+			for _, runner := range scripts.Runners() {
+				assert.Contains(runner.Env, "npm_package_name=@acme/web", runner.CommandCall.Args)
+				assert.Contains(runner.Env, "npm_package_version=1.4.0", runner.CommandCall.Args)
+			}
+		})
+
+		It("returns an error without a package.json", func() {
+			assert.NoError(os.Remove(manifestPath))
+
+			_, err := executeCmd(factory.CreateDenoAsDefault(nil), "--cwd", targetDir+"/", "run", "dev", "--npm-env-compat")
+			assert.ErrorContains(err, "the --npm-env-compat flag needs a package.json")
+			assert.False(mockRunner.HasBeenCalled)
+		})
+	})
+})
@@ -0,0 +1,110 @@
+// Package cmd provides command-line interface implementations for the JavaScript package delegator.
+package cmd
+
+import (
+	// standard library
+	"fmt"
+	"strings"
+
+	// external
+	"github.com/charmbracelet/log"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+)
+
+const (
+	_BEFORE_FLAG      = "before"
+	_AFTER_FLAG       = "after"
+	_RESUME_FROM_FLAG = "resume-from"
+)
+
+// readRunHooks returns the scripts named with --before and --after in the order they were given.
+// Every one of them must exist in the manifest, so a typo stops the run before anything starts.
+func readRunHooks(cmd *cobra.Command, pm, targetDir string) (before, after []string, err error) {
+	if before, err = cmd.Flags().GetStringArray(_BEFORE_FLAG); err != nil {
+		return nil, nil, err
+	}
+	if after, err = cmd.Flags().GetStringArray(_AFTER_FLAG); err != nil {
+		return nil, nil, err
+	}
+
+	if len(before) == 0 && len(after) == 0 {
+		return nil, nil, nil
+	}
+
+	if err := checkScriptsExist(pm, targetDir, append(append([]string{}, before...), after...)); err != nil {
+		return nil, nil, fmt.Errorf("the --%s and --%s scripts must exist: %w", _BEFORE_FLAG, _AFTER_FLAG, err)
+	}
+
+	return before, after, nil
+}
+
+// resumeRunHooks drops the scripts of the sequence --before, script, --after that come before resumeFrom.
+// skipScript reports whether script itself comes before it, an empty resumeFrom keeps the whole sequence.
+func resumeRunHooks(before []string, script string, after []string, resumeFrom string) (resumedBefore, resumedAfter []string, skipScript bool, err error) {
+	if resumeFrom == "" {
+		return before, after, false, nil
+	}
+
+	if i := lo.IndexOf(before, resumeFrom); i >= 0 {
+		return before[i:], after, false, nil
+	}
+	if resumeFrom == script {
+		return nil, after, false, nil
+	}
+	if i := lo.IndexOf(after, resumeFrom); i >= 0 {
+		return nil, after[i:], true, nil
+	}
+
+	sequence := lo.Flatten([][]string{before, {script}, after})
+	return nil, nil, false, fmt.Errorf("the --%s script %s is not part of the sequence %s", _RESUME_FROM_FLAG, resumeFrom, strings.Join(sequence, " -> "))
+}
+
+// runWithHooks runs the before scripts, then run and then the after scripts, each as its own command.
+// The first script that fails stops the rest, skipScript leaves out run when --resume-from starts after it.
+func runWithHooks(cmd *cobra.Command, pm string, before, after []string, skipScript bool, opts runOptions, run func() error) error {
+	for _, name := range before {
+		if err := runHookScript(cmd, pm, name, opts); err != nil {
+			return err
+		}
+	}
+
+	if !skipScript {
+		if err := run(); err != nil {
+			return err
+		}
+	}
+
+	for _, name := range after {
+		if err := runHookScript(cmd, pm, name, opts); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// runHookScript runs a --before or --after script without arguments in the project itself.
+func runHookScript(cmd *cobra.Command, pm, name string, opts runOptions) error {
+	cmdRunner := getCommandRunnerFromCommandContext(cmd)
+	goEnv := getGoEnvFromCommandContext(cmd)
+	de := getDebugExecutorFromCommandContext(cmd)
+
+	cmdArgs, err := buildRunArgs(pm, name, nil, runOptions{Silent: opts.Silent, Shell: opts.Shell, YarnVersion: opts.YarnVersion})
+	if err != nil {
+		return err
+	}
+
+	cmdRunner.Command(pm, cmdArgs...)
+	de.LogJSCommandIfDebugIsTrue(pm, cmdArgs...)
+
+	goEnv.ExecuteIfModeIsProduction(func() {
+		log.Info("Running command", "pm", pm, "args", strings.Join(cmdArgs, " "))
+	})
+
+	if err := cmdRunner.Run(); err != nil {
+		return fmt.Errorf("the %s script failed: %w", name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package cmd_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/louiss0/javascript-package-delegator/mock"
+	"github.com/louiss0/javascript-package-delegator/testutil"
+)
+
+var _ = Describe("Run --before and --after", func() {
+	assert := assert.New(GinkgoT())
+
+	var (
+		mockRunner *mock.MockCommandRunner
+		factory    *testutil.RootCommandFactory
+		dir        string
+	)
+
+	BeforeEach(func() {
+		mockRunner = mock.NewMockCommandRunner()
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+
+		dir = GinkgoT().TempDir()
+		assert.NoError(os.WriteFile(filepath.Join(dir, "package.json"), []byte(`{
+  "scripts": {
+    "clean": "rimraf dist",
+    "codegen": "graphql-codegen",
+    "build": "vite build",
+    "notify": "node notify.js"
+  }
+}`), 0o644))
+	})
+
+	It("runs the hooks and the script as separate commands in order", func() {
+		_, err := executeCmd(factory.CreatePnpmAsDefault(nil), "run", "--cwd", dir+"/", "build", "--before", "clean", "--after", "notify")
+		assert.NoError(err)
+		assert.Equal([]mock.CommandCall{
+			{Name: "pnpm", Args: []string{"run", "clean"}},
+			{Name: "pnpm", Args: []string{"run", "build"}},
+			{Name: "pnpm", Args: []string{"run", "notify"}},
+		}, mockRunner.CommandHistory())
+	})
+
+	It("runs several --before values in the order given", func() {
+		_, err := executeCmd(factory.CreateNpmAsDefault(nil), "run", "--cwd", dir+"/", "build", "--before", "codegen", "--before", "clean")
+		assert.NoError(err)
+		assert.Equal([]mock.CommandCall{
+			{Name: "npm", Args: []string{"run", "codegen"}},
+			{Name: "npm", Args: []string{"run", "clean"}},
+			{Name: "npm", Args: []string{"run", "build"}},
+		}, mockRunner.CommandHistory())
+	})
+
+	It("passes the script arguments to the script only", func() {
+		_, err := executeCmd(factory.CreateNpmAsDefault(nil), "run", "--cwd", dir+"/", "--after", "notify", "build", "--", "--mode", "staging")
+		assert.NoError(err)
+		assert.Equal([]mock.CommandCall{
+			{Name: "npm", Args: []string{"run", "build", "--", "--mode", "staging"}},
+			{Name: "npm", Args: []string{"run", "notify"}},
+		}, mockRunner.CommandHistory())
+	})
+
+	It("errors on an unknown hook script before running anything", func() {
+		_, err := executeCmd(factory.CreateNpmAsDefault(nil), "run", "--cwd", dir+"/", "build", "--before", "clean", "--after", "deploy")
+		assert.ErrorContains(err, `script "deploy" was not found in package.json`)
+		assert.False(mockRunner.HasBeenCalled)
+	})
+
+	It("skips the scripts before --resume-from", func() {
+		_, err := executeCmd(factory.CreateNpmAsDefault(nil), "run", "--cwd", dir+"/", "build", "--before", "clean", "--after", "notify", "--resume-from", "build")
+		assert.NoError(err)
+		assert.Equal([]mock.CommandCall{
+			{Name: "npm", Args: []string{"run", "build"}},
+			{Name: "npm", Args: []string{"run", "notify"}},
+		}, mockRunner.CommandHistory())
+	})
+
+	It("resumes from a --before script", func() {
+		_, err := executeCmd(factory.CreateNpmAsDefault(nil), "run", "--cwd", dir+"/", "build", "--before", "clean", "--before", "codegen", "--resume-from", "codegen")
+		assert.NoError(err)
+		assert.Equal([]mock.CommandCall{
+			{Name: "npm", Args: []string{"run", "codegen"}},
+			{Name: "npm", Args: []string{"run", "build"}},
+		}, mockRunner.CommandHistory())
+	})
+
+	It("skips the script itself when resuming from an --after script", func() {
+		_, err := executeCmd(factory.CreateNpmAsDefault(nil), "run", "--cwd", dir+"/", "build", "--before", "clean", "--after", "notify", "--resume-from", "notify")
+		assert.NoError(err)
+		assert.Equal([]mock.CommandCall{
+			{Name: "npm", Args: []string{"run", "notify"}},
+		}, mockRunner.CommandHistory())
+	})
+
+	It("errors when --resume-from is not part of the sequence", func() {
+		_, err := executeCmd(factory.CreateNpmAsDefault(nil), "run", "--cwd", dir+"/", "build", "--before", "clean", "--resume-from", "codegen")
+		assert.ErrorContains(err, "the --resume-from script codegen is not part of the sequence clean -> build")
+		assert.False(mockRunner.HasBeenCalled)
+	})
+
+	It("cannot be combined with --watch", func() {
+		_, err := executeCmd(factory.CreateNpmAsDefault(nil), "run", "--cwd", dir+"/", "build", "--before", "clean", "--watch")
+		assert.ErrorContains(err, "none of the others can be")
+		assert.False(mockRunner.HasBeenCalled)
+	})
+})
@@ -0,0 +1,54 @@
+// Package cmd provides command-line interface implementations for the JavaScript package delegator.
+package cmd
+
+import (
+	// standard library
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+const _JSON_LOGS_FLAG = "json-logs"
+
+type scriptStartLog struct {
+	Event     string    `json:"event"`
+	PM        string    `json:"pm"`
+	Argv      []string  `json:"argv"`
+	StartTime time.Time `json:"startTime"`
+}
+
+type scriptEndLog struct {
+	Event      string    `json:"event"`
+	PM         string    `json:"pm"`
+	Argv       []string  `json:"argv"`
+	StartTime  time.Time `json:"startTime"`
+	EndTime    time.Time `json:"endTime"`
+	ExitCode   int       `json:"exitCode"`
+	DurationMs int64     `json:"durationMs"`
+}
+
+// runWithJSONLogs writes a JSON line to w before and after run, the output of the script itself is left alone.
+// The end line is written even when the script fails, so its exit code can be read back.
+func runWithJSONLogs(w io.Writer, pm string, argv []string, run func() error) (err error) {
+	encoder := json.NewEncoder(w)
+	start := time.Now().UTC()
+	if encodeErr := encoder.Encode(scriptStartLog{Event: "start", PM: pm, Argv: argv, StartTime: start}); encodeErr != nil {
+		return fmt.Errorf("failed to write the --%s start line: %w", _JSON_LOGS_FLAG, encodeErr)
+	}
+
+	defer func() {
+		end := time.Now().UTC()
+		_ = encoder.Encode(scriptEndLog{
+			Event:      "end",
+			PM:         pm,
+			Argv:       argv,
+			StartTime:  start,
+			EndTime:    end,
+			ExitCode:   commandExitCode(err),
+			DurationMs: end.Sub(start).Milliseconds(),
+		})
+	}()
+
+	return run()
+}
@@ -0,0 +1,101 @@
+package cmd_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	tmock "github.com/stretchr/testify/mock"
+
+	"github.com/louiss0/javascript-package-delegator/mock"
+	"github.com/louiss0/javascript-package-delegator/testutil"
+)
+
+var _ = Describe("Run Command --json-logs", func() {
+	assert := assert.New(GinkgoT())
+
+	var (
+		mockRunner *mock.MockCommandRunner
+		factory    *testutil.RootCommandFactory
+		dir        string
+	)
+
+	// executeLogged runs the command and returns the JSON lines written to stderr.
+	executeLogged := func(root *cobra.Command, args ...string) ([]map[string]any, error) {
+		stderr := new(bytes.Buffer)
+		root.SilenceErrors = true
+		root.SilenceUsage = true
+		root.SetOut(new(bytes.Buffer))
+		root.SetErr(stderr)
+		root.SetArgs(args)
+		err := root.Execute()
+
+		var lines []map[string]any
+		for _, line := range strings.Split(strings.TrimSpace(stderr.String()), "\n") {
+			if line == "" {
+				continue
+			}
+			var logLine map[string]any
+			assert.NoError(json.Unmarshal([]byte(line), &logLine), "every stderr line must be JSON: %q", line)
+			lines = append(lines, logLine)
+		}
+		return lines, err
+	}
+
+	BeforeEach(func() {
+		mockRunner = mock.NewMockCommandRunner()
+		mockRunner.On("Run", "npm", []string{"run", "broken"}, tmock.Anything).
+			Return(fakeExitError{code: 3}).Maybe()
+
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+
+		dir = GinkgoT().TempDir() + "/"
+	})
+
+	It("writes a start and an end line around a passing script", func() {
+		lines, err := executeLogged(factory.CreateNpmAsDefault(nil), "--cwd", dir, "run", "build", "--json-logs")
+		assert.NoError(err)
+		assert.True(mockRunner.HasCommand("npm", "run", "build"), "got %v", mockRunner.CommandCall)
+		assert.Len(lines, 2)
+
+		assert.Equal("start", lines[0]["event"])
+		assert.Equal("npm", lines[0]["pm"])
+		assert.Equal([]any{"run", "build"}, lines[0]["argv"])
+
+		assert.Equal("end", lines[1]["event"])
+		assert.Equal("npm", lines[1]["pm"])
+		assert.Equal([]any{"run", "build"}, lines[1]["argv"])
+		assert.Equal(float64(0), lines[1]["exitCode"])
+		assert.Equal(lines[0]["startTime"], lines[1]["startTime"])
+
+		start, err := time.Parse(time.RFC3339Nano, lines[1]["startTime"].(string))
+		assert.NoError(err)
+		end, err := time.Parse(time.RFC3339Nano, lines[1]["endTime"].(string))
+		assert.NoError(err)
+		assert.False(end.Before(start))
+	})
+
+	It("writes the exit code of a failing script in the end line", func() {
+		lines, err := executeLogged(factory.CreateNpmAsDefault(nil), "--cwd", dir, "run", "broken", "--json-logs")
+		assert.Error(err)
+		assert.Len(lines, 2)
+		assert.Equal("start", lines[0]["event"])
+		assert.Equal("end", lines[1]["event"])
+		assert.Equal([]any{"run", "broken"}, lines[1]["argv"])
+		assert.Equal(float64(3), lines[1]["exitCode"])
+	})
+
+	It("writes nothing without the flag", func() {
+		lines, err := executeLogged(factory.CreateNpmAsDefault(nil), "--cwd", dir, "run", "build")
+		assert.NoError(err)
+		assert.Empty(lines)
+	})
+})
@@ -0,0 +1,86 @@
+// Package cmd provides command-line interface implementations for the JavaScript package delegator.
+package cmd
+
+import (
+	// standard library
+	"fmt"
+	"os"
+	"path/filepath"
+
+	// external
+	"github.com/charmbracelet/log"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+
+	// internal
+	"github.com/louiss0/javascript-package-delegator/detect"
+	"github.com/louiss0/javascript-package-delegator/internal/deps"
+)
+
+const _AUTO_INSTALL_FLAG = "auto-install"
+
+// nodeLockfiles are the lockfiles an install into node_modules is made from.
+var nodeLockfiles = []string{
+	detect.PNPM_LOCK_YAML,
+	detect.YARN_LOCK,
+	detect.PACKAGE_LOCK_JSON,
+	detect.BUN_LOCKB,
+	detect.BUN_LOCK_JSON,
+}
+
+// freshnessLockfile returns the lockfile in targetDir that run compares with the last install,
+// false when pm doesn't install into node_modules from one.
+func freshnessLockfile(pm, targetDir string) (string, bool) {
+	if pm == "deno" || (pm == "yarn" && IsYarnPnpProject(targetDir)) {
+		return "", false
+	}
+
+	return lo.Find(nodeLockfiles, func(name string) bool {
+		_, err := os.Stat(filepath.Join(targetDir, name))
+		return err == nil
+	})
+}
+
+// checkLockfileFreshness warns when the lockfile in targetDir changed after the last install,
+// so the script doesn't run against stale node_modules. With --auto-install it installs instead
+// and stores the new dependency hash like start does.
+func checkLockfileFreshness(cmd *cobra.Command, pm, targetDir string) error {
+	lockfile, found := freshnessLockfile(pm, targetDir)
+	if !found {
+		return nil
+	}
+
+	stale, err := deps.LockfileNewerThanInstall(targetDir, lockfile)
+	if err != nil {
+		return err
+	}
+	if !stale {
+		return nil
+	}
+
+	autoInstall, err := cmd.Flags().GetBool(_AUTO_INSTALL_FLAG)
+	if err != nil {
+		return err
+	}
+	if !autoInstall {
+		return printNote(cmd, "%s changed after the last install, run %s install or pass --%s", lockfile, pm, _AUTO_INSTALL_FLAG)
+	}
+
+	cmdRunner := getCommandRunnerFromCommandContext(cmd)
+	goEnv := getGoEnvFromCommandContext(cmd)
+	de := getDebugExecutorFromCommandContext(cmd)
+
+	goEnv.ExecuteIfModeIsProduction(func() {
+		log.Info("Auto-installing dependencies", "reason", lockfile+" changed", "pm", pm, "dir", targetDir)
+	})
+
+	de.LogJSCommandIfDebugIsTrue(pm, "install")
+	cmdRunner.Command(pm, "install")
+	if err := cmdRunner.Run(); err != nil {
+		return fmt.Errorf("failed to install dependencies: %w", err)
+	}
+
+	writeNodeDepsHash(targetDir, goEnv, de)
+
+	return nil
+}
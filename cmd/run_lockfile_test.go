@@ -0,0 +1,92 @@
+package cmd_test
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/stretchr/testify/assert"
+	tmock "github.com/stretchr/testify/mock"
+
+	"github.com/louiss0/javascript-package-delegator/internal/deps"
+	"github.com/louiss0/javascript-package-delegator/mock"
+	"github.com/louiss0/javascript-package-delegator/testutil"
+)
+
+var _ = Describe("Run lockfile freshness", func() {
+	assert := assert.New(GinkgoT())
+
+	var (
+		mockRunner *mock.MockCommandRunner
+		factory    *testutil.RootCommandFactory
+		dir        string
+	)
+
+	BeforeEach(func() {
+		mockRunner = mock.NewMockCommandRunner()
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+
+		// The last install happened an hour ago
+		dir = GinkgoT().TempDir()
+		assert.NoError(os.WriteFile(filepath.Join(dir, "package.json"), []byte(`{
+  "scripts": {"build": "vite build"},
+  "dependencies": {"react": "18.2.0"}
+}`), 0o644))
+		assert.NoError(os.WriteFile(filepath.Join(dir, "package-lock.json"), []byte(`{}`), 0o644))
+		assert.NoError(os.Mkdir(filepath.Join(dir, "node_modules"), 0o755))
+		assert.NoError(deps.WriteStoredDepsHash(dir, "stale"))
+
+		installedAt := time.Now().Add(-time.Hour)
+		assert.NoError(os.Chtimes(filepath.Join(dir, "node_modules", deps.DepsHashFile), installedAt, installedAt))
+		assert.NoError(os.Chtimes(filepath.Join(dir, "node_modules"), installedAt, installedAt))
+	})
+
+	It("warns when the lockfile changed after the last install", func() {
+		output, err := executeCmd(factory.CreateNpmAsDefault(nil), "run", "--cwd", dir+"/", "build")
+		assert.NoError(err)
+		assert.Contains(output, "Note: package-lock.json changed after the last install, run npm install or pass --auto-install")
+		assert.Equal([]mock.CommandCall{
+			{Name: "npm", Args: []string{"run", "build"}},
+		}, mockRunner.CommandHistory())
+	})
+
+	It("installs and updates the dependency hash with --auto-install", func() {
+		testutil.DebugExecutorExpectationManager.ExpectUpdatedDependencyHash()
+
+		output, err := executeCmd(factory.CreateNpmAsDefault(nil), "run", "--cwd", dir+"/", "--auto-install", "build")
+		assert.NoError(err)
+		assert.NotContains(output, "Note:")
+		assert.Equal([]mock.CommandCall{
+			{Name: "npm", Args: []string{"install"}},
+			{Name: "npm", Args: []string{"run", "build"}},
+		}, mockRunner.CommandHistory())
+
+		currentHash, err := deps.ComputeNodeDepsHash(dir)
+		assert.NoError(err)
+		storedHash, err := deps.ReadStoredDepsHash(dir)
+		assert.NoError(err)
+		assert.Equal(currentHash, storedHash)
+		factory.DebugExecutor().AssertCalled(GinkgoT(), "LogDebugMessageIfDebugIsTrue", "Updated dependency hash", "hash", tmock.Anything)
+
+		stale, err := deps.LockfileNewerThanInstall(dir, "package-lock.json")
+		assert.NoError(err)
+		assert.False(stale)
+	})
+
+	It("stays quiet when node_modules is newer than the lockfile", func() {
+		lockedAt := time.Now().Add(-2 * time.Hour)
+		assert.NoError(os.Chtimes(filepath.Join(dir, "package-lock.json"), lockedAt, lockedAt))
+
+		output, err := executeCmd(factory.CreateNpmAsDefault(nil), "run", "--cwd", dir+"/", "--auto-install", "build")
+		assert.NoError(err)
+		assert.NotContains(output, "Note:")
+		assert.Equal([]mock.CommandCall{
+			{Name: "npm", Args: []string{"run", "build"}},
+		}, mockRunner.CommandHistory())
+	})
+})
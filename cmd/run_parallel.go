@@ -0,0 +1,220 @@
+// Package cmd provides command-line interface implementations for the JavaScript package delegator.
+package cmd
+
+import (
+	// standard library
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"sync"
+
+	// external
+	"github.com/samber/lo"
+)
+
+const (
+	_PARALLEL_FLAG          = "parallel"
+	_CONTINUE_ON_ERROR_FLAG = "continue-on-error"
+	_CONCURRENCY_FLAG       = "concurrency"
+)
+
+// ParallelCommandRunnerFactory creates the runner of a single script started by run --parallel.
+// The runner writes to stdout and stderr instead of the terminal and stops once ctx is cancelled.
+type ParallelCommandRunnerFactory func(ctx context.Context, stdout, stderr io.Writer) CommandRunner
+
+// newParallelCommandRunner runs the script as a child process without access to stdin,
+// the scripts share the terminal so none of them can read from it.
+func newParallelCommandRunner(ctx context.Context, stdout, stderr io.Writer) CommandRunner {
+	return &commandRunner{
+		execCommandFunc: func(name string, args ...string) *exec.Cmd {
+			return exec.CommandContext(ctx, name, args...)
+		},
+		stdout: stdout,
+		stderr: stderr,
+	}
+}
+
+// withDecorators wraps every runner the factory creates in decorators, applied in order.
+// The root passes the wrappers of its own runner so --parallel, --timeout and --capture honor the same flags.
+func (f ParallelCommandRunnerFactory) withDecorators(decorators ...func(CommandRunner) CommandRunner) ParallelCommandRunnerFactory {
+	return func(ctx context.Context, stdout, stderr io.Writer) CommandRunner {
+		runner := f(ctx, stdout, stderr)
+		for _, decorate := range decorators {
+			runner = decorate(runner)
+		}
+		return runner
+	}
+}
+
+// parallelScript is one script started by run --parallel or one command started by run --concurrently.
+type parallelScript struct {
+	name    string // The prefix of its output
+	program string
+	args    []string
+}
+
+// runScriptsInParallel starts every script with its own runner and waits for all of them.
+// At most concurrency scripts run at the same time, the others wait for one of them to finish.
+// Unless continueOnError is set the remaining scripts are stopped once one of them fails,
+// scripts that are still waiting are not started at all.
+func runScriptsInParallel(
+	ctx context.Context,
+	stdout, stderr io.Writer,
+	newRunner ParallelCommandRunnerFactory,
+	targetDir string,
+	env []string,
+	cleanEnv bool,
+	scripts []parallelScript,
+	concurrency int,
+	continueOnError bool,
+) error {
+	if concurrency <= 0 {
+		return fmt.Errorf("the --%s flag must be greater than 0, got: %d", _CONCURRENCY_FLAG, concurrency)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Prefixed lines of different scripts must not interleave
+	var outputMu sync.Mutex
+	errs := make([]error, len(scripts))
+
+	// The script that failed first is the one reported, the others were stopped because of it
+	var firstErr error
+	var firstErrOnce sync.Once
+
+	// Every running script holds a slot until it finishes
+	slots := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+	for i, script := range scripts {
+		prefix := fmt.Sprintf("[%s] ", script.name)
+		scriptStdout := &prefixWriter{w: stdout, prefix: prefix, mu: &outputMu}
+		scriptStderr := &prefixWriter{w: stderr, prefix: prefix, mu: &outputMu}
+
+		runner := newRunner(ctx, scriptStdout, scriptStderr)
+		if targetDir != "" {
+			if err := runner.SetTargetDir(targetDir); err != nil {
+				return err
+			}
+		}
+		if cleanEnv {
+			runner.ClearEnv()
+		}
+		if len(env) > 0 {
+			runner.SetEnv(env)
+		}
+		runner.Command(script.program, script.args...)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			defer func() { <-slots }()
+
+			// Another script may have failed while this one was waiting for a slot
+			if ctx.Err() != nil {
+				return
+			}
+
+			defer func() {
+				_ = scriptStdout.Flush()
+				_ = scriptStderr.Flush()
+			}()
+
+			if err := runner.Run(); err != nil {
+				errs[i] = fmt.Errorf("script %s failed: %w", script.name, err)
+				firstErrOnce.Do(func() { firstErr = errs[i] })
+				if !continueOnError {
+					cancel()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if !continueOnError {
+		return firstErr
+	}
+
+	return errors.Join(lo.Filter(errs, func(err error, _ int) bool { return err != nil })...)
+}
+
+// prefixWriter writes every line it receives to w preceded by prefix.
+// A line is only written once it is complete, Flush writes what is left.
+type prefixWriter struct {
+	w      io.Writer
+	prefix string
+	mu     *sync.Mutex
+	buf    []byte
+}
+
+func (p *prefixWriter) Write(data []byte) (int, error) {
+	p.buf = append(p.buf, data...)
+
+	for {
+		i := bytes.IndexByte(p.buf, '\n')
+		if i < 0 {
+			return len(data), nil
+		}
+
+		if err := p.writeLine(p.buf[:i+1]); err != nil {
+			return 0, err
+		}
+		p.buf = p.buf[i+1:]
+	}
+}
+
+// Flush writes a trailing line that did not end with a newline.
+func (p *prefixWriter) Flush() error {
+	if len(p.buf) == 0 {
+		return nil
+	}
+
+	line := append(p.buf, '\n')
+	p.buf = nil
+	return p.writeLine(line)
+}
+
+func (p *prefixWriter) writeLine(line []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	_, err := fmt.Fprintf(p.w, "%s%s", p.prefix, line)
+	return err
+}
+
+// checkScriptsExist returns an error naming the first script that is not defined in the project manifest.
+func checkScriptsExist(pm, targetDir string, names []string) error {
+	if pm == "deno" {
+		denoJSON, err := readDenoJSONFrom(targetDir)
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			if _, ok := denoJSON.Tasks[name]; !ok {
+				return fmt.Errorf("task %q was not found in deno.json", name)
+			}
+		}
+		return nil
+	}
+
+	pkg, err := readPackageJSONAndUnmarshalScriptsFrom(targetDir)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		if _, ok := pkg.Scripts[name]; !ok {
+			return fmt.Errorf("script %q was not found in package.json", name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+package cmd_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/louiss0/javascript-package-delegator/cmd"
+	"github.com/louiss0/javascript-package-delegator/mock"
+	"github.com/louiss0/javascript-package-delegator/testutil"
+)
+
+var _ = Describe("Run --parallel", func() {
+	assert := assert.New(GinkgoT())
+
+	var (
+		mockRunner *mock.MockCommandRunner
+		factory    *testutil.RootCommandFactory
+		dir        string
+	)
+
+	executeParallel := func(root *cobra.Command, args ...string) (string, error) {
+		stdout := new(bytes.Buffer)
+		root.SilenceErrors = true
+		root.SilenceUsage = true
+		root.SetOut(stdout)
+		root.SetErr(new(bytes.Buffer))
+		// The fake runners wait for each other, so every script needs a slot whatever the number of CPUs
+		root.SetArgs(append([]string{"run", "--cwd", dir + "/", "--parallel", "--concurrency", "8"}, args...))
+		err := root.Execute()
+		return stdout.String(), err
+	}
+
+	BeforeEach(func() {
+		mockRunner = mock.NewMockCommandRunner()
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+
+		dir = GinkgoT().TempDir()
+		assert.NoError(os.WriteFile(filepath.Join(dir, "package.json"), []byte(`{
+  "scripts": {
+    "dev:server": "node server.js",
+    "dev:client": "vite",
+    "lint": "eslint ."
+  }
+}`), 0o644))
+	})
+
+	It("launches every listed script concurrently with prefixed output", func() {
+		recorder := &testutil.BlockingScripts{Together: 2, FinishIn: time.Millisecond, Output: "hello from %s\nready"}
+		root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.NewParallelCommandRunner = recorder.NewRunner
+		})
+
+		output, err := executeParallel(root, "dev:server", "dev:client")
+		assert.NoError(err)
+
+		commands := recorder.Commands()
+		sort.Slice(commands, func(i, j int) bool {
+			return commands[i][2] < commands[j][2]
+		})
+		assert.Equal([][]string{
+			{"npm", "run", "dev:client"},
+			{"npm", "run", "dev:server"},
+		}, commands)
+
+		assert.Contains(output, "[dev:server] hello from dev:server\n")
+		assert.Contains(output, "[dev:client] hello from dev:client\n")
+		// Output without a trailing newline is still flushed with its prefix
+		assert.Contains(output, "[dev:server] ready\n")
+		assert.False(mockRunner.HasBeenCalled, "the scripts must not go through the shared runner")
+	})
+
+	It("errors on an unknown script before running anything", func() {
+		recorder := &testutil.BlockingScripts{Together: 2, FinishIn: time.Millisecond, Output: "hello from %s\nready"}
+		root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.NewParallelCommandRunner = recorder.NewRunner
+		})
+
+		_, err := executeParallel(root, "dev:server", "missing")
+		assert.ErrorContains(err, `script "missing" was not found in package.json`)
+		assert.Empty(recorder.Commands())
+	})
+
+	It("stops the other scripts once one fails", func() {
+		recorder := &testutil.BlockingScripts{
+			Together: 3,
+			FinishIn: 200 * time.Millisecond,
+			Output:   "hello from %s\nready",
+			Fail:     map[string]error{"lint": fmt.Errorf("exit status 2")},
+		}
+		root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.NewParallelCommandRunner = recorder.NewRunner
+		})
+
+		_, err := executeParallel(root, "dev:server", "dev:client", "lint")
+		assert.EqualError(err, "script lint failed: exit status 2")
+		assert.ElementsMatch([]string{"dev:server", "dev:client"}, recorder.Stopped())
+	})
+
+	It("keeps the other scripts running with --continue-on-error", func() {
+		recorder := &testutil.BlockingScripts{
+			Together: 3,
+			FinishIn: 200 * time.Millisecond,
+			Output:   "hello from %s\nready",
+			Fail:     map[string]error{"lint": fmt.Errorf("exit status 2")},
+		}
+		root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.NewParallelCommandRunner = recorder.NewRunner
+		})
+
+		_, err := executeParallel(root, "--continue-on-error", "dev:server", "dev:client", "lint")
+		assert.EqualError(err, "script lint failed: exit status 2")
+		assert.Empty(recorder.Stopped())
+	})
+
+	It("requires at least one script name", func() {
+		root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.NewParallelCommandRunner = (&testutil.BlockingScripts{}).NewRunner
+		})
+
+		_, err := executeParallel(root)
+		assert.ErrorContains(err, "the --parallel flag requires at least one script name")
+	})
+
+	Context("--concurrency", func() {
+		BeforeEach(func() {
+			assert.NoError(os.WriteFile(filepath.Join(dir, "package.json"), []byte(`{
+  "scripts": {"a": "a", "b": "b", "c": "c", "d": "d", "e": "e"}
+}`), 0o644))
+		})
+
+		It("runs no more than n scripts at the same time", func() {
+			recorder := &testutil.BlockingScripts{FinishIn: 20 * time.Millisecond}
+			root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+				deps.NewParallelCommandRunner = recorder.NewRunner
+			})
+
+			_, err := executeParallel(root, "--concurrency", "2", "a", "b", "c", "d", "e")
+			assert.NoError(err)
+			assert.ElementsMatch([]string{"a", "b", "c", "d", "e"}, recorder.Scripts())
+			assert.Equal(2, recorder.MaxRunning())
+		})
+
+		It("runs every script when there are fewer scripts than n", func() {
+			recorder := &testutil.BlockingScripts{Together: 3, FinishIn: time.Millisecond, Output: "hello from %s\nready"}
+			root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+				deps.NewParallelCommandRunner = recorder.NewRunner
+			})
+
+			_, err := executeParallel(root, "--concurrency", "10", "a", "b", "c")
+			assert.NoError(err)
+			assert.Len(recorder.Commands(), 3)
+		})
+
+		It("does not start waiting scripts once one fails", func() {
+			// Whichever script gets the only slot fails
+			recorder := &testutil.BlockingScripts{
+				Together: 1,
+				Fail: map[string]error{
+					"a": fmt.Errorf("exit status 1"),
+					"b": fmt.Errorf("exit status 1"),
+					"c": fmt.Errorf("exit status 1"),
+				},
+			}
+			root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+				deps.NewParallelCommandRunner = recorder.NewRunner
+			})
+
+			_, err := executeParallel(root, "--concurrency", "1", "a", "b", "c")
+			assert.ErrorContains(err, "failed: exit status 1")
+			assert.Len(recorder.Commands(), 1)
+		})
+
+		It("rejects a concurrency below 1", func() {
+			recorder := &testutil.BlockingScripts{FinishIn: 20 * time.Millisecond}
+			root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+				deps.NewParallelCommandRunner = recorder.NewRunner
+			})
+
+			_, err := executeParallel(root, "--concurrency", "0", "a", "b")
+			assert.ErrorContains(err, "the --concurrency flag must be greater than 0, got: 0")
+			assert.Empty(recorder.Scripts())
+		})
+	})
+})
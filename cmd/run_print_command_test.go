@@ -0,0 +1,53 @@
+package cmd_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/louiss0/javascript-package-delegator/mock"
+	"github.com/louiss0/javascript-package-delegator/testutil"
+)
+
+var _ = Describe("Run Command --print-command", func() {
+	assert := assert.New(GinkgoT())
+
+	var (
+		mockRunner *mock.MockCommandRunner
+		factory    *testutil.RootCommandFactory
+		targetDir  string
+	)
+
+	BeforeEach(func() {
+		mockRunner = mock.NewMockCommandRunner()
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+
+		targetDir = GinkgoT().TempDir()
+		assert.NoError(os.WriteFile(filepath.Join(targetDir, "package.json"), []byte(`{"scripts": {"build": "vite build"}}`), 0644))
+	})
+
+	It("prints the resolved command without running it", func() {
+		output, err := executeCmd(factory.CreatePnpmAsDefault(nil), "--cwd", targetDir+"/", "run", "build", "--print-command", "--", "--mode", "production")
+		assert.NoError(err)
+		assert.Equal("pnpm run build -- --mode production\n", output)
+		assert.False(mockRunner.HasBeenCalled)
+	})
+
+	It("quotes arguments the shell would split", func() {
+		output, err := executeCmd(factory.CreateYarnOneAsDefault(nil), "--cwd", targetDir+"/", "run", "build", "--print-command", "--", "--title", "it's here")
+		assert.NoError(err)
+		assert.Equal(`yarn run build --title 'it'\''s here'`+"\n", output)
+		assert.False(mockRunner.HasBeenCalled)
+	})
+
+	It("requires a script name", func() {
+		_, err := executeCmd(factory.CreateNpmAsDefault(nil), "--cwd", targetDir+"/", "run", "--print-command")
+		assert.ErrorContains(err, "the --print-command flag requires a script name")
+	})
+})
@@ -0,0 +1,66 @@
+package cmd_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/louiss0/javascript-package-delegator/mock"
+	"github.com/louiss0/javascript-package-delegator/testutil"
+)
+
+var _ = Describe("Run Command --recursive", func() {
+	assert := assert.New(GinkgoT())
+
+	var (
+		mockRunner *mock.MockCommandRunner
+		factory    *testutil.RootCommandFactory
+		targetDir  string
+	)
+
+	BeforeEach(func() {
+		mockRunner = mock.NewMockCommandRunner()
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+
+		// The root of a monorepo usually has no build script of its own
+		targetDir = GinkgoT().TempDir()
+		assert.NoError(os.WriteFile(filepath.Join(targetDir, "package.json"), []byte(`{"private": true}`), 0644))
+	})
+
+	DescribeTable("translates the recursive run",
+		func(newRootCmd func(*testutil.RootCommandFactory, error) *cobra.Command, flag string, expected []string) {
+			_, err := executeCmd(newRootCmd(factory, nil), "--cwd", targetDir+"/", "run", "build", flag)
+			assert.NoError(err)
+			assert.True(mockRunner.HasCommand(expected[0], expected[1:]...), "got %v", mockRunner.CommandCall)
+		},
+		Entry("pnpm", (*testutil.RootCommandFactory).CreatePnpmAsDefault, "-r", []string{"pnpm", "-r", "run", "build"}),
+		Entry("yarn v2+", (*testutil.RootCommandFactory).CreateYarnTwoAsDefault, "--recursive", []string{"yarn", "workspaces", "foreach", "--all", "run", "build"}),
+		Entry("npm", (*testutil.RootCommandFactory).CreateNpmAsDefault, "-r", []string{"npm", "--workspaces", "run", "build"}),
+		Entry("bun", (*testutil.RootCommandFactory).CreateBunAsDefault, "-r", []string{"bun", "--filter", "*", "run", "build"}),
+	)
+
+	It("forwards the script arguments and --if-present without checking the root manifest", func() {
+		_, err := executeCmd(factory.CreatePnpmAsDefault(nil), "--cwd", targetDir+"/", "run", "build", "-r", "--if-present", "--", "--mode", "production")
+		assert.NoError(err)
+		assert.True(mockRunner.HasCommand("pnpm", "-r", "run", "--if-present", "build", "--mode", "production"))
+	})
+
+	It("explains the yarn v1 alternative", func() {
+		_, err := executeCmd(factory.CreateYarnOneAsDefault(nil), "--cwd", targetDir+"/", "run", "build", "-r")
+		assert.ErrorContains(err, "the --recursive flag requires yarn v2 or newer, yarn v1 runs a script in every workspace with 'yarn workspaces run build'")
+		assert.False(mockRunner.HasBeenCalled)
+	})
+
+	It("returns an error for deno", func() {
+		_, err := executeCmd(factory.CreateDenoAsDefault(nil), "--cwd", targetDir+"/", "run", "build", "-r")
+		assert.ErrorContains(err, "deno does not support the --recursive flag")
+		assert.False(mockRunner.HasBeenCalled)
+	})
+})
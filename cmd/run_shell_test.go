@@ -0,0 +1,72 @@
+package cmd_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/louiss0/javascript-package-delegator/mock"
+	"github.com/louiss0/javascript-package-delegator/testutil"
+)
+
+var _ = Describe("Run Command --shell", func() {
+	assert := assert.New(GinkgoT())
+
+	var (
+		mockRunner *mock.MockCommandRunner
+		factory    *testutil.RootCommandFactory
+		targetDir  string
+	)
+
+	BeforeEach(func() {
+		mockRunner = mock.NewMockCommandRunner()
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+
+		targetDir = GinkgoT().TempDir()
+		assert.NoError(os.WriteFile(filepath.Join(targetDir, "package.json"), []byte(`{"scripts": {"build": "vite build"}}`), 0644))
+		assert.NoError(os.WriteFile(filepath.Join(targetDir, "deno.json"), []byte(`{"tasks": {"build": "deno run build.ts"}}`), 0644))
+	})
+
+	It("appends --script-shell for npm", func() {
+		_, err := executeCmd(factory.CreateNpmAsDefault(nil), "--cwd", targetDir+"/", "run", "build", "--shell", "bash")
+		assert.NoError(err)
+		assert.True(mockRunner.HasCommand("npm", "run", "build", "--script-shell", "bash"), "got %v", mockRunner.CommandCall)
+	})
+
+	It("keeps the script arguments after the npm flag", func() {
+		_, err := executeCmd(factory.CreateNpmAsDefault(nil), "--cwd", targetDir+"/", "run", "build", "--shell", "/bin/bash", "--", "--mode", "production")
+		assert.NoError(err)
+		assert.True(mockRunner.HasCommand("npm", "run", "build", "--script-shell", "/bin/bash", "--", "--mode", "production"), "got %v", mockRunner.CommandCall)
+	})
+
+	It("sets the script-shell config for pnpm", func() {
+		_, err := executeCmd(factory.CreatePnpmAsDefault(nil), "--cwd", targetDir+"/", "run", "build", "--shell", "bash")
+		assert.NoError(err)
+		assert.True(mockRunner.HasCommand("pnpm", "run", "--config.script-shell=bash", "build"), "got %v", mockRunner.CommandCall)
+	})
+
+	DescribeTable("returns an error for the package managers that run scripts with their own shell",
+		func(newRootCmd func(*testutil.RootCommandFactory, error) *cobra.Command, message string) {
+			_, err := executeCmd(newRootCmd(factory, nil), "--cwd", targetDir+"/", "run", "build", "--shell", "bash")
+			assert.ErrorContains(err, message)
+			assert.False(mockRunner.HasBeenCalled)
+		},
+		Entry("yarn v1", (*testutil.RootCommandFactory).CreateYarnOneAsDefault, "yarn v1 reads script-shell from .yarnrc ('yarn config set script-shell bash')"),
+		Entry("yarn v2+", (*testutil.RootCommandFactory).CreateYarnTwoAsDefault, "yarn v2+ always runs scripts with its own shell"),
+		Entry("bun", (*testutil.RootCommandFactory).CreateBunAsDefault, "bun runs scripts with its own shell, it has no --shell flag"),
+		Entry("deno", (*testutil.RootCommandFactory).CreateDenoAsDefault, "deno task runs tasks with its own shell, it has no --shell flag"),
+	)
+
+	It("returns an error for an empty shell", func() {
+		_, err := executeCmd(factory.CreateNpmAsDefault(nil), "--cwd", targetDir+"/", "run", "build", "--shell", "")
+		assert.ErrorContains(err, "the --shell flag needs the name or path of a shell")
+		assert.False(mockRunner.HasBeenCalled)
+	})
+})
@@ -0,0 +1,56 @@
+package cmd_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/louiss0/javascript-package-delegator/mock"
+	"github.com/louiss0/javascript-package-delegator/testutil"
+)
+
+var _ = Describe("Run Command --silent", func() {
+	assert := assert.New(GinkgoT())
+
+	var (
+		mockRunner *mock.MockCommandRunner
+		factory    *testutil.RootCommandFactory
+		targetDir  string
+	)
+
+	BeforeEach(func() {
+		mockRunner = mock.NewMockCommandRunner()
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+
+		targetDir = GinkgoT().TempDir()
+		assert.NoError(os.WriteFile(filepath.Join(targetDir, "package.json"), []byte(`{"scripts": {"build": "vite build"}}`), 0644))
+		assert.NoError(os.WriteFile(filepath.Join(targetDir, "deno.json"), []byte(`{"tasks": {"build": "deno run build.ts"}}`), 0644))
+	})
+
+	// npm takes the flag after the script name, pnpm and bun after run and yarn before run
+	DescribeTable("places the quiet flag of each package manager",
+		func(newRootCmd func(*testutil.RootCommandFactory, error) *cobra.Command, expected []string) {
+			_, err := executeCmd(newRootCmd(factory, nil), "--cwd", targetDir+"/", "run", "build", "--silent", "--", "--mode", "production")
+			assert.NoError(err)
+			assert.True(mockRunner.HasCommand(expected[0], expected[1:]...), "got %v", mockRunner.CommandCall)
+		},
+		Entry("npm", (*testutil.RootCommandFactory).CreateNpmAsDefault, []string{"npm", "run", "build", "--silent", "--", "--mode", "production"}),
+		Entry("pnpm", (*testutil.RootCommandFactory).CreatePnpmAsDefault, []string{"pnpm", "run", "--silent", "build", "--", "--mode", "production"}),
+		Entry("yarn", (*testutil.RootCommandFactory).CreateYarnTwoAsDefault, []string{"yarn", "--silent", "run", "build", "--mode", "production"}),
+		Entry("bun", (*testutil.RootCommandFactory).CreateBunAsDefault, []string{"bun", "run", "--silent", "build", "--mode", "production"}),
+		Entry("deno", (*testutil.RootCommandFactory).CreateDenoAsDefault, []string{"deno", "task", "--quiet", "build", "--mode", "production"}),
+	)
+
+	It("keeps the flag in place for a recursive run", func() {
+		_, err := executeCmd(factory.CreatePnpmAsDefault(nil), "--cwd", targetDir+"/", "run", "build", "--silent", "-r")
+		assert.NoError(err)
+		assert.True(mockRunner.HasCommand("pnpm", "-r", "run", "--silent", "build"))
+	})
+})
@@ -0,0 +1,52 @@
+// Package cmd provides command-line interface implementations for the JavaScript package delegator.
+package cmd
+
+import (
+	// standard library
+	"fmt"
+
+	// external
+	"github.com/samber/lo"
+
+	// internal
+	"github.com/louiss0/javascript-package-delegator/detect"
+)
+
+const _TASK_RUNNER_FLAG = "task-runner"
+
+const (
+	_TASK_RUNNER_JUST = "just"
+	_TASK_RUNNER_TASK = "task"
+)
+
+// taskRunners are the values of run --task-runner, npm and deno run the script through that package manager.
+var taskRunners = []string{detect.NPM, detect.DENO, _TASK_RUNNER_JUST, _TASK_RUNNER_TASK}
+
+// validateTaskRunner returns an error when runner isn't one of the task runners run knows.
+func validateTaskRunner(runner string) error {
+	if !lo.Contains(taskRunners, runner) {
+		return fmt.Errorf("the --%s flag must be one of %v, got: %s", _TASK_RUNNER_FLAG, taskRunners, runner)
+	}
+	return nil
+}
+
+// isExternalTaskRunner reports whether runner runs recipes outside of any package manager.
+func isExternalTaskRunner(runner string) bool {
+	return runner == _TASK_RUNNER_JUST || runner == _TASK_RUNNER_TASK
+}
+
+// buildTaskRunnerArgs builds the arguments that make just or task run the recipe or task named script.
+// task only hands the arguments after -- to the task as CLI_ARGS, just takes them as recipe parameters.
+func buildTaskRunnerArgs(runner, script string, scriptArgs []string) []string {
+	if runner == _TASK_RUNNER_TASK && len(scriptArgs) > 0 {
+		return lo.Flatten([][]string{{script, "--"}, scriptArgs})
+	}
+	return append([]string{script}, scriptArgs...)
+}
+
+// taskRunnerConflicts are the run flags that only a package manager understands.
+var taskRunnerConflicts = []string{
+	_LIST_FLAG, _LIST_JSON_FLAG, "if-present", _WATCH_FLAG, _PARALLEL_FLAG, _TIMEOUT_FLAG, _ATTEMPTS_FLAG,
+	_CASCADE_FLAG, _RECURSIVE_FLAG, _SHELL_FLAG, _SILENT_FLAG, _BEFORE_FLAG, _AFTER_FLAG,
+	_RESUME_FROM_FLAG, _CAPTURE_FLAG, _CAPTURE_LIMIT_FLAG,
+}
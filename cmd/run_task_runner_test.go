@@ -0,0 +1,89 @@
+package cmd_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/louiss0/javascript-package-delegator/mock"
+	"github.com/louiss0/javascript-package-delegator/testutil"
+)
+
+var _ = Describe("Run Command --task-runner", func() {
+	assert := assert.New(GinkgoT())
+
+	var (
+		mockRunner *mock.MockCommandRunner
+		factory    *testutil.RootCommandFactory
+		targetDir  string
+	)
+
+	BeforeEach(func() {
+		mockRunner = mock.NewMockCommandRunner()
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+
+		targetDir = GinkgoT().TempDir()
+		assert.NoError(os.WriteFile(filepath.Join(targetDir, "package.json"), []byte(`{"scripts": {"build": "vite build"}}`), 0644))
+		assert.NoError(os.WriteFile(filepath.Join(targetDir, "deno.json"), []byte(`{"tasks": {"build": "deno run build.ts"}}`), 0644))
+	})
+
+	It("runs the script through the detected package manager by default", func() {
+		_, err := executeCmd(factory.CreatePnpmAsDefault(nil), "--cwd", targetDir+"/", "run", "build")
+		assert.NoError(err)
+		assert.True(mockRunner.HasCommand("pnpm", "run", "build"), "got %v", mockRunner.CommandCall)
+	})
+
+	It("delegates to just", func() {
+		_, err := executeCmd(factory.CreateNpmAsDefault(nil), "--cwd", targetDir+"/", "run", "build", "--task-runner", "just")
+		assert.NoError(err)
+		assert.True(mockRunner.HasCommand("just", "build"), "got %v", mockRunner.CommandCall)
+	})
+
+	It("passes the arguments to just as recipe parameters", func() {
+		_, err := executeCmd(factory.CreateNpmAsDefault(nil), "--cwd", targetDir+"/", "run", "build", "--task-runner", "just", "--", "release")
+		assert.NoError(err)
+		assert.True(mockRunner.HasCommand("just", "build", "release"), "got %v", mockRunner.CommandCall)
+	})
+
+	It("delegates to task and hands it the arguments after --", func() {
+		_, err := executeCmd(factory.CreateNpmAsDefault(nil), "--cwd", targetDir+"/", "run", "build", "--task-runner", "task", "--", "--verbose")
+		assert.NoError(err)
+		assert.True(mockRunner.HasCommand("task", "build", "--", "--verbose"), "got %v", mockRunner.CommandCall)
+	})
+
+	It("runs the deno task when it is deno", func() {
+		_, err := executeCmd(factory.CreateNpmAsDefault(nil), "--cwd", targetDir+"/", "run", "build", "--task-runner", "deno")
+		assert.NoError(err)
+		assert.True(mockRunner.HasCommand("deno", "task", "build"), "got %v", mockRunner.CommandCall)
+	})
+
+	It("prints the command of the task runner", func() {
+		output, err := executeCmd(factory.CreateNpmAsDefault(nil), "--cwd", targetDir+"/", "run", "build", "--task-runner", "just", "--print-command")
+		assert.NoError(err)
+		assert.Equal("just build\n", output)
+		assert.False(mockRunner.HasBeenCalled)
+	})
+
+	It("returns an error for a task runner it doesn't know", func() {
+		_, err := executeCmd(factory.CreateNpmAsDefault(nil), "--cwd", targetDir+"/", "run", "build", "--task-runner", "make")
+		assert.ErrorContains(err, "the --task-runner flag must be one of [npm deno just task], got: make")
+		assert.False(mockRunner.HasBeenCalled)
+	})
+
+	It("returns an error for the flags only a package manager understands", func() {
+		_, err := executeCmd(factory.CreateNpmAsDefault(nil), "--cwd", targetDir+"/", "run", "build", "--task-runner", "just", "--silent")
+		assert.ErrorContains(err, "the --silent flag cannot be combined with --task-runner just")
+		assert.False(mockRunner.HasBeenCalled)
+	})
+
+	It("returns an error when no script is named", func() {
+		_, err := executeCmd(factory.CreateNpmAsDefault(nil), "--cwd", targetDir+"/", "run", "--task-runner", "task")
+		assert.ErrorContains(err, "the --task-runner flag requires a script name when it is task")
+	})
+})
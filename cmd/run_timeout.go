@@ -0,0 +1,72 @@
+// Package cmd provides command-line interface implementations for the JavaScript package delegator.
+package cmd
+
+import (
+	// standard library
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+const _TIMEOUT_FLAG = "timeout"
+
+// ErrScriptTimedOut is returned by run --timeout when the script was stopped for running too long.
+var ErrScriptTimedOut = errors.New("script timed out")
+
+// runScriptWithTimeout runs the script on a runner of its own whose command is killed once timeout passes.
+// Only the script itself is timed, the scripts run before it use the runner of the command as usual.
+func runScriptWithTimeout(
+	ctx context.Context,
+	stdout, stderr io.Writer,
+	newRunner ParallelCommandRunnerFactory,
+	targetDir string,
+	env []string,
+	cleanEnv bool,
+	timeout time.Duration,
+	pm string,
+	args []string,
+) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	runner, err := newScriptRunner(ctx, stdout, stderr, newRunner, targetDir, env, cleanEnv, pm, args)
+	if err != nil {
+		return err
+	}
+
+	err = runner.Run()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%w after %s", ErrScriptTimedOut, timeout)
+	}
+	return err
+}
+
+// newScriptRunner creates a runner of its own for the script, set up like the runner of the command.
+func newScriptRunner(
+	ctx context.Context,
+	stdout, stderr io.Writer,
+	newRunner ParallelCommandRunnerFactory,
+	targetDir string,
+	env []string,
+	cleanEnv bool,
+	pm string,
+	args []string,
+) (CommandRunner, error) {
+	runner := newRunner(ctx, stdout, stderr)
+	if targetDir != "" {
+		if err := runner.SetTargetDir(targetDir); err != nil {
+			return nil, err
+		}
+	}
+	if cleanEnv {
+		runner.ClearEnv()
+	}
+	if len(env) > 0 {
+		runner.SetEnv(env)
+	}
+	runner.Command(pm, args...)
+
+	return runner, nil
+}
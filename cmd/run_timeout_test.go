@@ -0,0 +1,117 @@
+package cmd_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/louiss0/javascript-package-delegator/cmd"
+	"github.com/louiss0/javascript-package-delegator/mock"
+	"github.com/louiss0/javascript-package-delegator/testutil"
+)
+
+var _ = Describe("Run --timeout", func() {
+	assert := assert.New(GinkgoT())
+
+	var (
+		mockRunner *mock.MockCommandRunner
+		factory    *testutil.RootCommandFactory
+		dir        string
+		scripts    *testutil.BlockingScripts
+		stderr     *bytes.Buffer
+	)
+
+	// executeTimed keeps the error chain that executeCmd flattens into a message
+	executeTimed := func(args ...string) error {
+		root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.NewParallelCommandRunner = scripts.NewRunner
+		})
+		root.SilenceErrors = true
+		root.SilenceUsage = true
+		stderr = new(bytes.Buffer)
+		root.SetOut(new(bytes.Buffer))
+		root.SetErr(stderr)
+		root.SetArgs(append([]string{"run", "--cwd", dir + "/"}, args...))
+		return root.Execute()
+	}
+
+	BeforeEach(func() {
+		mockRunner = mock.NewMockCommandRunner()
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+
+		scripts = &testutil.BlockingScripts{}
+		dir = GinkgoT().TempDir()
+		assert.NoError(os.WriteFile(filepath.Join(dir, "package.json"), []byte(`{
+  "scripts": {
+    "pretest": "tsc",
+    "test": "vitest run"
+  }
+}`), 0o644))
+	})
+
+	It("stops a hung script with a script timed out error", func() {
+		start := time.Now()
+		err := executeTimed("test", "--timeout", "50ms")
+		assert.ErrorIs(err, cmd.ErrScriptTimedOut)
+		assert.ErrorContains(err, "script timed out after 50ms")
+		assert.Less(time.Since(start), 2*time.Second)
+		assert.Equal([][]string{{"npm", "run", "test"}}, scripts.Commands())
+		assert.Equal([]string{dir + "/"}, scripts.Dirs())
+	})
+
+	It("only times the script, the --before script runs as usual", func() {
+		err := executeTimed("test", "--before", "pretest", "--timeout", "50ms")
+		assert.ErrorIs(err, cmd.ErrScriptTimedOut)
+		assert.Equal([]mock.CommandCall{{Name: "npm", Args: []string{"run", "pretest"}}}, mockRunner.CommandHistory())
+		assert.True(mockRunner.HasBeenCalled)
+	})
+
+	It("returns the result of a script that finishes in time", func() {
+		scripts.FinishIn = 10 * time.Millisecond
+
+		err := executeTimed("test", "--timeout", "5s")
+		assert.NoError(err)
+		assert.Equal([][]string{{"npm", "run", "test"}}, scripts.Commands())
+	})
+
+	It("runs the script on the usual runner without the flag", func() {
+		err := executeTimed("test")
+		assert.NoError(err)
+		assert.True(mockRunner.HasCommand("npm", "run", "test"))
+		assert.Empty(scripts.Commands())
+	})
+
+	It("starts the timed script with the --manager-path binary", func() {
+		scripts.FinishIn = 10 * time.Millisecond
+		managerPath := filepath.Join(GinkgoT().TempDir(), "npm-custom")
+		assert.NoError(os.WriteFile(managerPath, []byte("#!/bin/sh\n"), 0o755))
+
+		err := executeTimed("test", "--manager-path", managerPath, "--timeout", "5s")
+		assert.NoError(err)
+		assert.Equal([][]string{{managerPath, "run", "test"}}, scripts.Commands())
+	})
+
+	It("reports the timed script with --reporter ndjson", func() {
+		scripts.FinishIn = 10 * time.Millisecond
+
+		err := executeTimed("test", "--reporter", "ndjson", "--timeout", "5s")
+		assert.NoError(err)
+		assert.Contains(stderr.String(), `"event":"exec"`)
+		assert.Contains(stderr.String(), `"argv":["run","test"]`)
+		assert.Contains(stderr.String(), `"event":"done"`)
+	})
+
+	It("rejects a negative duration", func() {
+		err := executeTimed("test", "--timeout", "-1s")
+		assert.ErrorContains(err, "the --timeout flag must not be negative, got: -1s")
+		assert.False(mockRunner.HasBeenCalled)
+	})
+})
@@ -0,0 +1,128 @@
+package cmd_test
+
+import (
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/stretchr/testify/assert"
+	tmock "github.com/stretchr/testify/mock"
+
+	"github.com/louiss0/javascript-package-delegator/cmd"
+	"github.com/louiss0/javascript-package-delegator/detect"
+	"github.com/louiss0/javascript-package-delegator/mock"
+	"github.com/louiss0/javascript-package-delegator/testutil"
+)
+
+var _ = Describe("Run Command watch mode", func() {
+	assert := assert.New(GinkgoT())
+
+	var (
+		mockRunner *mock.MockCommandRunner
+		factory    *testutil.RootCommandFactory
+		projectDir string
+		// onRun is called while the script runs, like a build writing its output
+		onRun func()
+	)
+
+	BeforeEach(func() {
+		onRun = nil
+		mockRunner = mock.NewMockCommandRunner()
+		mockRunner.On("Run", "npm", []string{"run", "build"}, tmock.Anything).
+			Run(func(tmock.Arguments) {
+				if onRun != nil {
+					onRun()
+				}
+			}).
+			Return(nil).Maybe()
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+		testutil.DebugExecutorExpectationManager.ExpectCommonPMDetectionFlow(detect.NPM, detect.PACKAGE_LOCK_JSON)
+		testutil.DebugExecutorExpectationManager.ExpectJSCommandLog("npm", "run", "build")
+		projectDir = GinkgoT().TempDir()
+	})
+
+	It("runs the script again after a file changes", func() {
+		watcher := mock.NewMockFileWatcher(filepath.Join(projectDir, "src", "index.ts"))
+		root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.NewFileWatcher = func(string) (cmd.FileWatcher, error) {
+				return watcher, nil
+			}
+		})
+
+		output, err := executeCmd(root, "run", "build", "--watch", "--cwd", projectDir+"/")
+		assert.NoError(err)
+		assert.Contains(output, "Change detected, running build again")
+		mockRunner.AssertNumberOfCalls(GinkgoT(), "Run", 2)
+		assert.True(mockRunner.HasCommand("npm", "run", "build"))
+		assert.True(watcher.Closed)
+	})
+
+	It("collapses a burst of changes into one run", func() {
+		watcher := mock.NewMockFileWatcher(
+			filepath.Join(projectDir, "src", "a.ts"),
+			filepath.Join(projectDir, "src", "b.ts"),
+			filepath.Join(projectDir, "src", "c.ts"),
+		)
+		root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.NewFileWatcher = func(string) (cmd.FileWatcher, error) {
+				return watcher, nil
+			}
+		})
+
+		_, err := executeCmd(root, "run", "build", "--watch", "--cwd", projectDir+"/")
+		assert.NoError(err)
+		mockRunner.AssertNumberOfCalls(GinkgoT(), "Run", 2)
+	})
+
+	It("ignores changes inside node_modules and .git", func() {
+		watcher := mock.NewMockFileWatcher(
+			filepath.Join(projectDir, "node_modules", "react", "index.js"),
+			filepath.Join(projectDir, ".git", "index"),
+		)
+		root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.NewFileWatcher = func(string) (cmd.FileWatcher, error) {
+				return watcher, nil
+			}
+		})
+
+		output, err := executeCmd(root, "run", "build", "--watch", "--cwd", projectDir+"/")
+		assert.NoError(err)
+		assert.NotContains(output, "Change detected")
+		mockRunner.AssertNumberOfCalls(GinkgoT(), "Run", 1)
+	})
+
+	It("doesn't run the script again for the changes it makes itself", func() {
+		watcher := mock.NewMockFileWatcher()
+		onRun = func() {
+			watcher.Emit(filepath.Join(projectDir, "dist", "index.js"))
+			watcher.Emit(filepath.Join(projectDir, "coverage", "lcov.info"))
+		}
+		root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.NewFileWatcher = func(string) (cmd.FileWatcher, error) {
+				return watcher, nil
+			}
+		})
+
+		output, err := executeCmd(root, "run", "build", "--watch", "--cwd", projectDir+"/")
+		assert.NoError(err)
+		assert.NotContains(output, "Change detected")
+		mockRunner.AssertNumberOfCalls(GinkgoT(), "Run", 1)
+	})
+
+	It("runs the script once without --watch", func() {
+		watcher := mock.NewMockFileWatcher(filepath.Join(projectDir, "src", "index.ts"))
+		root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.NewFileWatcher = func(string) (cmd.FileWatcher, error) {
+				return watcher, nil
+			}
+		})
+
+		_, err := executeCmd(root, "run", "build", "--cwd", projectDir+"/")
+		assert.NoError(err)
+		mockRunner.AssertNumberOfCalls(GinkgoT(), "Run", 1)
+		assert.False(watcher.Closed)
+	})
+})
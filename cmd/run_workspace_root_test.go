@@ -0,0 +1,81 @@
+package cmd_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/louiss0/javascript-package-delegator/mock"
+	"github.com/louiss0/javascript-package-delegator/testutil"
+)
+
+var _ = Describe("Run Command workspace root", func() {
+	assert := assert.New(GinkgoT())
+
+	var (
+		mockRunner *mock.MockCommandRunner
+		factory    *testutil.RootCommandFactory
+		root       string
+		packageDir string
+	)
+
+	BeforeEach(func() {
+		mockRunner = mock.NewMockCommandRunner()
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+
+		// Only the root of the monorepo defines lint
+		root = GinkgoT().TempDir()
+		packageDir = filepath.Join(root, "packages", "ui")
+		assert.NoError(os.MkdirAll(packageDir, 0o755))
+		assert.NoError(os.WriteFile(
+			filepath.Join(root, "package.json"),
+			[]byte(`{"private": true, "workspaces": ["packages/*"], "scripts": {"lint": "eslint ."}}`),
+			0o644,
+		))
+		assert.NoError(os.WriteFile(
+			filepath.Join(packageDir, "package.json"),
+			[]byte(`{"name": "@acme/ui", "scripts": {"build": "vite build"}}`),
+			0o644,
+		))
+	})
+
+	It("points at -w when only the workspace root defines the script", func() {
+		_, err := executeCmd(factory.CreateNpmAsDefault(nil), "--cwd", packageDir+"/", "run", "lint")
+		assert.ErrorContains(err, "script 'lint' not found here; it exists in the workspace root "+root+", run it with -w/--workspace-root")
+		assert.False(mockRunner.HasBeenCalled)
+	})
+
+	It("runs the script from the workspace root with -w", func() {
+		_, err := executeCmd(factory.CreatePnpmAsDefault(nil), "--cwd", packageDir+"/", "run", "lint", "-w")
+		assert.NoError(err)
+		assert.True(mockRunner.HasCommand("pnpm", "run", "lint"))
+		assert.Equal(root, mockRunner.WorkingDir)
+	})
+
+	It("runs a script of the package as usual", func() {
+		_, err := executeCmd(factory.CreateNpmAsDefault(nil), "--cwd", packageDir+"/", "run", "build")
+		assert.NoError(err)
+		assert.True(mockRunner.HasCommand("npm", "run", "build"))
+	})
+
+	It("leaves a script nobody defines to the package manager", func() {
+		_, err := executeCmd(factory.CreateNpmAsDefault(nil), "--cwd", packageDir+"/", "run", "test")
+		assert.NoError(err)
+		assert.True(mockRunner.HasCommand("npm", "run", "test"))
+	})
+
+	It("returns an error for -w outside a monorepo", func() {
+		projectDir := GinkgoT().TempDir()
+		assert.NoError(os.WriteFile(filepath.Join(projectDir, "package.json"), []byte(`{"scripts": {"lint": "eslint ."}}`), 0o644))
+
+		_, err := executeCmd(factory.CreateNpmAsDefault(nil), "--cwd", projectDir+"/", "run", "lint", "-w")
+		assert.ErrorContains(err, "the --workspace-root flag found no workspace root")
+		assert.False(mockRunner.HasBeenCalled)
+	})
+})
@@ -0,0 +1,165 @@
+// Package cmd provides command-line interface implementations for the JavaScript package delegator.
+package cmd
+
+import (
+	// standard library
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	// external
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+)
+
+const _FILTER_FLAG = "filter"
+
+// ScriptEntry is a script of package.json or a task of deno.json.
+// Group is the part of the name before the first colon, or the whole name when it has none.
+type ScriptEntry struct {
+	Name    string `json:"name"`
+	Group   string `json:"group"`
+	Command string `json:"command"`
+}
+
+// NewScriptsCmd creates a new Cobra command that prints the scripts of package.json
+// or the tasks of deno.json grouped by the prefix of their names.
+// It only reads the manifest, no package manager command is executed.
+func NewScriptsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "scripts",
+		Aliases: []string{"tasks"},
+		Short:   "Print the scripts of package.json or the tasks of deno.json",
+		Long: `Print the scripts of package.json, or the tasks of deno.json for deno, with their commands.
+Scripts are grouped by the part of their name before the first colon, so dev:server
+and dev:client are printed under dev.
+
+The --filter flag keeps the scripts whose name fuzzy matches it, that is contains its
+characters in order, or whose command contains it. Case is ignored.
+
+Examples:
+  jpd scripts                # Print every script grouped by prefix
+  jpd scripts --filter dev   # Print the scripts matching dev
+  jpd scripts --filter bld   # Fuzzy matching finds build and build:ssr
+  jpd tasks --json           # Print the scripts as JSON`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pm, _ := cmd.Flags().GetString(AGENT_FLAG)
+
+			filter, err := cmd.Flags().GetString(_FILTER_FLAG)
+			if err != nil {
+				return err
+			}
+
+			asJSON, err := cmd.Flags().GetBool(_JSON_FLAG)
+			if err != nil {
+				return err
+			}
+
+			targetDir, err := cmd.Flags().GetString(_CWD_FLAG)
+			if err != nil {
+				return fmt.Errorf("failed to parse --%s flag: %w", _CWD_FLAG, err)
+			}
+			if targetDir == "" {
+				if targetDir, err = os.Getwd(); err != nil {
+					return fmt.Errorf("failed to get current working directory: %w", err)
+				}
+			}
+
+			scripts, err := readRunScripts(pm, targetDir)
+			if err != nil {
+				return err
+			}
+
+			entries := FilterScripts(scriptEntries(scripts), filter)
+
+			if asJSON {
+				encoder := json.NewEncoder(cmd.OutOrStdout())
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(entries)
+			}
+
+			if len(scripts) == 0 {
+				_, err := fmt.Fprintf(cmd.OutOrStdout(), "No scripts found in %s\n", lo.Ternary(pm == "deno", "deno.json", "package.json"))
+				return err
+			}
+
+			if len(entries) == 0 {
+				_, err := fmt.Fprintf(cmd.OutOrStdout(), "No scripts match %q\n", filter)
+				return err
+			}
+
+			return printScriptTable(cmd.OutOrStdout(), entries)
+		},
+	}
+
+	cmd.Flags().String(_FILTER_FLAG, "", "Only print the scripts whose name fuzzy matches or whose command contains this text")
+	cmd.Flags().Bool(_JSON_FLAG, false, "Print the scripts as JSON")
+
+	return cmd
+}
+
+// scriptEntries turns scripts into entries sorted by group and then by name.
+func scriptEntries(scripts map[string]string) []ScriptEntry {
+	entries := lo.MapToSlice(scripts, func(name, command string) ScriptEntry {
+		group, _, _ := strings.Cut(name, ":")
+		return ScriptEntry{Name: name, Group: group, Command: command}
+	})
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Group != entries[j].Group {
+			return entries[i].Group < entries[j].Group
+		}
+		return entries[i].Name < entries[j].Name
+	})
+
+	return entries
+}
+
+// FilterScripts keeps the entries whose name contains the characters of filter in order
+// or whose command contains filter, ignoring case. An empty filter keeps every entry.
+func FilterScripts(entries []ScriptEntry, filter string) []ScriptEntry {
+	filter = strings.ToLower(filter)
+
+	return lo.Filter(entries, func(entry ScriptEntry, _ int) bool {
+		return fuzzyMatch(strings.ToLower(entry.Name), filter) ||
+			strings.Contains(strings.ToLower(entry.Command), filter)
+	})
+}
+
+// fuzzyMatch reports whether the runes of pattern appear in text in the same order.
+func fuzzyMatch(text, pattern string) bool {
+	remaining := []rune(pattern)
+	for _, r := range text {
+		if len(remaining) == 0 {
+			break
+		}
+		if r == remaining[0] {
+			remaining = remaining[1:]
+		}
+	}
+	return len(remaining) == 0
+}
+
+// printScriptTable prints entries under a line holding their group,
+// with the names of a group aligned in a column.
+func printScriptTable(w io.Writer, entries []ScriptEntry) error {
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	for i, entry := range entries {
+		if i == 0 || entries[i-1].Group != entry.Group {
+			if _, err := fmt.Fprintln(table, entry.Group); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintf(table, "  %s\t%s\n", entry.Name, entry.Command); err != nil {
+			return err
+		}
+	}
+
+	return table.Flush()
+}
@@ -0,0 +1,104 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/louiss0/javascript-package-delegator/cmd"
+	"github.com/louiss0/javascript-package-delegator/mock"
+	"github.com/louiss0/javascript-package-delegator/testutil"
+)
+
+var _ = Describe("Scripts Command", func() {
+	assert := assert.New(GinkgoT())
+
+	var (
+		mockRunner *mock.MockCommandRunner
+		factory    *testutil.RootCommandFactory
+		root       string
+	)
+
+	writeFile := func(name, content string) {
+		assert.NoError(os.WriteFile(filepath.Join(root, name), []byte(content), 0o644))
+	}
+
+	BeforeEach(func() {
+		mockRunner = mock.NewMockCommandRunner()
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+
+		root = GinkgoT().TempDir()
+		writeFile("package.json", `{
+			"scripts": {
+				"build": "vite build",
+				"build:ssr": "vite build --ssr",
+				"dev:server": "node server.js",
+				"dev:client": "vite",
+				"lint": "eslint ."
+			}
+		}`)
+	})
+
+	It("prints the scripts grouped by the prefix of their names", func() {
+		output, err := executeCmd(factory.CreateNpmAsDefault(nil), "--cwd", root+"/", "scripts")
+		assert.NoError(err)
+		assert.Equal(
+			"build\n"+
+				"  build      vite build\n"+
+				"  build:ssr  vite build --ssr\n"+
+				"dev\n"+
+				"  dev:client  vite\n"+
+				"  dev:server  node server.js\n"+
+				"lint\n"+
+				"  lint  eslint .\n",
+			output,
+		)
+		assert.False(mockRunner.HasBeenCalled)
+	})
+
+	It("narrows the table with --filter", func() {
+		output, err := executeCmd(factory.CreateNpmAsDefault(nil), "--cwd", root+"/", "scripts", "--filter", "dev")
+		assert.NoError(err)
+		assert.Equal("dev\n  dev:client  vite\n  dev:server  node server.js\n", output)
+	})
+
+	It("fuzzy matches names and matches commands with --filter", func() {
+		output, err := executeCmd(factory.CreateNpmAsDefault(nil), "--cwd", root+"/", "tasks", "--filter", "BLD", "--json")
+		assert.NoError(err)
+
+		var entries []cmd.ScriptEntry
+		assert.NoError(json.Unmarshal([]byte(output), &entries))
+		assert.Equal([]cmd.ScriptEntry{
+			{Name: "build", Group: "build", Command: "vite build"},
+			{Name: "build:ssr", Group: "build", Command: "vite build --ssr"},
+		}, entries)
+
+		output, err = executeCmd(factory.CreateNpmAsDefault(nil), "--cwd", root+"/", "scripts", "--filter", "server.js")
+		assert.NoError(err)
+		assert.Equal("dev\n  dev:server  node server.js\n", output)
+	})
+
+	It("says so when nothing matches the filter", func() {
+		output, err := executeCmd(factory.CreateNpmAsDefault(nil), "--cwd", root+"/", "scripts", "--filter", "zzz")
+		assert.NoError(err)
+		assert.Equal("No scripts match \"zzz\"\n", output)
+	})
+
+	It("reads the tasks of deno.json for deno", func() {
+		writeFile("deno.json", `{"tasks": {"start": "deno run main.ts"}}`)
+
+		output, err := executeCmd(factory.CreateDenoAsDefault(nil), "--cwd", root+"/", "scripts", "--json")
+		assert.NoError(err)
+
+		var entries []cmd.ScriptEntry
+		assert.NoError(json.Unmarshal([]byte(output), &entries))
+		assert.Equal([]cmd.ScriptEntry{{Name: "start", Group: "start", Command: "deno run main.ts"}}, entries)
+	})
+})
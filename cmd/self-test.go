@@ -0,0 +1,133 @@
+// Package cmd provides command-line interface implementations for the JavaScript package delegator.
+package cmd
+
+import (
+	// standard library
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	// external
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+
+	// internal
+	"github.com/louiss0/javascript-package-delegator/detect"
+)
+
+const _DIR_FLAG = "dir"
+
+// NewSelfTestCmd creates a hidden command that traces every detection step against a directory.
+// It only reads the directory, nothing is executed or written.
+func NewSelfTestCmd(pathLookup detect.PathLookup) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:    "self-test",
+		Short:  "Trace package manager detection against a directory",
+		Hidden: true,
+		Args:   cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir, err := cmd.Flags().GetString(_DIR_FLAG)
+			if err != nil {
+				return err
+			}
+			if dir == "" {
+				if dir, err = os.Getwd(); err != nil {
+					return fmt.Errorf("failed to get current working directory: %w", err)
+				}
+			}
+
+			dir, err = filepath.Abs(dir)
+			if err != nil {
+				return err
+			}
+
+			info, err := os.Stat(dir)
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", dir, err)
+			}
+			if !info.IsDir() {
+				return fmt.Errorf("%s is not a directory", dir)
+			}
+
+			return traceDetection(cmd.OutOrStdout(), dir, pathLookup)
+		},
+	}
+
+	cmd.Flags().String(_DIR_FLAG, "", "Directory to run detection against (defaults to the current directory)")
+
+	return cmd
+}
+
+// traceDetection runs the same steps the root command uses to choose a package manager
+// and writes one line per step.
+func traceDetection(w io.Writer, dir string, pathLookup detect.PathLookup) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Directory: %s\n", dir)
+
+	lockfileDir := dir
+	lockfile, err := detect.DetectLockfileIn(dir, detect.RealFileSystem{})
+	if err != nil {
+		fmt.Fprintf(&b, "Lockfile: none in %s\n", dir)
+
+		lockfileDir, lockfile, err = detect.DetectLockfileInAncestors(filepath.Dir(dir), detect.RealFileSystem{})
+		if err != nil {
+			b.WriteString("Ancestor lockfile: none\n")
+		} else {
+			fmt.Fprintf(&b, "Ancestor lockfile: %s\n", filepath.Join(lockfileDir, lockfile))
+		}
+	} else {
+		fmt.Fprintf(&b, "Lockfile: %s\n", lockfile)
+	}
+
+	packageManagerField := readPackageManagerField(dir)
+	fmt.Fprintf(&b, "packageManager field: %s\n", packageManagerField)
+
+	var detectedPM string
+	if lockfile != "" {
+		pm, err := detect.DetectJSPackageManagerBasedOnLockFile(lockfile, pathLookup)
+		switch {
+		case err == nil:
+			fmt.Fprintf(&b, "Lockfile package manager: %s (found in PATH)\n", pm)
+			detectedPM = pm
+		case errors.Is(err, detect.ErrNoPackageManager):
+			fmt.Fprintf(&b, "Lockfile package manager: %s (not found in PATH)\n", detect.LockFileToPackageManagerMap[lockfile])
+		default:
+			fmt.Fprintf(&b, "Lockfile package manager: %v\n", err)
+		}
+	}
+
+	if detectedPM == "" {
+		pm, err := detect.DetectJSPackageManager(pathLookup)
+		if err != nil {
+			b.WriteString("PATH: no supported package manager found\n")
+		} else {
+			fmt.Fprintf(&b, "PATH: %s\n", pm)
+			detectedPM = pm
+		}
+	}
+
+	fmt.Fprintf(&b, "Volta: %s\n", lo.Ternary(detect.DetectVolta(pathLookup), "active", "not found"))
+
+	fmt.Fprintf(&b, "Result: %s\n", lo.Ternary(detectedPM != "", detectedPM, "none"))
+
+	_, err = io.WriteString(w, b.String())
+	return err
+}
+
+// readPackageManagerField describes the packageManager field of the package.json in dir.
+func readPackageManagerField(dir string) string {
+	if _, err := os.Stat(filepath.Join(dir, "package.json")); err != nil {
+		return "no package.json"
+	}
+
+	packageManager, err := readPackageManagerFieldFrom(dir)
+	if err != nil {
+		return fmt.Sprintf("invalid package.json: %v", err)
+	}
+
+	return lo.Ternary(packageManager != "", packageManager, "not set")
+}
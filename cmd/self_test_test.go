@@ -0,0 +1,124 @@
+package cmd_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/louiss0/javascript-package-delegator/cmd"
+	"github.com/louiss0/javascript-package-delegator/detect"
+	"github.com/louiss0/javascript-package-delegator/mock"
+	"github.com/louiss0/javascript-package-delegator/testutil"
+)
+
+var _ = Describe("Self Test Command", func() {
+	assert := assert.New(GinkgoT())
+
+	var (
+		mockRunner *mock.MockCommandRunner
+		factory    *testutil.RootCommandFactory
+		pathLookup *mock.MockPathLookup
+		dir        string
+	)
+
+	setPath := func(pm, path string) {
+		var err error
+		if path == "" {
+			err = fmt.Errorf("executable file not found in $PATH: %w", os.ErrNotExist)
+		}
+		pathLookup.ExpectedLookPathResults[pm] = struct {
+			Path  string
+			Error error
+		}{path, err}
+	}
+
+	BeforeEach(func() {
+		mockRunner = mock.NewMockCommandRunner()
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+
+		pathLookup = mock.NewMockPathLookup()
+		for _, pm := range detect.SupportedJSPackageManagers {
+			setPath(pm, "")
+		}
+		setPath(detect.NPM, "/usr/bin/npm")
+		setPath(detect.VOLTA, "")
+
+		dir = GinkgoT().TempDir()
+		assert.NoError(os.WriteFile(filepath.Join(dir, detect.PNPM_LOCK_YAML), []byte("lockfileVersion: '9.0'\n"), 0o644))
+		assert.NoError(os.WriteFile(filepath.Join(dir, "package.json"), []byte(`{"packageManager": "pnpm@9.1.0"}`), 0o644))
+	})
+
+	It("is hidden from help", func() {
+		root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.PathLookup = pathLookup
+		})
+
+		selfTest, _, err := root.Find([]string{"self-test"})
+		assert.NoError(err)
+		assert.True(selfTest.Hidden)
+	})
+
+	It("traces every detection step against the directory", func() {
+		setPath(detect.PNPM, "/usr/local/bin/pnpm")
+		root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.PathLookup = pathLookup
+		})
+
+		output, err := executeCmd(root, "self-test", "--dir", dir)
+		assert.NoError(err)
+		assert.Equal(
+			fmt.Sprintf("Directory: %s\n", dir)+
+				"Lockfile: pnpm-lock.yaml\n"+
+				"packageManager field: pnpm@9.1.0\n"+
+				"Lockfile package manager: pnpm (found in PATH)\n"+
+				"Volta: not found\n"+
+				"Result: pnpm\n",
+			output,
+		)
+		assert.False(mockRunner.HasBeenCalled, "self-test must not execute a package manager")
+	})
+
+	It("falls back to PATH when the lockfile package manager is not installed", func() {
+		root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.PathLookup = pathLookup
+		})
+
+		output, err := executeCmd(root, "self-test", "--dir", dir)
+		assert.NoError(err)
+		assert.Contains(output, "Lockfile package manager: pnpm (not found in PATH)\n")
+		assert.Contains(output, "PATH: npm\n")
+		assert.Contains(output, "Result: npm\n")
+	})
+
+	It("reports a lockfile found in an ancestor directory", func() {
+		nested := filepath.Join(dir, "packages", "app")
+		assert.NoError(os.MkdirAll(nested, 0o755))
+		setPath(detect.PNPM, "/usr/local/bin/pnpm")
+		root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.PathLookup = pathLookup
+		})
+
+		output, err := executeCmd(root, "self-test", "--dir", nested)
+		assert.NoError(err)
+		assert.Contains(output, fmt.Sprintf("Lockfile: none in %s\n", nested))
+		assert.Contains(output, fmt.Sprintf("Ancestor lockfile: %s\n", filepath.Join(dir, detect.PNPM_LOCK_YAML)))
+		assert.Contains(output, "packageManager field: no package.json\n")
+		assert.Contains(output, "Result: pnpm\n")
+	})
+
+	It("returns an error when the directory does not exist", func() {
+		root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.PathLookup = pathLookup
+		})
+
+		_, err := executeCmd(root, "self-test", "--dir", filepath.Join(dir, "missing"))
+		assert.ErrorContains(err, "failed to read")
+	})
+})
@@ -358,17 +358,7 @@ func autoInstallDependenciesIfNeeded(
 			return fmt.Errorf("failed to install dependencies: %w", err)
 		}
 
-		if newHash, err := deps.ComputeNodeDepsHash(baseDir); err == nil {
-			if err := deps.WriteStoredDepsHash(baseDir, newHash); err == nil {
-				if goEnv.IsDevelopmentMode() {
-					hashShort := ""
-					if len(newHash) >= 8 {
-						hashShort = newHash[:8]
-					}
-					de.LogDebugMessageIfDebugIsTrue("Updated dependency hash", "hash", hashShort)
-				}
-			}
-		}
+		writeNodeDepsHash(baseDir, goEnv, de)
 	} else {
 		de.LogJSCommandIfDebugIsTrue("deno", "cache", "deno.json")
 		cmdRunner.Command("deno", "cache", "deno.json")
@@ -393,3 +383,23 @@ func autoInstallDependenciesIfNeeded(
 
 	return nil
 }
+
+// writeNodeDepsHash stores the dependency hash of the package.json in baseDir after an install,
+// so the next preflight knows node_modules matches it. A hash that can't be stored only means the next check installs again.
+func writeNodeDepsHash(baseDir string, goEnv env.GoEnv, de DebugExecutor) {
+	newHash, err := deps.ComputeNodeDepsHash(baseDir)
+	if err != nil {
+		return
+	}
+	if err := deps.WriteStoredDepsHash(baseDir, newHash); err != nil {
+		return
+	}
+
+	if goEnv.IsDevelopmentMode() {
+		hashShort := ""
+		if len(newHash) >= 8 {
+			hashShort = newHash[:8]
+		}
+		de.LogDebugMessageIfDebugIsTrue("Updated dependency hash", "hash", hashShort)
+	}
+}
@@ -3,6 +3,7 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/charmbracelet/huh"
@@ -37,6 +38,8 @@ func (t *dependencyMultiSelectUI) Run() error {
 }
 
 const _INTERACTIVE_FLAG = "interactive"
+const _ALL_GROUPS_FLAG = "all-groups"
+const _PRUNE_LOCKFILE_FLAG = "prune-lockfile"
 
 func NewUninstallCmd(newDependencySelectorUI func(options []string) DependencyUIMultiSelector) *cobra.Command {
 	cmd := &cobra.Command{
@@ -48,7 +51,9 @@ Equivalent to 'nun' command - detects npm, yarn, pnpm, or bun and runs the unins
 Examples:
   javascript-package-delegator uninstall lodash       # Uninstall lodash
   javascript-package-delegator uninstall lodash react # Uninstall multiple packages
-  javascript-package-delegator uninstall -g typescript # Uninstall global package`,
+  javascript-package-delegator uninstall -g typescript # Uninstall global package
+  javascript-package-delegator uninstall --all-groups react # Also remove react from peer/optional dependencies
+  javascript-package-delegator uninstall lodash --prune-lockfile # Refresh the lock file without installing after the removal`,
 		Aliases: []string{"un", "remove", "rm"},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			pm, _ := cmd.Flags().GetString(AGENT_FLAG)
@@ -68,6 +73,15 @@ Examples:
 				return err
 			}
 
+			allGroups, err := cmd.Flags().GetBool(_ALL_GROUPS_FLAG)
+			if err != nil {
+				return err
+			}
+
+			if allGroups && pm == detect.DENO {
+				return fmt.Errorf("the --%s flag is not supported for %s", _ALL_GROUPS_FLAG, detect.DENO)
+			}
+
 			// Validate args: require at least one unless interactive mode
 			if !interactive && len(args) == 0 {
 				return fmt.Errorf("requires at least 1 arg(s), only received 0")
@@ -102,6 +116,9 @@ Examples:
 					return fmt.Errorf("no packages found for interactive uninstall")
 				}
 
+				if err := errIfQuiet(cmd, "choosing the dependencies to uninstall"); err != nil {
+					return err
+				}
 				dependencySelectorUI := newDependencySelectorUI(dependencies)
 
 				if err := dependencySelectorUI.Run(); err != nil {
@@ -156,7 +173,28 @@ Examples:
 				cmdArgs = lo.Flatten([][]string{cmdArgs, selectedPackages, args})
 
 			default:
-				return fmt.Errorf("unsupported package manager: %s", pm)
+				return unsupportedPackageManagerError(pm)
+			}
+
+			pruneLockfile, err := cmd.Flags().GetBool(_PRUNE_LOCKFILE_FLAG)
+			if err != nil {
+				return err
+			}
+
+			// The refresh is built before anything runs, so an unsupported one doesn't leave a half done removal
+			var pruneArgs []string
+			if pruneLockfile {
+				yarnVersion := ""
+				if pm == detect.YARN {
+					if version, err := detect.DetectYarnVersion(getYarnVersionRunnerCommandContext(cmd)); err == nil {
+						yarnVersion = version
+					}
+				}
+
+				pruneArgs, err = buildLockfileRefreshArgs(pm, yarnVersion)
+				if err != nil {
+					return err
+				}
 			}
 
 			// Execute the command
@@ -167,6 +205,33 @@ Examples:
 				log.Info("Running command", "pm", pm, "args", strings.Join(cmdArgs, " "))
 			})
 
+			if err := cmdRunner.Run(); err != nil {
+				return err
+			}
+
+			if allGroups {
+				if err := removeFromAllGroups(cmd, lo.Flatten([][]string{selectedPackages, args})); err != nil {
+					return err
+				}
+			}
+
+			if !pruneLockfile {
+				return nil
+			}
+
+			switch {
+			case pm == detect.DENO:
+				return printNote(cmd, "deno remove edits deno.json and deno.lock itself, --%s has nothing left to do", _PRUNE_LOCKFILE_FLAG)
+			case len(pruneArgs) == 0:
+				return printNote(cmd, "%s updates its lock file when it removes packages, --%s has nothing left to do", pm, _PRUNE_LOCKFILE_FLAG)
+			}
+
+			cmdRunner.Command(pm, pruneArgs...)
+			de.LogJSCommandIfDebugIsTrue(pm, pruneArgs...)
+			goEnv.ExecuteIfModeIsProduction(func() {
+				log.Info("Refreshing the lock file", "pm", pm, "args", strings.Join(pruneArgs, " "))
+			})
+
 			return cmdRunner.Run()
 		},
 	}
@@ -175,7 +240,54 @@ Examples:
 	cmd.Flags().BoolP(_GLOBAL_FLAG, "g", false, "Uninstall global packages")
 	cmd.Flags().BoolP(_INTERACTIVE_FLAG, "i", false, "Uninstall packages interactively")
 
+	cmd.Flags().Bool(_ALL_GROUPS_FLAG, false, "Also remove the packages from every dependency group in package.json")
+	cmd.Flags().Bool(_PRUNE_LOCKFILE_FLAG, false, "Refresh the lock file without installing once the packages are removed")
+
 	cmd.MarkFlagsMutuallyExclusive(_GLOBAL_FLAG, _INTERACTIVE_FLAG)
+	cmd.MarkFlagsMutuallyExclusive(_GLOBAL_FLAG, _ALL_GROUPS_FLAG)
+	cmd.MarkFlagsMutuallyExclusive(_GLOBAL_FLAG, _PRUNE_LOCKFILE_FLAG)
 
 	return cmd
 }
+
+// removeFromAllGroups removes the packages from every dependency group of package.json.
+// Package managers sometimes only remove a package from the group they installed it to,
+// so every group is cleaned up explicitly.
+func removeFromAllGroups(cmd *cobra.Command, packages []string) error {
+	de := getDebugExecutorFromCommandContext(cmd)
+
+	targetDir, err := cmd.Flags().GetString(_CWD_FLAG)
+	if err != nil {
+		return fmt.Errorf("failed to parse --%s flag: %w", _CWD_FLAG, err)
+	}
+	if targetDir == "" {
+		targetDir, err = os.Getwd()
+		if err != nil {
+			return fmt.Errorf("failed to get current working directory: %w", err)
+		}
+	}
+
+	packageNames := ParsePackageNames(packages)
+	changed, err := deps.RemovePackagesFromAllGroups(targetDir, packageNames)
+	if err != nil {
+		return err
+	}
+
+	if changed {
+		de.LogDebugMessageIfDebugIsTrue("Removed packages from all dependency groups", "packages", strings.Join(packageNames, " "))
+	}
+
+	return nil
+}
+
+// buildLockfileRefreshArgs returns the arguments that make pm bring its lock file in line with the manifest
+// without installing anything, nil when the remove command of pm already leaves a strict lock file.
+func buildLockfileRefreshArgs(pm, yarnVersion string) ([]string, error) {
+	// deno remove edits deno.json and deno.lock, yarn v1 rewrites yarn.lock as part of its full install
+	if pm == detect.DENO || (pm == detect.YARN && ParseYarnMajor(yarnVersion) < 2) {
+		return nil, nil
+	}
+
+	_, args, err := BuildInstallCommand(pm, yarnVersion, nil, InstallOptions{LockfileOnly: true})
+	return args, err
+}
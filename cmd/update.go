@@ -2,24 +2,101 @@
 package cmd
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 
+	"github.com/charmbracelet/huh"
 	"github.com/charmbracelet/log"
+	"github.com/samber/lo"
 	"github.com/spf13/cobra"
+
+	"github.com/louiss0/javascript-package-delegator/build_info"
+	"github.com/louiss0/javascript-package-delegator/detect"
 )
 
-func NewUpdateCmd() *cobra.Command {
+type updateMultiSelectUI struct {
+	selectedValues []string
+	selectUI       huh.MultiSelect[string]
+}
+
+func newUpdateMultiSelectUI(options []string) MultiUISelecter {
+	return &updateMultiSelectUI{
+		selectUI: *huh.NewMultiSelect[string]().
+			Title("Select packages to update").
+			Description("Picked packages are installed at their latest version").
+			Options(huh.NewOptions(options...)...),
+	}
+}
+
+func (u updateMultiSelectUI) Values() []string {
+	return u.selectedValues
+}
+
+func (u *updateMultiSelectUI) Run() error {
+	return u.selectUI.Value(&u.selectedValues).Run()
+}
+
+// parseNpmOutdated returns the sorted names of the packages in 'npm outdated --json' output.
+func parseNpmOutdated(output []byte) ([]string, error) {
+	if len(bytes.TrimSpace(output)) == 0 {
+		return nil, nil
+	}
+
+	var outdated map[string]json.RawMessage
+	if err := json.Unmarshal(output, &outdated); err != nil {
+		return nil, fmt.Errorf("failed to parse npm outdated output: %w", err)
+	}
+
+	names := lo.Keys(outdated)
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// readDependencyNamesFrom returns the sorted names of the dependencies and devDependencies
+// in the package.json of baseDir.
+func readDependencyNamesFrom(baseDir string) ([]string, error) {
+	data, err := os.ReadFile(filepath.Join(baseDir, "package.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read package.json: %w", err)
+	}
+
+	var pkg struct {
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return nil, fmt.Errorf("failed to parse package.json: %w", err)
+	}
+
+	names := lo.Keys(lo.Assign(pkg.Dependencies, pkg.DevDependencies))
+	sort.Strings(names)
+
+	return names, nil
+}
+
+func NewUpdateCmd(newUpdateMultiSelectUI func(options []string) MultiUISelecter) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "update [packages...]",
 		Short: "Update packages using the detected package manager",
 		Long: `Update packages to their latest versions using the appropriate package manager.
 Equivalent to 'nup' command - detects npm, yarn, pnpm, or bun and runs the update command.
+Yarn v2+ projects use 'yarn up' ('yarn up -i' when interactive) instead of 'yarn upgrade',
+with the '*' pattern when no package is named.
+npm has no interactive update, so -i lists the packages from 'npm outdated' and installs
+the selected ones at their latest version. npm's --latest installs the named packages, or
+every dependency in package.json when none are named, at their latest version.
 
 Examples:
   javascript-package-delegator update           # Update all packages
   javascript-package-delegator update lodash    # Update specific package
   javascript-package-delegator update -i        # Interactive update (where supported)
+  javascript-package-delegator update --latest  # Update past the version ranges
   javascript-package-delegator update -g typescript # Update global package`,
 		Aliases: []string{"u", "up", "upgrade"},
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -33,6 +110,21 @@ Examples:
 				log.Info("Using package manager", "pm", pm)
 			})
 
+			// JPD is not a dependency of the project, so point to the channel it was installed with
+			selfNames := lo.Filter(args, func(arg string, _ int) bool {
+				return lo.Contains(build_info.SELF_NAMES, ParsePackageNames([]string{arg})[0])
+			})
+			if len(selfNames) > 0 {
+				if err := printSelfUpgradeGuidance(cmd); err != nil {
+					return err
+				}
+
+				args = lo.Without(args, selfNames...)
+				if len(args) == 0 {
+					return nil
+				}
+			}
+
 			// Get flags
 			interactive, _ := cmd.Flags().GetBool("interactive")
 			global, _ := cmd.Flags().GetBool("global")
@@ -43,7 +135,87 @@ Examples:
 			switch pm {
 			case "npm":
 				if interactive {
-					return fmt.Errorf("npm does not support interactive updates")
+					targetDir, err := cmd.Flags().GetString(_CWD_FLAG)
+					if err != nil {
+						return fmt.Errorf("failed to parse --%s flag: %w", _CWD_FLAG, err)
+					}
+
+					outdatedArgs := []string{"outdated", "--json"}
+					if global {
+						outdatedArgs = append(outdatedArgs, "--global")
+					}
+
+					output, err := runOutdated(cmd.Context(), getParallelRunnerFromCommandContext(cmd), targetDir, pm, outdatedArgs...)
+					if err != nil {
+						return err
+					}
+
+					outdated, err := parseNpmOutdated(output)
+					if err != nil {
+						return err
+					}
+
+					// Named packages narrow the choices down to themselves
+					if len(args) > 0 {
+						outdated = lo.Intersect(outdated, ParsePackageNames(args))
+					}
+
+					if len(outdated) == 0 {
+						_, err := fmt.Fprintln(cmd.OutOrStdout(), "All packages are up to date")
+						return err
+					}
+
+					if err := errIfQuiet(cmd, "choosing the packages to update"); err != nil {
+						return err
+					}
+					updateSelectUI := newUpdateMultiSelectUI(outdated)
+					if err := updateSelectUI.Run(); err != nil {
+						return err
+					}
+
+					selected := updateSelectUI.Values()
+					if len(selected) == 0 {
+						return fmt.Errorf("no packages selected for update")
+					}
+
+					cmdArgs = []string{"install"}
+					cmdArgs = append(cmdArgs, lo.Map(selected, func(name string, _ int) string {
+						return name + "@latest"
+					})...)
+					if global {
+						cmdArgs = append(cmdArgs, "--global")
+					}
+					break
+				}
+				// npm update stays within the version ranges, so --latest installs each package at @latest
+				if latest {
+					names := ParsePackageNames(args)
+					if len(names) == 0 {
+						if global {
+							return fmt.Errorf("npm needs the names of the global packages to update to their latest version")
+						}
+
+						targetDir, err := cmd.Flags().GetString(_CWD_FLAG)
+						if err != nil {
+							return fmt.Errorf("failed to parse --%s flag: %w", _CWD_FLAG, err)
+						}
+
+						if names, err = readDependencyNamesFrom(targetDir); err != nil {
+							return err
+						}
+						if len(names) == 0 {
+							return fmt.Errorf("no dependencies found in package.json to update")
+						}
+					}
+
+					cmdArgs = []string{"install"}
+					cmdArgs = append(cmdArgs, lo.Map(names, func(name string, _ int) string {
+						return name + "@latest"
+					})...)
+					if global {
+						cmdArgs = append(cmdArgs, "--global")
+					}
+					break
 				}
 				if len(args) == 0 {
 					cmdArgs = []string{"update"}
@@ -54,30 +226,48 @@ Examples:
 				if global {
 					cmdArgs = append(cmdArgs, "--global")
 				}
-				if latest {
-					cmdArgs = append(cmdArgs, "--latest")
-				}
 
 			case "yarn":
-				if interactive {
-					cmdArgs = []string{"upgrade-interactive"}
-					if len(args) > 0 {
-						cmdArgs = append(cmdArgs, args...)
-					}
-				} else {
-					if len(args) == 0 {
-						cmdArgs = []string{"upgrade"}
+				// Yarn v1 uses upgrade/upgrade-interactive, v2+ uses up/up -i
+				yarnVersion, err := detect.DetectYarnVersion(
+					getYarnVersionRunnerCommandContext(cmd),
+				)
+
+				if err != nil || strings.HasPrefix(yarnVersion, "1.") {
+					// Yarn v1 or unknown version
+					if interactive {
+						cmdArgs = []string{"upgrade-interactive"}
 					} else {
 						cmdArgs = []string{"upgrade"}
-						cmdArgs = append(cmdArgs, args...)
 					}
+					cmdArgs = append(cmdArgs, args...)
+					if global {
+						cmdArgs = append(cmdArgs, "--global")
+					}
+					if latest {
+						cmdArgs = append(cmdArgs, "--latest")
+					}
+					break
 				}
+
+				// Yarn v2+ has no global packages and yarn up ignores version ranges already
 				if global {
-					cmdArgs = append(cmdArgs, "--global")
+					return fmt.Errorf("yarn %s does not support global updates", strings.TrimSpace(yarnVersion))
 				}
 				if latest {
-					cmdArgs = append(cmdArgs, "--latest")
+					if err := printNote(cmd, "yarn %s up ignores version ranges already, --latest is ignored", strings.TrimSpace(yarnVersion)); err != nil {
+						return err
+					}
+				}
+				cmdArgs = []string{"up"}
+				if interactive {
+					cmdArgs = append(cmdArgs, "-i")
+				}
+				// yarn up needs a package, the glob matches every dependency
+				if len(args) == 0 {
+					cmdArgs = append(cmdArgs, "*")
 				}
+				cmdArgs = append(cmdArgs, args...)
 
 			case "pnpm":
 				if interactive {
@@ -125,7 +315,7 @@ Examples:
 				return fmt.Errorf("deno does not support the update command")
 
 			default:
-				return fmt.Errorf("unsupported package manager: %s", pm)
+				return unsupportedPackageManagerError(pm)
 			}
 
 			// Execute the command
@@ -146,3 +336,13 @@ Examples:
 
 	return cmd
 }
+
+// printSelfUpgradeGuidance explains how to upgrade JPD itself instead of asking a package manager to do it.
+func printSelfUpgradeGuidance(cmd *cobra.Command) error {
+	_, err := fmt.Fprintf(
+		cmd.OutOrStdout(),
+		"Note: jpd %s is not managed by your package manager, upgrade it with the tool you installed it with:\n  %s\n",
+		build_info.Version(), strings.Join(build_info.UpgradeInstructions(), "\n  "),
+	)
+	return err
+}
@@ -0,0 +1,136 @@
+package cmd_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/louiss0/javascript-package-delegator/cmd"
+	"github.com/louiss0/javascript-package-delegator/mock"
+	"github.com/louiss0/javascript-package-delegator/testutil"
+)
+
+var _ = Describe("Update Command npm interactive", func() {
+	assert := assert.New(GinkgoT())
+
+	const outdatedJSON = `{
+  "react": {"current": "18.2.0", "wanted": "18.2.0", "latest": "19.0.0", "dependent": "app"},
+  "lodash": {"current": "4.17.20", "wanted": "4.17.21", "latest": "4.17.21", "dependent": "app"},
+  "vite": {"current": "5.0.0", "wanted": "5.4.0", "latest": "6.0.0", "dependent": "app"}
+}`
+
+	var (
+		mockRunner *mock.MockCommandRunner
+		factory    *testutil.RootCommandFactory
+		offered    []string
+		scripts    *testutil.MockScriptRunners
+	)
+
+	npmOutdated := func(output string, err error) cmd.ParallelCommandRunnerFactory {
+		scripts = &testutil.MockScriptRunners{Setup: testutil.ScriptOutput(err, output)}
+		return scripts.NewRunner
+	}
+
+	// outdatedCall returns the command npm was asked which packages are outdated with
+	outdatedCall := func() mock.CommandCall {
+		return scripts.Runners()[0].CommandCall
+	}
+
+	// selecting returns a select UI that records its options and picks the given packages
+	selecting := func(picked ...string) func([]string) cmd.MultiUISelecter {
+		return func(options []string) cmd.MultiUISelecter {
+			offered = options
+			ui := &mock.MockDependencyUISelector{}
+			ui.On("Run").Return(nil)
+			ui.On("Values").Return(picked)
+			return ui
+		}
+	}
+
+	BeforeEach(func() {
+		mockRunner = mock.NewMockCommandRunner()
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+
+		offered = nil
+	})
+
+	It("installs the selected outdated packages at their latest version", func() {
+		root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.NewParallelCommandRunner = npmOutdated(outdatedJSON, nil)
+			deps.NewUpdateMultiSelectUI = selecting("lodash", "vite")
+		})
+
+		_, err := executeCmd(root, "update", "--interactive")
+		assert.NoError(err)
+		assert.Equal([]string{"lodash", "react", "vite"}, offered)
+		assert.True(mockRunner.HasCommand("npm", "install", "lodash@latest", "vite@latest"))
+	})
+
+	It("only offers the named packages", func() {
+		root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.NewParallelCommandRunner = npmOutdated(outdatedJSON, nil)
+			deps.NewUpdateMultiSelectUI = selecting("react")
+		})
+
+		_, err := executeCmd(root, "update", "-i", "react", "typescript")
+		assert.NoError(err)
+		assert.Equal([]string{"react"}, offered)
+		assert.True(mockRunner.HasCommand("npm", "install", "react@latest"))
+	})
+
+	It("checks and installs global packages with --global", func() {
+		root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.NewParallelCommandRunner = npmOutdated(outdatedJSON, nil)
+			deps.NewUpdateMultiSelectUI = selecting("vite")
+		})
+
+		_, err := executeCmd(root, "update", "-i", "--global")
+		assert.NoError(err)
+		assert.Equal(mock.CommandCall{Name: "npm", Args: []string{"outdated", "--json", "--global"}}, outdatedCall())
+		assert.True(mockRunner.HasCommand("npm", "install", "vite@latest", "--global"))
+	})
+
+	It("errors when nothing is selected", func() {
+		root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.NewParallelCommandRunner = npmOutdated(outdatedJSON, nil)
+			deps.NewUpdateMultiSelectUI = selecting()
+		})
+
+		_, err := executeCmd(root, "update", "-i")
+		assert.Error(err)
+		assert.Contains(err.Error(), "no packages selected for update")
+		assert.False(mockRunner.HasBeenCalled)
+	})
+
+	It("returns the error when npm outdated fails", func() {
+		root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.NewParallelCommandRunner = npmOutdated("", fmt.Errorf("exit status 254"))
+			deps.NewUpdateMultiSelectUI = selecting("react")
+		})
+
+		_, err := executeCmd(root, "update", "-i")
+		assert.Error(err)
+		assert.Contains(err.Error(), "failed to run npm outdated")
+		assert.Nil(offered)
+	})
+
+	It("asks the --manager-path binary which packages are outdated", func() {
+		managerPath := filepath.Join(GinkgoT().TempDir(), "npm-custom")
+		assert.NoError(os.WriteFile(managerPath, []byte("#!/bin/sh\n"), 0o755))
+		root := factory.CreateRootCmdWith(func(deps *cmd.Dependencies) {
+			deps.NewParallelCommandRunner = npmOutdated(outdatedJSON, nil)
+			deps.NewUpdateMultiSelectUI = selecting("react")
+		})
+
+		_, err := executeCmd(root, "--manager-path", managerPath, "update", "-i")
+		assert.NoError(err)
+		assert.Equal(mock.CommandCall{Name: managerPath, Args: []string{"outdated", "--json"}}, outdatedCall())
+	})
+})
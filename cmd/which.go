@@ -0,0 +1,99 @@
+// Package cmd provides command-line interface implementations for the JavaScript package delegator.
+package cmd
+
+import (
+	// standard library
+	"fmt"
+	"path/filepath"
+
+	// external
+	"github.com/spf13/cobra"
+
+	// internal
+	"github.com/louiss0/javascript-package-delegator/detect"
+)
+
+const _ALL_FLAG = "all"
+
+// NewWhichCmd creates a new Cobra command for the "which" functionality.
+// It prints where the package manager executable lives without running it.
+func NewWhichCmd(pathLookup detect.PathLookup) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "which",
+		Short: "Print the path of the package manager executable",
+		Long: `Print the absolute path of the executable jpd runs for the detected package manager.
+The agent can be overridden with --agent or the JPD_AGENT environment variable.
+No package manager command is executed.
+
+Examples:
+  jpd which        # Print the path of the detected package manager
+  jpd which --all  # Print the path of every package manager found in PATH`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			all, err := cmd.Flags().GetBool(_ALL_FLAG)
+			if err != nil {
+				return err
+			}
+
+			if all {
+				found := 0
+				for _, pm := range detect.SupportedJSPackageManagers {
+					path, err := lookAbsPath(pathLookup, pm)
+					if err != nil {
+						continue
+					}
+
+					found++
+					if _, err := fmt.Fprintf(cmd.OutOrStdout(), "%s: %s\n", pm, path); err != nil {
+						return err
+					}
+				}
+
+				if found == 0 {
+					return fmt.Errorf("none of %v were found in PATH", detect.SupportedJSPackageManagers)
+				}
+				return nil
+			}
+
+			pm, err := cmd.Flags().GetString(AGENT_FLAG)
+			if err != nil {
+				return fmt.Errorf("failed to get agent flag: %w", err)
+			}
+			if pm == "" {
+				return fmt.Errorf("no package manager detected, pass --%s to choose one", AGENT_FLAG)
+			}
+
+			// An explicit binary wins over PATH, the same as when commands run
+			if managerPath := cmd.Flag(_MANAGER_PATH_FLAG); managerPath != nil && managerPath.Value.String() != "" {
+				path, err := filepath.Abs(managerPath.Value.String())
+				if err != nil {
+					return err
+				}
+				_, err = fmt.Fprintln(cmd.OutOrStdout(), path)
+				return err
+			}
+
+			path, err := lookAbsPath(pathLookup, pm)
+			if err != nil {
+				return fmt.Errorf("%s was not found in PATH: %w", pm, err)
+			}
+
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), path)
+			return err
+		},
+	}
+
+	cmd.Flags().Bool(_ALL_FLAG, false, "Print the path of every package manager found in PATH")
+
+	return cmd
+}
+
+// lookAbsPath resolves the executable of pm and makes the result absolute.
+func lookAbsPath(pathLookup detect.PathLookup, pm string) (string, error) {
+	path, err := pathLookup.LookPath(pm)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Abs(path)
+}
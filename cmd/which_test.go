@@ -0,0 +1,95 @@
+package cmd_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/louiss0/javascript-package-delegator/cmd"
+	"github.com/louiss0/javascript-package-delegator/detect"
+	"github.com/louiss0/javascript-package-delegator/mock"
+	"github.com/louiss0/javascript-package-delegator/testutil"
+)
+
+var _ = Describe("Which Command", func() {
+	assert := assert.New(GinkgoT())
+
+	var (
+		mockRunner *mock.MockCommandRunner
+		factory    *testutil.RootCommandFactory
+		pathLookup *mock.MockPathLookup
+	)
+
+	setPath := func(pm, path string) {
+		var err error
+		if path == "" {
+			err = fmt.Errorf("executable file not found in $PATH")
+		}
+		pathLookup.ExpectedLookPathResults[pm] = struct {
+			Path  string
+			Error error
+		}{path, err}
+	}
+
+	BeforeEach(func() {
+		mockRunner = mock.NewMockCommandRunner()
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+
+		pathLookup = mock.NewMockPathLookup()
+		setPath(detect.NPM, "/usr/bin/npm")
+		setPath(detect.PNPM, "/usr/local/bin/pnpm")
+		setPath(detect.YARN, "")
+		setPath(detect.BUN, "")
+		setPath(detect.DENO, "/opt/deno/bin/deno")
+	})
+
+	It("prints the path of the detected package manager", func() {
+		root := factory.CreateRootCmdWith(testutil.LockfileDetected(detect.PNPM, detect.PNPM_LOCK_YAML), func(deps *cmd.Dependencies) {
+			deps.PathLookup = pathLookup
+		})
+
+		output, err := executeCmd(root, "which")
+		assert.NoError(err)
+		assert.Equal("/usr/local/bin/pnpm\n", output)
+		assert.False(mockRunner.HasBeenCalled, "which must not execute a package manager")
+	})
+
+	It("respects the --agent flag", func() {
+		root := factory.CreateRootCmdWith(testutil.LockfileDetected(detect.PNPM, detect.PNPM_LOCK_YAML), func(deps *cmd.Dependencies) {
+			deps.PathLookup = pathLookup
+		})
+
+		output, err := executeCmd(root, "which", "--agent", detect.DENO)
+		assert.NoError(err)
+		assert.Equal("/opt/deno/bin/deno\n", output)
+	})
+
+	It("prints every package manager found with --all", func() {
+		root := factory.CreateRootCmdWith(testutil.LockfileDetected(detect.PNPM, detect.PNPM_LOCK_YAML), func(deps *cmd.Dependencies) {
+			deps.PathLookup = pathLookup
+		})
+
+		output, err := executeCmd(root, "which", "--all")
+		assert.NoError(err)
+		assert.Contains(output, "npm: /usr/bin/npm\n")
+		assert.Contains(output, "pnpm: /usr/local/bin/pnpm\n")
+		assert.Contains(output, "deno: /opt/deno/bin/deno\n")
+		assert.NotContains(output, "yarn")
+		assert.NotContains(output, "bun")
+	})
+
+	It("returns a clear error when the package manager is not in PATH", func() {
+		root := factory.CreateRootCmdWith(testutil.LockfileDetected(detect.PNPM, detect.PNPM_LOCK_YAML), func(deps *cmd.Dependencies) {
+			deps.PathLookup = pathLookup
+		})
+
+		_, err := executeCmd(root, "which", "--agent", detect.YARN)
+		assert.Error(err)
+		assert.Contains(err.Error(), "yarn was not found in PATH")
+	})
+})
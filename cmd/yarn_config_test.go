@@ -0,0 +1,108 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/louiss0/javascript-package-delegator/cmd"
+	"github.com/louiss0/javascript-package-delegator/detect"
+	"github.com/louiss0/javascript-package-delegator/mock"
+	"github.com/louiss0/javascript-package-delegator/testutil"
+)
+
+var _ = Describe("Yarn config file detection", func() {
+	assert := assert.New(GinkgoT())
+
+	var (
+		mockRunner *mock.MockCommandRunner
+		factory    *testutil.RootCommandFactory
+		dir        string
+	)
+
+	writeFile := func(name, content string) {
+		assert.NoError(os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+
+	BeforeEach(func() {
+		mockRunner = mock.NewMockCommandRunner()
+		factory = testutil.NewRootCommandFactory(mockRunner)
+		factory.SetupBasicCommandRunnerExpectations()
+		factory.ResetDebugExecutor()
+		testutil.DebugExecutorExpectationManager.DebugExecutor = factory.DebugExecutor()
+		factory.SetupBasicDebugExecutorExpectations()
+
+		dir = GinkgoT().TempDir()
+	})
+
+	Context("with only .yarnrc.yml", func() {
+		BeforeEach(func() {
+			writeFile(detect.YARNRC_YML, "nodeLinker: node-modules\n")
+		})
+
+		It("detects yarn and creates the project the yarn v2+ way", func() {
+			// yarn --version would report v1, the config file is trusted instead
+			root := factory.CreateRootCmdWith(testutil.YarnConfigDetection(detect.NPM, "1.22.19"))
+
+			// create parses its own flags, so it detects in the working directory instead of --cwd
+			originalDir, err := os.Getwd()
+			assert.NoError(err)
+			assert.NoError(os.Chdir(dir))
+			GinkgoT().Cleanup(func() { _ = os.Chdir(originalDir) })
+
+			output, err := executeCmd(root, "create", "vite", "my-app", "--cache-template")
+			assert.NoError(err)
+			assert.Contains(output, "yarn has no cache option for create templates")
+			assert.True(mockRunner.HasCommand("yarn", "create", "vite", "my-app"), "got %v", mockRunner.CommandCall)
+		})
+
+		It("runs dlx through yarn dlx", func() {
+			root := factory.CreateRootCmdWith(testutil.YarnConfigDetection(detect.NPM, "1.22.19"))
+
+			_, err := executeCmd(root, "--cwd", dir+"/", "dlx", "cowsay", "hi")
+			assert.NoError(err)
+			assert.True(mockRunner.HasCommand("yarn", "dlx", "cowsay", "hi"), "got %v", mockRunner.CommandCall)
+		})
+
+		It("reports the yarn config file as the agent source", func() {
+			root := factory.CreateRootCmdWith(testutil.YarnConfigDetection(detect.NPM, "1.22.19"))
+
+			output, err := executeCmd(root, "--cwd", dir+"/", "env", "--json")
+			assert.NoError(err)
+			var report cmd.EnvReport
+			assert.NoError(json.Unmarshal([]byte(output), &report))
+			assert.Equal(detect.YARN, report.Agent)
+			assert.Equal(cmd.AGENT_SOURCE_YARN_CONFIG, report.AgentSource)
+		})
+	})
+
+	It("asks yarn for its version with only .yarnrc", func() {
+		writeFile(detect.YARNRC, "registry \"https://registry.npmjs.org\"\n")
+		root := factory.CreateRootCmdWith(testutil.YarnConfigDetection(detect.NPM, "1.22.19"))
+
+		_, err := executeCmd(root, "--cwd", dir+"/", "dlx", "cowsay", "hi")
+		assert.NoError(err)
+		assert.True(mockRunner.HasCommand("yarn", "cowsay", "hi"), "got %v", mockRunner.CommandCall)
+	})
+
+	It("prefers a lock file over the yarn config file", func() {
+		writeFile(detect.YARNRC_YML, "nodeLinker: node-modules\n")
+		writeFile(detect.PACKAGE_LOCK_JSON, "{}")
+		root := factory.CreateRootCmdWith(testutil.YarnConfigDetection(detect.PNPM, ""))
+
+		_, err := executeCmd(root, "--cwd", dir+"/", "dlx", "cowsay")
+		assert.NoError(err)
+		assert.True(mockRunner.HasCommand("npx", "cowsay"), "got %v", mockRunner.CommandCall)
+	})
+
+	It("falls back to PATH without a lock file or yarn config file", func() {
+		root := factory.CreateRootCmdWith(testutil.YarnConfigDetection(detect.PNPM, ""))
+
+		_, err := executeCmd(root, "--cwd", dir+"/", "dlx", "cowsay")
+		assert.NoError(err)
+		assert.True(mockRunner.HasCommand("pnpm", "dlx", "cowsay"), "got %v", mockRunner.CommandCall)
+	})
+})
@@ -156,6 +156,21 @@ var _ = Describe("Detect", Label("fast", "unit"), func() {
 			assert.Equal(detect.DENO_LOCK, lockfile)
 		})
 
+		It("should prefer deno.lock when deno.json is also present", func() {
+			mockFs.StatFn = func(name string) (os.FileInfo, error) {
+				switch name {
+				case filepath.Join("/mock/test/dir", detect.DENO_LOCK):
+					return mock.NewMockFileInfo(detect.DENO_LOCK, 0, 0, time.Time{}, false), nil
+				case filepath.Join("/mock/test/dir", detect.DENO_JSON):
+					return mock.NewMockFileInfo(detect.DENO_JSON, 0, 0, time.Time{}, false), nil
+				}
+				return nil, os.ErrNotExist
+			}
+			lockfile, err := detect.DetectLockfileIn(testDir, mockFs)
+			assert.NoError(err)
+			assert.Equal(detect.DENO_LOCK, lockfile)
+		})
+
 		It("should detect deno from deno.json", func() {
 			mockFs.StatFn = func(name string) (os.FileInfo, error) {
 				if name == filepath.Join("/mock/test/dir", detect.DENO_JSON) {
@@ -257,6 +272,90 @@ var _ = Describe("Detect", Label("fast", "unit"), func() {
 
 	})
 
+	Context("DetectLockfileInAncestors", func() {
+		It("finds the lock file of the nearest ancestor", func() {
+			root := GinkgoT().TempDir()
+			nested := filepath.Join(root, "packages", "web")
+			assert.NoError(os.MkdirAll(nested, 0o755))
+			assert.NoError(os.WriteFile(filepath.Join(root, detect.PNPM_LOCK_YAML), []byte(""), 0o644))
+
+			dir, lockfile, err := detect.DetectLockfileInAncestors(nested, detect.RealFileSystem{})
+			assert.NoError(err)
+			assert.Equal(root, dir)
+			assert.Equal(detect.PNPM_LOCK_YAML, lockfile)
+		})
+
+		It("prefers the lock file closest to the start directory", func() {
+			root := GinkgoT().TempDir()
+			nested := filepath.Join(root, "packages", "web")
+			assert.NoError(os.MkdirAll(nested, 0o755))
+			assert.NoError(os.WriteFile(filepath.Join(root, detect.PNPM_LOCK_YAML), []byte(""), 0o644))
+			assert.NoError(os.WriteFile(filepath.Join(root, "packages", detect.YARN_LOCK), []byte(""), 0o644))
+
+			dir, lockfile, err := detect.DetectLockfileInAncestors(nested, detect.RealFileSystem{})
+			assert.NoError(err)
+			assert.Equal(filepath.Join(root, "packages"), dir)
+			assert.Equal(detect.YARN_LOCK, lockfile)
+		})
+
+		It("returns an error when no ancestor has a lock file", func() {
+			mockFs := mock.NewMockFileSystem()
+			mockFs.StatFn = func(name string) (os.FileInfo, error) {
+				return nil, os.ErrNotExist
+			}
+
+			_, _, err := detect.DetectLockfileInAncestors("/mock/test/dir", mockFs)
+			assert.Error(err)
+		})
+	})
+
+	Context("DetectYarnConfigIn", func() {
+		It("finds .yarnrc.yml before .yarnrc", func() {
+			dir := GinkgoT().TempDir()
+			assert.NoError(os.WriteFile(filepath.Join(dir, detect.YARNRC), []byte(""), 0o644))
+			assert.NoError(os.WriteFile(filepath.Join(dir, detect.YARNRC_YML), []byte(""), 0o644))
+
+			config, err := detect.DetectYarnConfigIn(dir, detect.RealFileSystem{})
+			assert.NoError(err)
+			assert.Equal(detect.YARNRC_YML, config)
+		})
+
+		It("finds .yarnrc", func() {
+			dir := GinkgoT().TempDir()
+			assert.NoError(os.WriteFile(filepath.Join(dir, detect.YARNRC), []byte(""), 0o644))
+
+			config, err := detect.DetectYarnConfigIn(dir, detect.RealFileSystem{})
+			assert.NoError(err)
+			assert.Equal(detect.YARNRC, config)
+		})
+
+		It("returns an error when there is no yarn config file", func() {
+			_, err := detect.DetectYarnConfigIn(GinkgoT().TempDir(), detect.RealFileSystem{})
+			assert.Error(err)
+		})
+	})
+
+	Context("YarnVersionFromConfig", func() {
+		DescribeTable("reads the yarn version .yarnrc.yml hints at",
+			func(content, expected string) {
+				dir := GinkgoT().TempDir()
+				assert.NoError(os.WriteFile(filepath.Join(dir, detect.YARNRC_YML), []byte(content), 0o644))
+
+				version, err := detect.YarnVersionFromConfig(dir)
+				assert.NoError(err)
+				assert.Equal(expected, version)
+			},
+			Entry("the version of the yarnPath release", "nodeLinker: node-modules\nyarnPath: .yarn/releases/yarn-4.1.0.cjs\n", "4.1.0"),
+			Entry("a prerelease yarnPath", "yarnPath: .yarn/releases/yarn-4.0.0-rc.42.cjs\n", "4.0.0-rc.42"),
+			Entry("v2 without a yarnPath", "nodeLinker: pnp\n", "2"),
+		)
+
+		It("returns an error when there is no .yarnrc.yml", func() {
+			_, err := detect.YarnVersionFromConfig(GinkgoT().TempDir())
+			assert.Error(err)
+		})
+	})
+
 	Context("DetectJSPackageManagerBasedOnLockFile", func() {
 		var mockPath *mock.MockPathLookup
 
@@ -8,6 +8,7 @@ import (
 	"os"
 	"os/exec" // Keep this import for RealPathLookup
 	"path/filepath"
+	"regexp"
 
 	"github.com/samber/lo"
 )
@@ -96,6 +97,27 @@ func DetectLockfileIn(targetDir string, fs FileSystem) (lockfile string, err err
 	return "", fmt.Errorf("no lock file found") // Return a specific error if no lockfile is found after checking all
 }
 
+// DetectLockfileInAncestors walks up from the start directory to the filesystem root
+// and returns the nearest directory containing a lock file along with the lock file name.
+func DetectLockfileInAncestors(startDir string, fs FileSystem) (lockfileDir string, lockfile string, err error) {
+	dir, err := filepath.Abs(startDir)
+	if err != nil {
+		return "", "", err
+	}
+
+	for {
+		if lockfile, err := DetectLockfileIn(dir, fs); err == nil {
+			return dir, lockfile, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", "", fmt.Errorf("no lock file found in %s or its ancestors", startDir)
+		}
+		dir = parent
+	}
+}
+
 // SupportedJSPackageManagers is a list of supported JavaScript package managers.
 // NPM must be last if the user has node on their computer it will be detected before the others.
 var SupportedJSPackageManagers = [5]string{DENO, BUN, PNPM, YARN, NPM}
@@ -175,6 +197,44 @@ func DetectYarnVersion(yarnVersionRunner YarnCommandVersionOutputter) (string, e
 	return result, nil
 }
 
+const (
+	YARNRC_YML = ".yarnrc.yml"
+	YARNRC     = ".yarnrc"
+)
+
+// yarnConfigFiles are the config files that point at yarn when a project has no lock file yet,
+// .yarnrc.yml belongs to yarn v2+ and .yarnrc to yarn v1.
+var yarnConfigFiles = [2]string{YARNRC_YML, YARNRC}
+
+// DetectYarnConfigIn searches for a yarn config file in the specified target directory.
+func DetectYarnConfigIn(targetDir string, fs FileSystem) (config string, err error) {
+	for _, configFile := range yarnConfigFiles {
+		if _, err := fs.Stat(filepath.Join(targetDir, configFile)); err == nil {
+			return configFile, nil
+		}
+	}
+
+	return "", fmt.Errorf("no yarn config file found")
+}
+
+// yarnPathVersion matches the version in the yarnPath of .yarnrc.yml, like .yarn/releases/yarn-4.1.0.cjs.
+var yarnPathVersion = regexp.MustCompile(`(?m)^yarnPath:.*yarn-(\d+\.\d+\.\d+[^/\s]*)\.c?js\b`)
+
+// YarnVersionFromConfig returns the yarn version the .yarnrc.yml of targetDir hints at:
+// the version of its yarnPath release, or "2" when it doesn't pin one since only yarn v2+ reads .yarnrc.yml.
+func YarnVersionFromConfig(targetDir string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(targetDir, YARNRC_YML))
+	if err != nil {
+		return "", err
+	}
+
+	if match := yarnPathVersion.FindSubmatch(data); match != nil {
+		return string(match[1]), nil
+	}
+
+	return "2", nil
+}
+
 const VOLTA = "volta"
 
 var VOLTA_RUN_COMMAND = []string{VOLTA, "run"}
@@ -184,3 +244,11 @@ func DetectVolta(pathLookup PathLookup) bool {
 	_, err := pathLookup.LookPath(VOLTA) // Use the injected pathLookup
 	return err == nil
 }
+
+const COREPACK = "corepack"
+
+// DetectCorepack reports whether corepack is available in PATH.
+func DetectCorepack(pathLookup PathLookup) bool {
+	_, err := pathLookup.LookPath(COREPACK)
+	return err == nil
+}
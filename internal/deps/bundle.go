@@ -0,0 +1,60 @@
+// Package deps provides functionality for dependency management and detection
+// across different JavaScript package managers and runtime environments.
+package deps
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/samber/lo"
+)
+
+// bundledDependenciesFields are the spellings npm accepts for the bundled dependencies of package.json.
+var bundledDependenciesFields = []string{"bundledDependencies", "bundleDependencies"}
+
+// AddBundledDependencies adds the given packages to the bundledDependencies array of the package.json
+// found in cwd, using bundleDependencies instead when the file already spells it that way.
+// Key order and indentation of the file are preserved. It returns true when the file was changed.
+func AddBundledDependencies(cwd string, packages []string) (bool, error) {
+	packageJSONPath := filepath.Join(cwd, "package.json")
+	data, err := os.ReadFile(packageJSONPath)
+	if err != nil {
+		return false, fmt.Errorf("failed to read package.json: %w", err)
+	}
+
+	members, err := decodeOrderedObject(data)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse package.json: %w", err)
+	}
+
+	_, fieldIndex, found := lo.FindIndexOf(members, func(member jsonMember) bool {
+		return lo.Contains(bundledDependenciesFields, member.key)
+	})
+	if !found {
+		members = append(members, jsonMember{key: bundledDependenciesFields[0], value: json.RawMessage("[]")})
+		fieldIndex = len(members) - 1
+	}
+
+	var bundled []string
+	if err := json.Unmarshal(members[fieldIndex].value, &bundled); err != nil {
+		return false, fmt.Errorf("failed to parse %s in package.json: %w", members[fieldIndex].key, err)
+	}
+
+	added := lo.Without(lo.Uniq(packages), bundled...)
+	if len(added) == 0 {
+		return false, nil
+	}
+
+	members[fieldIndex].value, err = json.Marshal(append(bundled, added...))
+	if err != nil {
+		return false, err
+	}
+
+	if err := writeOrderedObject(packageJSONPath, data, members); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -72,6 +73,19 @@ var _ = Describe("Deps Package", Label("integration", "unit"), func() {
 						"react": "npm:react@18.2.0"
 					} 
 				}`),
+			Entry("keeps comment markers inside strings",
+				`{
+					"imports": {
+						"std/": "https://deno.land/std@0.200.0/", // standard library
+						"glob": "/* not a comment */"
+					}
+				}`,
+				`{
+					"imports": {
+						"std/": "https://deno.land/std@0.200.0/", 
+						"glob": "/* not a comment */"
+					}
+				}`),
 		)
 	})
 
@@ -624,6 +638,303 @@ var _ = Describe("Deps Package", Label("integration", "unit"), func() {
 			assert.Error(err)
 		})
 	})
+
+	Context("Removing packages from every dependency group", func() {
+		It("should remove the package from all groups and preserve key order", func() {
+			tempDir := GinkgoT().TempDir()
+			packageJSON := `{
+    "name": "app",
+    "dependencies": {
+        "react": "18.2.0",
+        "lodash": "4.17.21"
+    },
+    "peerDependencies": {
+        "react": ">=18"
+    },
+    "devDependencies": {
+        "typescript": "5.4.0"
+    }
+}
+`
+			err := os.WriteFile(filepath.Join(tempDir, "package.json"), []byte(packageJSON), 0644)
+			assert.NoError(err)
+
+			changed, err := deps.RemovePackagesFromAllGroups(tempDir, []string{"react"})
+			assert.NoError(err)
+			assert.True(changed)
+
+			data, err := os.ReadFile(filepath.Join(tempDir, "package.json"))
+			assert.NoError(err)
+			assert.Equal(`{
+    "name": "app",
+    "dependencies": {
+        "lodash": "4.17.21"
+    },
+    "peerDependencies": {},
+    "devDependencies": {
+        "typescript": "5.4.0"
+    }
+}
+`, string(data))
+		})
+
+		It("should leave package.json untouched when the package is not listed", func() {
+			tempDir := GinkgoT().TempDir()
+			packageJSON := `{"dependencies": {"react": "18.2.0"}}`
+			err := os.WriteFile(filepath.Join(tempDir, "package.json"), []byte(packageJSON), 0644)
+			assert.NoError(err)
+
+			changed, err := deps.RemovePackagesFromAllGroups(tempDir, []string{"lodash"})
+			assert.NoError(err)
+			assert.False(changed)
+
+			data, err := os.ReadFile(filepath.Join(tempDir, "package.json"))
+			assert.NoError(err)
+			assert.Equal(packageJSON, string(data))
+		})
+
+		It("should error when package.json is missing", func() {
+			_, err := deps.RemovePackagesFromAllGroups(GinkgoT().TempDir(), []string{"react"})
+			assert.Error(err)
+		})
+	})
+
+	Context("Adding bundled dependencies", func() {
+		It("should create bundledDependencies and preserve key order and indentation", func() {
+			tempDir := GinkgoT().TempDir()
+			packageJSON := `{
+  "name": "lib",
+  "dependencies": {
+    "lodash": "^4.17.21"
+  }
+}
+`
+			err := os.WriteFile(filepath.Join(tempDir, "package.json"), []byte(packageJSON), 0644)
+			assert.NoError(err)
+
+			changed, err := deps.AddBundledDependencies(tempDir, []string{"lodash"})
+			assert.NoError(err)
+			assert.True(changed)
+
+			data, err := os.ReadFile(filepath.Join(tempDir, "package.json"))
+			assert.NoError(err)
+			assert.Equal(`{
+  "name": "lib",
+  "dependencies": {
+    "lodash": "^4.17.21"
+  },
+  "bundledDependencies": [
+    "lodash"
+  ]
+}
+`, string(data))
+		})
+
+		It("should append to an existing bundleDependencies without duplicates", func() {
+			tempDir := GinkgoT().TempDir()
+			err := os.WriteFile(filepath.Join(tempDir, "package.json"), []byte(`{"bundleDependencies": ["lodash"]}`), 0644)
+			assert.NoError(err)
+
+			changed, err := deps.AddBundledDependencies(tempDir, []string{"lodash", "@scope/util", "@scope/util"})
+			assert.NoError(err)
+			assert.True(changed)
+
+			data, err := os.ReadFile(filepath.Join(tempDir, "package.json"))
+			assert.NoError(err)
+			assert.JSONEq(`{"bundleDependencies": ["lodash", "@scope/util"]}`, string(data))
+		})
+
+		It("should leave package.json untouched when the packages are already bundled", func() {
+			tempDir := GinkgoT().TempDir()
+			packageJSON := `{"bundledDependencies": ["lodash"]}`
+			err := os.WriteFile(filepath.Join(tempDir, "package.json"), []byte(packageJSON), 0644)
+			assert.NoError(err)
+
+			changed, err := deps.AddBundledDependencies(tempDir, []string{"lodash"})
+			assert.NoError(err)
+			assert.False(changed)
+		})
+	})
+
+	Context("Setting a script", func() {
+		It("should append the script and preserve key order and indentation", func() {
+			tempDir := GinkgoT().TempDir()
+			packageJSON := `{
+  "name": "app",
+  "scripts": {
+    "dev": "vite"
+  },
+  "dependencies": {
+    "react": "18.2.0"
+  }
+}
+`
+			manifestPath := filepath.Join(tempDir, "package.json")
+			assert.NoError(os.WriteFile(manifestPath, []byte(packageJSON), 0644))
+
+			err := deps.SetScript(manifestPath, "scripts", "ci", "vitest run && vite build", false)
+			assert.NoError(err)
+
+			data, err := os.ReadFile(manifestPath)
+			assert.NoError(err)
+			assert.Equal(`{
+  "name": "app",
+  "scripts": {
+    "dev": "vite",
+    "ci": "vitest run && vite build"
+  },
+  "dependencies": {
+    "react": "18.2.0"
+  }
+}
+`, string(data))
+		})
+
+		It("should create the field when the manifest has none", func() {
+			tempDir := GinkgoT().TempDir()
+			manifestPath := filepath.Join(tempDir, "deno.json")
+			assert.NoError(os.WriteFile(manifestPath, []byte(`{"imports": {}}`), 0644))
+
+			err := deps.SetScript(manifestPath, "tasks", "dev", "deno run -A main.ts", false)
+			assert.NoError(err)
+
+			data, err := os.ReadFile(manifestPath)
+			assert.NoError(err)
+			assert.JSONEq(`{"imports": {}, "tasks": {"dev": "deno run -A main.ts"}}`, string(data))
+		})
+
+		It("should return ErrScriptExists unless overwrite is set", func() {
+			tempDir := GinkgoT().TempDir()
+			manifestPath := filepath.Join(tempDir, "package.json")
+			assert.NoError(os.WriteFile(manifestPath, []byte(`{"scripts": {"test": "jest"}}`), 0644))
+
+			err := deps.SetScript(manifestPath, "scripts", "test", "vitest", false)
+			assert.ErrorIs(err, deps.ErrScriptExists)
+
+			assert.NoError(deps.SetScript(manifestPath, "scripts", "test", "vitest", true))
+			data, err := os.ReadFile(manifestPath)
+			assert.NoError(err)
+			assert.JSONEq(`{"scripts": {"test": "vitest"}}`, string(data))
+		})
+	})
+
+	Context("Keeping the style of an edited manifest", func() {
+		It("should keep a four space indent", func() {
+			tempDir := GinkgoT().TempDir()
+			manifestPath := filepath.Join(tempDir, "package.json")
+			packageJSON := "{\n    \"name\": \"app\",\n    \"scripts\": {\n        \"dev\": \"vite\"\n    }\n}\n"
+			assert.NoError(os.WriteFile(manifestPath, []byte(packageJSON), 0644))
+
+			assert.NoError(deps.SetScript(manifestPath, "scripts", "build", "vite build", false))
+
+			data, err := os.ReadFile(manifestPath)
+			assert.NoError(err)
+			assert.Equal("{\n    \"name\": \"app\",\n    \"scripts\": {\n        \"dev\": \"vite\",\n        \"build\": \"vite build\"\n    }\n}\n", string(data))
+		})
+
+		It("should keep tabs and a missing trailing newline", func() {
+			tempDir := GinkgoT().TempDir()
+			packageJSON := "{\n\t\"name\": \"lib\",\n\t\"dependencies\": {\n\t\t\"lodash\": \"^4.17.21\"\n\t}\n}"
+			assert.NoError(os.WriteFile(filepath.Join(tempDir, "package.json"), []byte(packageJSON), 0644))
+
+			changed, err := deps.AddBundledDependencies(tempDir, []string{"lodash"})
+			assert.NoError(err)
+			assert.True(changed)
+
+			data, err := os.ReadFile(filepath.Join(tempDir, "package.json"))
+			assert.NoError(err)
+			assert.Equal("{\n\t\"name\": \"lib\",\n\t\"dependencies\": {\n\t\t\"lodash\": \"^4.17.21\"\n\t},\n\t\"bundledDependencies\": [\n\t\t\"lodash\"\n\t]\n}", string(data))
+		})
+
+		It("should keep Windows line endings", func() {
+			tempDir := GinkgoT().TempDir()
+			packageJSON := "{\r\n  \"dependencies\": {\r\n    \"react\": \"18.2.0\",\r\n    \"lodash\": \"^4.17.21\"\r\n  }\r\n}\r\n"
+			assert.NoError(os.WriteFile(filepath.Join(tempDir, "package.json"), []byte(packageJSON), 0644))
+
+			changed, err := deps.RemovePackagesFromAllGroups(tempDir, []string{"lodash"})
+			assert.NoError(err)
+			assert.True(changed)
+
+			data, err := os.ReadFile(filepath.Join(tempDir, "package.json"))
+			assert.NoError(err)
+			assert.Equal("{\r\n  \"dependencies\": {\r\n    \"react\": \"18.2.0\"\r\n  }\r\n}\r\n", string(data))
+		})
+	})
+
+	Context("Checking the lockfile against the last install", func() {
+		It("should take the newest install marker as the last install", func() {
+			tempDir := GinkgoT().TempDir()
+			assert.NoError(os.WriteFile(filepath.Join(tempDir, "pnpm-lock.yaml"), []byte("lockfileVersion: '9.0'\n"), 0644))
+			assert.NoError(os.Mkdir(filepath.Join(tempDir, "node_modules"), 0755))
+			assert.NoError(os.WriteFile(filepath.Join(tempDir, "node_modules", ".modules.yaml"), []byte(""), 0644))
+
+			past := time.Now().Add(-time.Hour)
+			assert.NoError(os.Chtimes(filepath.Join(tempDir, "node_modules"), past, past))
+			assert.NoError(os.Chtimes(filepath.Join(tempDir, "pnpm-lock.yaml"), past.Add(time.Minute), past.Add(time.Minute)))
+
+			newer, err := deps.LockfileNewerThanInstall(tempDir, "pnpm-lock.yaml")
+			assert.NoError(err)
+			assert.False(newer, "pnpm wrote .modules.yaml after the lockfile")
+
+			assert.NoError(os.Chtimes(filepath.Join(tempDir, "node_modules", ".modules.yaml"), past, past))
+			newer, err = deps.LockfileNewerThanInstall(tempDir, "pnpm-lock.yaml")
+			assert.NoError(err)
+			assert.True(newer)
+		})
+
+		It("should never report a lockfile as newer without node_modules", func() {
+			tempDir := GinkgoT().TempDir()
+			assert.NoError(os.WriteFile(filepath.Join(tempDir, "yarn.lock"), []byte(""), 0644))
+
+			newer, err := deps.LockfileNewerThanInstall(tempDir, "yarn.lock")
+			assert.NoError(err)
+			assert.False(newer)
+		})
+	})
+
+	Context("FindWorkspaceRoot", func() {
+		It("walks up to the directory that declares workspaces", func() {
+			root := GinkgoT().TempDir()
+			packageDir := filepath.Join(root, "packages", "ui", "src")
+			assert.NoError(os.MkdirAll(packageDir, 0o755))
+			assert.NoError(os.WriteFile(filepath.Join(root, "pnpm-workspace.yaml"), []byte("packages:\n  - 'packages/*'\n"), 0o644))
+			assert.NoError(os.WriteFile(filepath.Join(root, "packages", "ui", "package.json"), []byte(`{"name": "ui"}`), 0o644))
+
+			found, err := deps.FindWorkspaceRoot(packageDir)
+			assert.NoError(err)
+			assert.Equal(root, found)
+		})
+
+		It("returns ErrNoWorkspaces outside a monorepo", func() {
+			_, err := deps.FindWorkspaceRoot(GinkgoT().TempDir())
+			assert.ErrorIs(err, deps.ErrNoWorkspaces)
+		})
+	})
+
+	Context("DiscoverWorkspaces", func() {
+		It("matches nested directories with ** and skips node_modules", func() {
+			root := GinkgoT().TempDir()
+			for name, content := range map[string]string{
+				"pnpm-workspace.yaml":                           "packages:\n  - '.'\n  - 'libs/**'\n",
+				"package.json":                                  `{"name": "root"}`,
+				"libs/core/package.json":                        `{"name": "core"}`,
+				"libs/plugins/auth/package.json":                `{"name": "auth"}`,
+				"libs/plugins/auth/node_modules/x/package.json": `{"name": "x"}`,
+			} {
+				path := filepath.Join(root, filepath.FromSlash(name))
+				assert.NoError(os.MkdirAll(filepath.Dir(path), 0o755))
+				assert.NoError(os.WriteFile(path, []byte(content), 0o644))
+			}
+
+			workspaces, err := deps.DiscoverWorkspaces(root)
+			assert.NoError(err)
+			assert.Equal([]deps.Workspace{
+				{Name: "root", Path: "."},
+				{Name: "core", Path: "libs/core"},
+				{Name: "auth", Path: "libs/plugins/auth"},
+			}, workspaces)
+		})
+	})
 })
 
 func TestDeps(t *testing.T) {
@@ -11,19 +11,7 @@ import (
 // to make it valid JSON for parsing.
 // This is a simplified implementation that handles most common JSONC features.
 func NormalizeJSONCToJSON(content []byte) []byte {
-	text := string(content)
-
-	// Remove single-line comments (//)
-	singleLineCommentRegex := regexp.MustCompile(`//.*$`)
-	lines := strings.Split(text, "\n")
-	for i, line := range lines {
-		lines[i] = singleLineCommentRegex.ReplaceAllString(line, "")
-	}
-	text = strings.Join(lines, "\n")
-
-	// Remove multi-line comments (/* ... */)
-	multiLineCommentRegex := regexp.MustCompile(`/\*[\s\S]*?\*/`)
-	text = multiLineCommentRegex.ReplaceAllString(text, "")
+	text := stripJSONCComments(string(content))
 
 	// Remove trailing commas before closing brackets/braces
 	// This handles cases like: "key": "value", } or "key": "value", ]
@@ -32,3 +20,50 @@ func NormalizeJSONCToJSON(content []byte) []byte {
 
 	return []byte(text)
 }
+
+// stripJSONCComments removes // and /* */ comments that are outside of string literals,
+// so values like "https://deno.land/x/mod.ts" are kept intact.
+func stripJSONCComments(text string) string {
+	var (
+		builder  strings.Builder
+		inString bool
+	)
+
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+
+		if inString {
+			builder.WriteByte(c)
+			if c == '\\' && i+1 < len(text) {
+				i++
+				builder.WriteByte(text[i])
+			} else if c == '"' {
+				inString = false
+			}
+			continue
+		}
+
+		switch {
+		case c == '"':
+			inString = true
+			builder.WriteByte(c)
+		case strings.HasPrefix(text[i:], "//"):
+			// Keep the newline so line structure is preserved
+			end := strings.IndexByte(text[i:], '\n')
+			if end == -1 {
+				return builder.String()
+			}
+			i += end - 1
+		case strings.HasPrefix(text[i:], "/*"):
+			end := strings.Index(text[i+2:], "*/")
+			if end == -1 {
+				return builder.String()
+			}
+			i += end + 3
+		default:
+			builder.WriteByte(c)
+		}
+	}
+
+	return builder.String()
+}
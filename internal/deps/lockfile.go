@@ -0,0 +1,49 @@
+// Package deps provides functionality for dependency management and detection
+// across different JavaScript package managers and runtime environments.
+package deps
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// installMarkers are the files jpd and the package managers write into node_modules once an install finishes.
+var installMarkers = []string{
+	DepsHashFile,
+	".package-lock.json", // npm
+	".modules.yaml",      // pnpm
+	".yarn-integrity",    // yarn v1
+	".yarn-state.yml",    // yarn v2+ with the node-modules linker
+}
+
+// LockfileNewerThanInstall reports whether the lockfile in cwd changed after the last install into node_modules.
+// The last install is the newest of node_modules and the install markers inside it.
+// Without node_modules there is no install to compare with, so the lockfile is never newer.
+func LockfileNewerThanInstall(cwd, lockfile string) (bool, error) {
+	lockfileInfo, err := os.Stat(filepath.Join(cwd, lockfile))
+	if err != nil {
+		return false, fmt.Errorf("failed to read %s: %w", lockfile, err)
+	}
+
+	nodeModulesPath := filepath.Join(cwd, "node_modules")
+	nodeModulesInfo, err := os.Stat(nodeModulesPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read node_modules: %w", err)
+	}
+
+	lastInstall := nodeModulesInfo.ModTime()
+	for _, marker := range installMarkers {
+		info, err := os.Stat(filepath.Join(nodeModulesPath, marker))
+		if err == nil && info.ModTime().After(lastInstall) {
+			lastInstall = info.ModTime()
+		}
+	}
+
+	return lockfileInfo.ModTime().After(lastInstall), nil
+}
@@ -0,0 +1,85 @@
+// Package deps provides functionality for dependency management and detection
+// across different JavaScript package managers and runtime environments.
+package deps
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// manifestStyle is how a manifest is laid out, so an edited manifest is written back the way it was found.
+type manifestStyle struct {
+	indent          string // One level of indentation, two spaces, four spaces or a tab
+	newline         string // "\n" or "\r\n"
+	trailingNewline bool
+}
+
+// detectManifestStyle reads the style of data, a manifest without indented lines is written with two spaces.
+func detectManifestStyle(data []byte) manifestStyle {
+	style := manifestStyle{indent: "  ", newline: "\n"}
+
+	if bytes.Contains(data, []byte("\r\n")) {
+		style.newline = "\r\n"
+	}
+	style.trailingNewline = bytes.HasSuffix(data, []byte("\n"))
+
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		trimmed := strings.TrimLeft(line, " \t")
+		if trimmed != "" && len(trimmed) < len(line) {
+			style.indent = line[:len(line)-len(trimmed)]
+			break
+		}
+	}
+
+	return style
+}
+
+// format indents the compact JSON in encoded and ends its lines the way the style does.
+func (s manifestStyle) format(encoded []byte) ([]byte, error) {
+	var out bytes.Buffer
+	if err := json.Indent(&out, encoded, "", s.indent); err != nil {
+		return nil, err
+	}
+	if s.trailingNewline {
+		out.WriteByte('\n')
+	}
+
+	// JSON strings can't hold a raw newline, so every one of them ends a line
+	if s.newline != "\n" {
+		return bytes.ReplaceAll(out.Bytes(), []byte("\n"), []byte(s.newline)), nil
+	}
+
+	return out.Bytes(), nil
+}
+
+// writeOrderedObject writes members to path in the style of original,
+// keeping its indentation, line endings and trailing newline.
+func writeOrderedObject(path string, original []byte, members []jsonMember) error {
+	name := filepath.Base(path)
+
+	encoded, err := encodeOrderedObject(members)
+	if err != nil {
+		return err
+	}
+
+	out, err := detectManifestStyle(original).format(encoded)
+	if err != nil {
+		return fmt.Errorf("failed to format %s: %w", name, err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(path, out, info.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,144 @@
+// Package deps provides functionality for dependency management and detection
+// across different JavaScript package managers and runtime environments.
+package deps
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/samber/lo"
+)
+
+// PackageJSONDependencyGroups lists every package.json field that maps package names to versions.
+var PackageJSONDependencyGroups = []string{
+	"dependencies",
+	"devDependencies",
+	"peerDependencies",
+	"optionalDependencies",
+}
+
+type jsonMember struct {
+	key   string
+	value json.RawMessage
+}
+
+// RemovePackagesFromAllGroups removes the given packages from every dependency group
+// in the package.json found in cwd. Key order and indentation of the file are preserved.
+// It returns true when the file was changed.
+func RemovePackagesFromAllGroups(cwd string, packages []string) (bool, error) {
+	packageJSONPath := filepath.Join(cwd, "package.json")
+	data, err := os.ReadFile(packageJSONPath)
+	if err != nil {
+		return false, fmt.Errorf("failed to read package.json: %w", err)
+	}
+
+	members, err := decodeOrderedObject(data)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse package.json: %w", err)
+	}
+
+	changed := false
+
+	for i, member := range members {
+		if !lo.Contains(PackageJSONDependencyGroups, member.key) {
+			continue
+		}
+
+		group, err := decodeOrderedObject(member.value)
+		if err != nil {
+			return false, fmt.Errorf("failed to parse %s in package.json: %w", member.key, err)
+		}
+
+		kept := lo.Reject(group, func(dep jsonMember, _ int) bool {
+			return lo.Contains(packages, dep.key)
+		})
+
+		if len(kept) == len(group) {
+			continue
+		}
+
+		changed = true
+		members[i].value, err = encodeOrderedObject(kept)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if !changed {
+		return false, nil
+	}
+
+	if err := writeOrderedObject(packageJSONPath, data, members); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// decodeOrderedObject decodes a JSON object into its members while keeping their order.
+func decodeOrderedObject(data []byte) ([]jsonMember, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return nil, fmt.Errorf("expected a JSON object")
+	}
+
+	var members []jsonMember
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		key, ok := token.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected an object key, got %v", token)
+		}
+
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return nil, err
+		}
+
+		members = append(members, jsonMember{key: key, value: value})
+	}
+
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+// encodeOrderedObject encodes members back into a compact JSON object.
+func encodeOrderedObject(members []jsonMember) (json.RawMessage, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+
+	for i, member := range members {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+
+		key, err := json.Marshal(member.key)
+		if err != nil {
+			return nil, err
+		}
+		b.Write(key)
+		b.WriteByte(':')
+
+		if err := json.Compact(&b, member.value); err != nil {
+			return nil, err
+		}
+	}
+
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
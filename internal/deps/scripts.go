@@ -0,0 +1,81 @@
+// Package deps provides functionality for dependency management and detection
+// across different JavaScript package managers and runtime environments.
+package deps
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/samber/lo"
+)
+
+// ErrScriptExists is returned by SetScript when the script is already defined and overwrite is false.
+var ErrScriptExists = errors.New("script already exists")
+
+// SetScript adds or updates the name entry of the field object ("scripts" in package.json,
+// "tasks" in deno.json) of the manifest at manifestPath. Key order and indentation are preserved.
+func SetScript(manifestPath, field, name, command string, overwrite bool) error {
+	manifest := filepath.Base(manifestPath)
+
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", manifest, err)
+	}
+
+	members, err := decodeOrderedObject(data)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", manifest, err)
+	}
+
+	_, fieldIndex, found := lo.FindIndexOf(members, func(member jsonMember) bool {
+		return member.key == field
+	})
+	if !found {
+		members = append(members, jsonMember{key: field, value: json.RawMessage("{}")})
+		fieldIndex = len(members) - 1
+	}
+
+	scripts, err := decodeOrderedObject(members[fieldIndex].value)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s in %s: %w", field, manifest, err)
+	}
+
+	value, err := marshalUnescaped(command)
+	if err != nil {
+		return err
+	}
+
+	_, scriptIndex, exists := lo.FindIndexOf(scripts, func(script jsonMember) bool {
+		return script.key == name
+	})
+	switch {
+	case exists && !overwrite:
+		return fmt.Errorf("%w: %q is already defined in the %s of %s", ErrScriptExists, name, field, manifest)
+	case exists:
+		scripts[scriptIndex].value = value
+	default:
+		scripts = append(scripts, jsonMember{key: name, value: value})
+	}
+
+	members[fieldIndex].value, err = encodeOrderedObject(scripts)
+	if err != nil {
+		return err
+	}
+
+	return writeOrderedObject(manifestPath, data, members)
+}
+
+// marshalUnescaped encodes value without escaping &, < and >, which are common in shell commands.
+func marshalUnescaped(value any) (json.RawMessage, error) {
+	var b bytes.Buffer
+	encoder := json.NewEncoder(&b)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSpace(b.Bytes()), nil
+}
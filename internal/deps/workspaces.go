@@ -0,0 +1,249 @@
+// Package deps provides functionality for dependency management and detection
+// across different JavaScript package managers and runtime environments.
+package deps
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/samber/lo"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrNoWorkspaces is returned when none of the manifests in a directory declare workspaces.
+var ErrNoWorkspaces = errors.New("no workspaces are declared in pnpm-workspace.yaml, package.json or deno.json")
+
+// Workspace is a package of a monorepo.
+// Path is relative to the monorepo root and uses forward slashes.
+type Workspace struct {
+	Name string `json:"name,omitempty"`
+	Path string `json:"path"`
+}
+
+// DiscoverWorkspaces lists the workspace packages of the monorepo in root sorted by path.
+// The globs come from the packages of pnpm-workspace.yaml, the workspaces of package.json
+// (array or object form) or the workspace of deno.json, in that order.
+// Globs starting with ! exclude the directories they match and
+// directories without a manifest are skipped.
+func DiscoverWorkspaces(root string) ([]Workspace, error) {
+	patterns, err := readWorkspacePatterns(root)
+	if err != nil {
+		return nil, err
+	}
+
+	var included, excluded []string
+	for _, pattern := range patterns {
+		if negated, ok := strings.CutPrefix(pattern, "!"); ok {
+			excluded = append(excluded, expandWorkspaceGlob(root, negated)...)
+			continue
+		}
+		included = append(included, expandWorkspaceGlob(root, pattern)...)
+	}
+
+	dirs := lo.Without(lo.Uniq(included), excluded...)
+	sort.Strings(dirs)
+
+	workspaces := []Workspace{}
+	for _, dir := range dirs {
+		name, found, err := readWorkspaceName(filepath.Join(root, filepath.FromSlash(dir)))
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			continue
+		}
+
+		workspaces = append(workspaces, Workspace{Name: name, Path: dir})
+	}
+
+	return workspaces, nil
+}
+
+// FindWorkspaceRoot returns the nearest directory, starting with dir itself and walking up,
+// whose pnpm-workspace.yaml, package.json or deno.json declares workspaces.
+// ErrNoWorkspaces is returned when no such directory exists.
+func FindWorkspaceRoot(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		_, err := readWorkspacePatterns(dir)
+		if err == nil {
+			return dir, nil
+		}
+		if !errors.Is(err, ErrNoWorkspaces) {
+			return "", err
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", ErrNoWorkspaces
+		}
+		dir = parent
+	}
+}
+
+// readWorkspacePatterns reads the workspace globs of the first manifest in root that declares them.
+func readWorkspacePatterns(root string) ([]string, error) {
+	data, err := os.ReadFile(filepath.Join(root, "pnpm-workspace.yaml"))
+	if err == nil {
+		var pnpmWorkspace struct {
+			Packages []string `yaml:"packages"`
+		}
+		if err := yaml.Unmarshal(data, &pnpmWorkspace); err != nil {
+			return nil, fmt.Errorf("failed to parse pnpm-workspace.yaml: %w", err)
+		}
+		return pnpmWorkspace.Packages, nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read pnpm-workspace.yaml: %w", err)
+	}
+
+	data, err = os.ReadFile(filepath.Join(root, "package.json"))
+	if err == nil {
+		var pkg struct {
+			Workspaces json.RawMessage `json:"workspaces"`
+		}
+		if err := json.Unmarshal(data, &pkg); err != nil {
+			return nil, fmt.Errorf("failed to parse package.json: %w", err)
+		}
+		if len(pkg.Workspaces) > 0 {
+			// Yarn v1 also accepts {"packages": [...], "nohoist": [...]}
+			patterns, err := unmarshalPatterns(pkg.Workspaces, "packages")
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse the workspaces of package.json: %w", err)
+			}
+			return patterns, nil
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read package.json: %w", err)
+	}
+
+	for _, manifest := range []string{"deno.json", "deno.jsonc"} {
+		data, err := os.ReadFile(filepath.Join(root, manifest))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", manifest, err)
+		}
+
+		var denoJSON struct {
+			Workspace json.RawMessage `json:"workspace"`
+		}
+		if err := json.Unmarshal(NormalizeJSONCToJSON(data), &denoJSON); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", manifest, err)
+		}
+		if len(denoJSON.Workspace) == 0 {
+			break
+		}
+
+		patterns, err := unmarshalPatterns(denoJSON.Workspace, "members")
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the workspace of %s: %w", manifest, err)
+		}
+		return patterns, nil
+	}
+
+	return nil, ErrNoWorkspaces
+}
+
+// unmarshalPatterns reads a list of globs that is either an array or an object holding the array in field.
+func unmarshalPatterns(data json.RawMessage, field string) ([]string, error) {
+	var patterns []string
+	if err := json.Unmarshal(data, &patterns); err == nil {
+		return patterns, nil
+	}
+
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(object[field], &patterns); err != nil {
+		return nil, err
+	}
+	return patterns, nil
+}
+
+// expandWorkspaceGlob returns the directories under root that pattern matches, relative to root.
+// Besides the path.Match syntax a ** segment matches any number of directories.
+func expandWorkspaceGlob(root, pattern string) []string {
+	pattern = path.Clean(strings.TrimPrefix(filepath.ToSlash(pattern), "./"))
+	if pattern == "." {
+		return []string{"."}
+	}
+
+	var matches []string
+	var match func(dir string, segments []string)
+	match = func(dir string, segments []string) {
+		if len(segments) == 0 {
+			matches = append(matches, dir)
+			return
+		}
+
+		segment, rest := segments[0], segments[1:]
+		if segment == "**" {
+			match(dir, rest)
+		}
+
+		entries, err := os.ReadDir(filepath.Join(root, filepath.FromSlash(dir)))
+		if err != nil {
+			return
+		}
+
+		for _, entry := range entries {
+			// Installed packages are never workspaces
+			if !entry.IsDir() || entry.Name() == "node_modules" {
+				continue
+			}
+
+			child := path.Join(dir, entry.Name())
+			if segment == "**" {
+				if !strings.HasPrefix(entry.Name(), ".") {
+					match(child, segments)
+				}
+				continue
+			}
+
+			if ok, _ := path.Match(segment, entry.Name()); ok {
+				match(child, rest)
+			}
+		}
+	}
+
+	match(".", strings.Split(pattern, "/"))
+	return matches
+}
+
+// readWorkspaceName reads the name of the package in dir from package.json, deno.json or deno.jsonc.
+// found is false when dir has none of them.
+func readWorkspaceName(dir string) (name string, found bool, err error) {
+	for _, manifest := range []string{"package.json", "deno.json", "deno.jsonc"} {
+		data, err := os.ReadFile(filepath.Join(dir, manifest))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return "", false, fmt.Errorf("failed to read %s: %w", filepath.Join(dir, manifest), err)
+		}
+
+		var pkg struct {
+			Name string `json:"name"`
+		}
+		if err := json.Unmarshal(NormalizeJSONCToJSON(data), &pkg); err != nil {
+			return "", false, fmt.Errorf("failed to parse %s: %w", filepath.Join(dir, manifest), err)
+		}
+		return pkg.Name, true, nil
+	}
+
+	return "", false, nil
+}
@@ -0,0 +1,227 @@
+// Package engines checks versions against the semver ranges used by the engines field of package.json.
+package engines
+
+import (
+	// standard library
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version is a major.minor.patch version. Prerelease and build metadata are ignored.
+type Version [3]int
+
+// ParseVersion parses versions such as "v20.11.1" or "18.2.0".
+func ParseVersion(s string) (Version, error) {
+	parts, count, err := parsePartial(strings.TrimPrefix(strings.TrimSpace(s), "v"))
+	if err != nil {
+		return Version{}, err
+	}
+	if count == 0 {
+		return Version{}, fmt.Errorf("invalid version %q", s)
+	}
+	return parts, nil
+}
+
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v[0], v[1], v[2])
+}
+
+func (v Version) compare(other Version) int {
+	for i := range v {
+		if v[i] != other[i] {
+			if v[i] < other[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// comparator is a single bound such as ">=18.0.0".
+type comparator struct {
+	op      string
+	version Version
+}
+
+func (c comparator) matches(v Version) bool {
+	result := v.compare(c.version)
+	switch c.op {
+	case ">=":
+		return result >= 0
+	case ">":
+		return result > 0
+	case "<=":
+		return result <= 0
+	case "<":
+		return result < 0
+	default:
+		return result == 0
+	}
+}
+
+// Satisfies reports whether version is inside the npm style range,
+// e.g. ">=18", "^20.11.0", "18.x || 20.x" or "16.14.0 - 18".
+func Satisfies(version Version, versionRange string) (bool, error) {
+	for _, set := range strings.Split(versionRange, "||") {
+		comparators, err := parseComparatorSet(set)
+		if err != nil {
+			return false, fmt.Errorf("invalid range %q: %w", versionRange, err)
+		}
+
+		matched := true
+		for _, c := range comparators {
+			if !c.matches(version) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// parseComparatorSet turns the space separated comparators of one || branch into bounds.
+func parseComparatorSet(set string) ([]comparator, error) {
+	fields := strings.Fields(set)
+
+	// Hyphen ranges: "1.2.3 - 2.3.4"
+	if len(fields) == 3 && fields[1] == "-" {
+		lower, err := expand(">=", fields[0])
+		if err != nil {
+			return nil, err
+		}
+		upper, err := expand("<=", fields[2])
+		if err != nil {
+			return nil, err
+		}
+		return append(lower, upper...), nil
+	}
+
+	var comparators []comparator
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+		op := leadingOperator(field)
+
+		// Allow a space between the operator and the version: ">= 18"
+		if op == field && i+1 < len(fields) {
+			i++
+			field += fields[i]
+		}
+
+		expanded, err := expand(op, strings.TrimPrefix(field, op))
+		if err != nil {
+			return nil, err
+		}
+		comparators = append(comparators, expanded...)
+	}
+
+	return comparators, nil
+}
+
+func leadingOperator(field string) string {
+	for _, op := range []string{">=", "<=", ">", "<", "=", "^", "~"} {
+		if strings.HasPrefix(field, op) {
+			return op
+		}
+	}
+	return ""
+}
+
+// expand turns an operator and a possibly partial version into plain bounds.
+func expand(op, partial string) ([]comparator, error) {
+	version, count, err := parsePartial(strings.TrimPrefix(partial, "v"))
+	if err != nil {
+		return nil, err
+	}
+
+	// "*", "x" and "" match everything
+	if count == 0 {
+		if op == "<" || op == ">" {
+			return []comparator{{op: "<", version: Version{}}}, nil
+		}
+		return nil, nil
+	}
+
+	// next is the first version after the partial, e.g. 18.2 -> 18.3.0
+	next := bump(version, count-1)
+
+	switch op {
+	case "", "=":
+		if count == 3 {
+			return []comparator{{op: "=", version: version}}, nil
+		}
+		return []comparator{{op: ">=", version: version}, {op: "<", version: next}}, nil
+	case ">=", "<":
+		return []comparator{{op: op, version: version}}, nil
+	case ">":
+		if count == 3 {
+			return []comparator{{op: ">", version: version}}, nil
+		}
+		return []comparator{{op: ">=", version: next}}, nil
+	case "<=":
+		if count == 3 {
+			return []comparator{{op: "<=", version: version}}, nil
+		}
+		return []comparator{{op: "<", version: next}}, nil
+	case "~":
+		// ~1.2.3 and ~1.2 allow patch changes, ~1 allows minor changes
+		return []comparator{{op: ">=", version: version}, {op: "<", version: bump(version, min(count-1, 1))}}, nil
+	case "^":
+		// ^ allows changes that keep the left most non zero part
+		index := 0
+		for index < count-1 && version[index] == 0 {
+			index++
+		}
+		return []comparator{{op: ">=", version: version}, {op: "<", version: bump(version, index)}}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported operator %q", op)
+}
+
+// bump increments the part at index and zeroes the parts after it.
+func bump(v Version, index int) Version {
+	bumped := v
+	bumped[index]++
+	for i := index + 1; i < len(bumped); i++ {
+		bumped[i] = 0
+	}
+	return bumped
+}
+
+// parsePartial parses up to three dot separated numbers and returns how many were given.
+// Wildcards (x, X, *) end the version early.
+func parsePartial(s string) (Version, int, error) {
+	var version Version
+
+	// Prerelease and build metadata don't change which range a version falls in here
+	if index := strings.IndexAny(s, "-+"); index >= 0 {
+		s = s[:index]
+	}
+	if s == "" {
+		return version, 0, nil
+	}
+
+	parts := strings.Split(s, ".")
+	if len(parts) > 3 {
+		return version, 0, fmt.Errorf("invalid version %q", s)
+	}
+
+	for i, part := range parts {
+		if part == "x" || part == "X" || part == "*" {
+			return version, i, nil
+		}
+
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return version, 0, fmt.Errorf("invalid version %q", s)
+		}
+		version[i] = n
+	}
+
+	return version, len(parts), nil
+}
@@ -0,0 +1,63 @@
+package engines_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/louiss0/javascript-package-delegator/internal/engines"
+)
+
+func TestEngines(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Engines Suite")
+}
+
+var _ = Describe("Engines", func() {
+	assert := assert.New(GinkgoT())
+
+	It("parses node versions", func() {
+		version, err := engines.ParseVersion("v20.11.1\n")
+		assert.NoError(err)
+		assert.Equal(engines.Version{20, 11, 1}, version)
+
+		_, err = engines.ParseVersion("not a version")
+		assert.Error(err)
+	})
+
+	DescribeTable("Satisfies",
+		func(version, versionRange string, expected bool) {
+			parsed, err := engines.ParseVersion(version)
+			assert.NoError(err)
+
+			satisfied, err := engines.Satisfies(parsed, versionRange)
+			assert.NoError(err)
+			assert.Equal(expected, satisfied)
+		},
+		Entry(">= matches a newer major", "20.11.1", ">=18", true),
+		Entry(">= rejects an older major", "16.20.2", ">=18", false),
+		Entry(">= with a space before the version", "18.0.0", ">= 18", true),
+		Entry("bounded range", "20.0.0", ">=18.17.0 <21", true),
+		Entry("bounded range upper bound", "21.0.0", ">=18.17.0 <21", false),
+		Entry("caret keeps the major", "18.19.0", "^18.17.0", true),
+		Entry("caret rejects the next major", "19.0.0", "^18.17.0", false),
+		Entry("caret on zero major keeps the minor", "0.3.0", "^0.2.3", false),
+		Entry("tilde keeps the minor", "20.1.9", "~20.1", true),
+		Entry("tilde rejects the next minor", "20.2.0", "~20.1", false),
+		Entry("x range", "18.4.0", "18.x", true),
+		Entry("or ranges", "20.5.0", "16.x || 18.x || 20.x", true),
+		Entry("or ranges without a match", "19.5.0", "16.x || 18.x || 20.x", false),
+		Entry("hyphen range", "17.9.0", "16.14.0 - 18", true),
+		Entry("hyphen range upper partial", "19.0.0", "16.14.0 - 18", false),
+		Entry("greater than a partial", "18.9.0", ">18", false),
+		Entry("exact version", "20.11.1", "20.11.1", true),
+		Entry("wildcard", "4.0.0", "*", true),
+	)
+
+	It("rejects malformed ranges", func() {
+		_, err := engines.Satisfies(engines.Version{20, 0, 0}, ">=eighteen")
+		assert.Error(err)
+	})
+})
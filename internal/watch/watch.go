@@ -0,0 +1,165 @@
+// Package watch provides a dependency free file watcher that polls a directory tree for changes.
+package watch
+
+import (
+	// standard library
+	"io/fs"
+	"path/filepath"
+	"sync"
+	"time"
+
+	// external
+	"github.com/samber/lo"
+)
+
+// IgnoredDirs are directories that are never walked because they change without the user editing them.
+var IgnoredDirs = []string{"node_modules", ".git"}
+
+// PollingWatcher reports files that were created, modified or removed under a directory.
+// It compares modification times on every tick, so it works the same on every platform.
+type PollingWatcher struct {
+	root     string
+	interval time.Duration
+	events   chan string
+	reset    chan struct{}
+	done     chan struct{}
+	once     sync.Once
+}
+
+// NewPollingWatcher starts watching root, checking for changes once per interval.
+func NewPollingWatcher(root string, interval time.Duration) (*PollingWatcher, error) {
+	snapshot, err := takeSnapshot(root)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &PollingWatcher{
+		root:     root,
+		interval: interval,
+		events:   make(chan string),
+		reset:    make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+
+	go w.poll(snapshot)
+
+	return w, nil
+}
+
+// Events returns the paths of the files that changed.
+// The channel is closed once the watcher is closed.
+func (w *PollingWatcher) Events() <-chan string {
+	return w.events
+}
+
+// Reset forgets the changes that weren't reported yet and compares the next ticks with the files as they are now.
+// Changes made before Reset, like the output of a script that just ran, are never reported.
+func (w *PollingWatcher) Reset() {
+	select {
+	case w.reset <- struct{}{}:
+	case <-w.done:
+	}
+}
+
+// Close stops the watcher.
+func (w *PollingWatcher) Close() error {
+	w.once.Do(func() {
+		close(w.done)
+	})
+	return nil
+}
+
+func (w *PollingWatcher) poll(previous map[string]time.Time) {
+	defer close(w.events)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	// resnapshot makes the files as they are now the baseline, the old one is kept when they can't be read
+	resnapshot := func() {
+		if current, err := takeSnapshot(w.root); err == nil {
+			previous = current
+		}
+	}
+
+	for {
+		select {
+		case <-w.done:
+			return
+		case <-w.reset:
+			resnapshot()
+			continue
+		case <-ticker.C:
+		}
+
+		current, err := takeSnapshot(w.root)
+		if err != nil {
+			// The directory may be mid rename, try again on the next tick
+			continue
+		}
+
+		changed := changedPaths(previous, current)
+		previous = current
+
+	report:
+		for _, path := range changed {
+			select {
+			case w.events <- path:
+			case <-w.reset:
+				// The changes left are dropped along with the ones of the next snapshot
+				resnapshot()
+				break report
+			case <-w.done:
+				return
+			}
+		}
+	}
+}
+
+// takeSnapshot records the modification time of every file under root.
+func takeSnapshot(root string) (map[string]time.Time, error) {
+	snapshot := map[string]time.Time{}
+
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			// Files can disappear between listing and stat
+			return nil
+		}
+
+		if entry.IsDir() {
+			if path != root && lo.Contains(IgnoredDirs, entry.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return nil
+		}
+
+		snapshot[path] = info.ModTime()
+		return nil
+	})
+
+	return snapshot, err
+}
+
+// changedPaths lists the files that were added, modified or removed between two snapshots.
+func changedPaths(previous, current map[string]time.Time) []string {
+	var changed []string
+
+	for path, modTime := range current {
+		if previousModTime, ok := previous[path]; !ok || !previousModTime.Equal(modTime) {
+			changed = append(changed, path)
+		}
+	}
+
+	for path := range previous {
+		if _, ok := current[path]; !ok {
+			changed = append(changed, path)
+		}
+	}
+
+	return changed
+}
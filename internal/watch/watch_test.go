@@ -0,0 +1,92 @@
+package watch_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/louiss0/javascript-package-delegator/internal/watch"
+)
+
+func TestWatch(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Watch Suite")
+}
+
+var _ = Describe("PollingWatcher", func() {
+	assert := assert.New(GinkgoT())
+
+	var (
+		root    string
+		watcher *watch.PollingWatcher
+	)
+
+	// nextEvent waits for the next change or returns an empty string after the timeout.
+	nextEvent := func(timeout time.Duration) string {
+		select {
+		case path := <-watcher.Events():
+			return path
+		case <-time.After(timeout):
+			return ""
+		}
+	}
+
+	BeforeEach(func() {
+		root = GinkgoT().TempDir()
+		assert.NoError(os.MkdirAll(filepath.Join(root, "node_modules", "react"), 0o755))
+		assert.NoError(os.WriteFile(filepath.Join(root, "index.js"), []byte("one"), 0o644))
+
+		var err error
+		watcher, err = watch.NewPollingWatcher(root, 10*time.Millisecond)
+		assert.NoError(err)
+	})
+
+	AfterEach(func() {
+		assert.NoError(watcher.Close())
+	})
+
+	It("reports a new file", func() {
+		path := filepath.Join(root, "app.js")
+		assert.NoError(os.WriteFile(path, []byte("app"), 0o644))
+
+		assert.Equal(path, nextEvent(2*time.Second))
+	})
+
+	It("reports a modified file", func() {
+		path := filepath.Join(root, "index.js")
+		later := time.Now().Add(time.Minute)
+		assert.NoError(os.Chtimes(path, later, later))
+
+		assert.Equal(path, nextEvent(2*time.Second))
+	})
+
+	It("ignores files inside node_modules", func() {
+		assert.NoError(os.WriteFile(filepath.Join(root, "node_modules", "react", "index.js"), []byte("react"), 0o644))
+
+		assert.Equal("", nextEvent(200*time.Millisecond))
+	})
+
+	It("drops the changes made before Reset", func() {
+		assert.NoError(os.MkdirAll(filepath.Join(root, "dist"), 0o755))
+		assert.NoError(os.WriteFile(filepath.Join(root, "dist", "index.js"), []byte("built"), 0o644))
+		watcher.Reset()
+
+		assert.Equal("", nextEvent(200*time.Millisecond))
+
+		path := filepath.Join(root, "app.js")
+		assert.NoError(os.WriteFile(path, []byte("app"), 0o644))
+		assert.Equal(path, nextEvent(2*time.Second))
+	})
+
+	It("closes the events channel once closed", func() {
+		assert.NoError(watcher.Close())
+
+		_, open := <-watcher.Events()
+		assert.False(open)
+	})
+})
@@ -61,6 +61,8 @@ type MockCommandRunner struct {
 	CommandCall     CommandCall
 	InvalidCommands []string
 	WorkingDir      string
+	Env             []string
+	EnvCleared      bool
 	commandHistory  []CommandCall
 }
 
@@ -141,6 +143,16 @@ func (m *MockCommandRunner) SetTargetDir(dir string) error {
 	return nil
 }
 
+// SetEnv records the environment entries passed to the command
+func (m *MockCommandRunner) SetEnv(env []string) {
+	m.Env = env
+}
+
+// ClearEnv records that the command doesn't inherit the environment
+func (m *MockCommandRunner) ClearEnv() {
+	m.EnvCleared = true
+}
+
 // Run simulates running the command
 func (m *MockCommandRunner) Run() error {
 	// If no command was set, return an error (unless tests override via expectation)
@@ -206,6 +218,8 @@ func (m *MockCommandRunner) Reset() {
 	m.CommandCall = CommandCall{}
 	m.InvalidCommands = []string{}
 	m.WorkingDir = ""
+	m.Env = nil
+	m.EnvCleared = false
 	m.commandHistory = []CommandCall{}
 	m.Mock = mock.Mock{}
 }
@@ -223,6 +237,11 @@ func (m *MockCommandRunner) LastCommand() (CommandCall, bool) {
 	return m.CommandCall, true
 }
 
+// CommandHistory returns every command recorded so far in the order they were set.
+func (m *MockCommandRunner) CommandHistory() []CommandCall {
+	return m.commandHistory
+}
+
 // WasCommandCalled checks the full command history for a specific invocation.
 func (m *MockCommandRunner) WasCommandCalled(name string, args ...string) bool {
 	for _, call := range m.commandHistory {
@@ -277,6 +296,56 @@ func NewMockYarnCommandVersionOutputer(version string) *MockYarnCommandVersionOu
 	return mockOutputer
 }
 
+// MockFileWatcher implements the cmd.FileWatcher interface with a fixed list of changes
+type MockFileWatcher struct {
+	paths    []string
+	events   chan string
+	released bool
+	Closed   bool
+}
+
+// NewMockFileWatcher creates a MockFileWatcher that reports the given paths once the first run is over and then stops
+func NewMockFileWatcher(paths ...string) *MockFileWatcher {
+	return &MockFileWatcher{
+		paths:  paths,
+		events: make(chan string, len(paths)+8),
+	}
+}
+
+// Events returns the queued changes
+func (w *MockFileWatcher) Events() <-chan string {
+	return w.events
+}
+
+// Emit queues a change made while the script runs, it is dropped by the next Reset
+func (w *MockFileWatcher) Emit(path string) {
+	if !w.released {
+		w.events <- path
+	}
+}
+
+// Reset drops the emitted changes, the first one releases the given paths
+func (w *MockFileWatcher) Reset() {
+	for len(w.events) > 0 {
+		<-w.events
+	}
+
+	if w.released {
+		return
+	}
+	w.released = true
+	for _, path := range w.paths {
+		w.events <- path
+	}
+	close(w.events)
+}
+
+// Close records that the watcher was closed
+func (w *MockFileWatcher) Close() error {
+	w.Closed = true
+	return nil
+}
+
 // MockCommandTextUI implements the cmd.CommandUITexter interface using testify/mock
 type MockCommandTextUI struct {
 	mock.Mock
@@ -684,3 +753,28 @@ func (m *CreateAppSearcherMock) On(method string, arguments ...interface{}) *moc
 func (m *CreateAppSearcherMock) AssertExpectations(t mock.TestingT) bool {
 	return m.m.AssertExpectations(t)
 }
+
+// PeerDependencyFetcherMock implements the cmd.PeerDependencyFetcher interface using testify/mock
+type PeerDependencyFetcherMock struct {
+	m mock.Mock // private field
+}
+
+// PeerDependencies implements PeerDependencyFetcher.PeerDependencies
+func (m *PeerDependencyFetcherMock) PeerDependencies(pkg string) (map[string]string, error) {
+	args := m.m.Called(pkg)
+	var out map[string]string
+	if v := args.Get(0); v != nil {
+		out = v.(map[string]string)
+	}
+	return out, args.Error(1)
+}
+
+// On provides a passthrough to support arranging expectations while keeping field private
+func (m *PeerDependencyFetcherMock) On(method string, arguments ...interface{}) *mock.Call {
+	return m.m.On(method, arguments...)
+}
+
+// AssertExpectations provides a passthrough to assert expectations
+func (m *PeerDependencyFetcherMock) AssertExpectations(t mock.TestingT) bool {
+	return m.m.AssertExpectations(t)
+}
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/samber/lo"
+)
+
+// PackageSearcher searches for packages matching a pattern.
+// NpmRegistryService searches over HTTP, the npm CLI searcher shells out to npm search.
+type PackageSearcher interface {
+	SearchPackages(pattern string) ([]PackageInfo, error)
+}
+
+// NpmCLIOutputter returns what the npm CLI prints to stdout when it runs with args.
+type NpmCLIOutputter func(args ...string) ([]byte, error)
+
+// npmCLISearcher searches with 'npm search --json', so the registry and proxy settings of npm are used.
+type npmCLISearcher struct {
+	output NpmCLIOutputter
+}
+
+// NewNpmCLISearcher creates a PackageSearcher that reads the results of 'npm search --json' from output.
+func NewNpmCLISearcher(output NpmCLIOutputter) PackageSearcher {
+	return &npmCLISearcher{output: output}
+}
+
+// npmCLISearchResult is one entry of the array 'npm search --json' prints.
+type npmCLISearchResult struct {
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	Description string `json:"description"`
+	Links       struct {
+		Homepage   string `json:"homepage"`
+		Repository string `json:"repository"`
+		Npm        string `json:"npm"`
+	} `json:"links"`
+}
+
+// SearchPackages runs 'npm search --json' for pattern and parses its output into a slice of PackageInfo.
+// It asks for as many results as the registry search does.
+func (s *npmCLISearcher) SearchPackages(pattern string) ([]PackageInfo, error) {
+	if pattern == "" {
+		return nil, fmt.Errorf("search pattern cannot be empty")
+	}
+
+	output, err := s.output("search", "--json", "--searchlimit=35", pattern)
+	if err != nil {
+		return nil, fmt.Errorf("failed to run npm search: %w", err)
+	}
+
+	var results []npmCLISearchResult
+	if err := json.Unmarshal(output, &results); err != nil {
+		return nil, fmt.Errorf("failed to parse npm search output: %w", err)
+	}
+
+	return lo.Map(results, func(result npmCLISearchResult, _ int) PackageInfo {
+		homepage := result.Links.Homepage
+		if homepage == "" {
+			homepage = result.Links.Repository
+		}
+		if homepage == "" {
+			homepage = result.Links.Npm
+		}
+
+		return PackageInfo{
+			Name:        result.Name,
+			Version:     result.Version,
+			Description: result.Description,
+			Homepage:    homepage,
+		}
+	}), nil
+}
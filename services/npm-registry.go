@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/samber/lo" // Import samber/lo
@@ -25,7 +26,7 @@ type PackageInfo struct {
 type NpmRegistryService interface {
 	SearchPackages(pattern string) ([]PackageInfo, error)
 	SearchCreateApps(query string, size int) ([]PackageInfo, error)
-	// Add other methods like GetPackageInfo(name string) if needed later
+	PeerDependencies(pkg string) (map[string]string, error)
 }
 
 // npmRegistryServiceImpl is the concrete implementation of NpmRegistryService
@@ -34,6 +35,8 @@ type npmRegistryServiceImpl struct {
 	client *http.Client
 	// baseSearchURL is the full base URL for the search endpoint, e.g., "https://registry.npmjs.com/-/v1/search"
 	baseSearchURL string
+	// baseRegistryURL is the URL the package documents are read from, e.g., "https://registry.npmjs.org"
+	baseRegistryURL string
 }
 
 // NewNpmRegistryService creates a new instance of NpmRegistryService
@@ -43,7 +46,8 @@ func NewNpmRegistryService() NpmRegistryService {
 		client: &http.Client{
 			Timeout: 10 * time.Second, // Set a reasonable timeout for HTTP requests
 		},
-		baseSearchURL: "https://registry.npmjs.com/-/v1/search", // Default to real npm search API endpoint
+		baseSearchURL:   "https://registry.npmjs.com/-/v1/search", // Default to real npm search API endpoint
+		baseRegistryURL: "https://registry.npmjs.org",
 	}
 }
 
@@ -56,6 +60,15 @@ func NewNpmRegistryServiceWithClient(client *http.Client, baseSearchURL string)
 	}
 }
 
+// NewNpmRegistryServiceWithRegistryURL allows injecting a custom HTTP client and the base URL
+// the package documents are read from, so PeerDependencies can be tested against a mock server.
+func NewNpmRegistryServiceWithRegistryURL(client *http.Client, baseRegistryURL string) NpmRegistryService {
+	return &npmRegistryServiceImpl{
+		client:          client,
+		baseRegistryURL: baseRegistryURL,
+	}
+}
+
 // npmSearchResponse is the internal struct for decoding the npm search API response.
 // It matches the structure returned by `https://registry.npmjs.com/-/v1/search`.
 type npmSearchResponse struct {
@@ -218,3 +231,44 @@ func (s *npmRegistryServiceImpl) SearchCreateApps(query string, size int) ([]Pac
 	})
 	return pkgs, nil
 }
+
+// PeerDependencies returns the peerDependencies of pkg, a package name optionally followed by @version or @tag.
+// Without a version the latest release is read.
+func (s *npmRegistryServiceImpl) PeerDependencies(pkg string) (map[string]string, error) {
+	name, version := pkg, "latest"
+	// The @ of a scope is not a version separator
+	if i := strings.LastIndex(pkg, "@"); i > 0 {
+		name, version = pkg[:i], pkg[i+1:]
+	}
+	if name == "" || version == "" {
+		return nil, fmt.Errorf("invalid package %q", pkg)
+	}
+
+	// The registry expects the slash of a scoped name to be escaped
+	url := fmt.Sprintf("%s/%s/%s", s.baseRegistryURL, strings.Replace(name, "/", "%2F", 1), version)
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make HTTP request to npm registry: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("npm registry returned status %d for %s: %s (body: %s)", resp.StatusCode, pkg, resp.Status, string(bodyBytes))
+	}
+
+	var manifest struct {
+		PeerDependencies map[string]string `json:"peerDependencies"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse npm registry response: %w", err)
+	}
+
+	return manifest.PeerDependencies, nil
+}
@@ -871,3 +871,95 @@ func TestNpmRegistryService_SearchCreateApps_HomepageFallbackLogic(t *testing.T)
 	// Third package should fallback to npm
 	assert.Equal(t, "https://www.npmjs.com/package/test3", packages[2].Homepage)
 }
+
+func TestNpmRegistryService_PeerDependencies_Versions(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.EscapedPath())
+		_, _ = w.Write([]byte(`{"name": "x", "peerDependencies": {"react": "^18.0.0", "react-dom": "^18.0.0"}}`))
+	}))
+	defer server.Close()
+
+	service := services.NewNpmRegistryServiceWithRegistryURL(server.Client(), server.URL)
+
+	for _, pkg := range []string{"react-redux", "react-redux@9.1.0", "@tanstack/react-query", "@tanstack/react-query@5.0.0"} {
+		peers, err := service.PeerDependencies(pkg)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"react": "^18.0.0", "react-dom": "^18.0.0"}, peers)
+	}
+
+	assert.Equal(t, []string{
+		"/react-redux/latest",
+		"/react-redux/9.1.0",
+		"/@tanstack%2Freact-query/latest",
+		"/@tanstack%2Freact-query/5.0.0",
+	}, paths)
+}
+
+func TestNpmRegistryService_PeerDependencies_None(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"name": "lodash", "version": "4.17.21"}`))
+	}))
+	defer server.Close()
+
+	service := services.NewNpmRegistryServiceWithRegistryURL(server.Client(), server.URL)
+
+	peers, err := service.PeerDependencies("lodash")
+	assert.NoError(t, err)
+	assert.Empty(t, peers)
+}
+
+func TestNpmRegistryService_PeerDependencies_HTTPError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error": "Not found"}`))
+	}))
+	defer server.Close()
+
+	service := services.NewNpmRegistryServiceWithRegistryURL(server.Client(), server.URL)
+
+	peers, err := service.PeerDependencies("does-not-exist")
+	assert.Nil(t, peers)
+	assert.ErrorContains(t, err, "npm registry returned status 404 for does-not-exist")
+}
+
+var _ = Describe("NpmCLISearcher", func() {
+	assertT := assert.New(GinkgoT())
+
+	It("runs npm search --json and parses its results", func() {
+		var ran []string
+		searcher := services.NewNpmCLISearcher(func(args ...string) ([]byte, error) {
+			ran = args
+			return []byte(`[
+				{"name": "react", "version": "18.2.0", "description": "React", "links": {"repository": "https://github.com/facebook/react", "npm": "https://www.npmjs.com/package/react"}},
+				{"name": "preact", "version": "10.19.0", "description": "Preact", "links": {"npm": "https://www.npmjs.com/package/preact"}}
+			]`), nil
+		})
+
+		packages, err := searcher.SearchPackages("react")
+		assertT.NoError(err)
+		assertT.Equal([]string{"search", "--json", "--searchlimit=35", "react"}, ran)
+		assertT.Equal([]services.PackageInfo{
+			{Name: "react", Version: "18.2.0", Description: "React", Homepage: "https://github.com/facebook/react"},
+			{Name: "preact", Version: "10.19.0", Description: "Preact", Homepage: "https://www.npmjs.com/package/preact"},
+		}, packages)
+	})
+
+	It("returns an error for output that isn't JSON", func() {
+		searcher := services.NewNpmCLISearcher(func(args ...string) ([]byte, error) {
+			return []byte("NAME | DESCRIPTION"), nil
+		})
+
+		_, err := searcher.SearchPackages("react")
+		assertT.ErrorContains(err, "failed to parse npm search output")
+	})
+
+	It("returns an error for an empty pattern", func() {
+		searcher := services.NewNpmCLISearcher(func(args ...string) ([]byte, error) {
+			return nil, fmt.Errorf("should not run")
+		})
+
+		_, err := searcher.SearchPackages("")
+		assertT.ErrorContains(err, "search pattern cannot be empty")
+	})
+})
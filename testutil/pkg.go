@@ -1,10 +1,16 @@
 package testutil
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"os"
+	"slices"
+	"sync"
+	"time"
 
 	ginkgo "github.com/onsi/ginkgo/v2"
+	"github.com/samber/lo"
 	"github.com/spf13/cobra"
 	tmock "github.com/stretchr/testify/mock"
 
@@ -348,6 +354,10 @@ func (f *RootCommandFactory) baseDependencies() cmd.Dependencies {
 		NewPackageMultiSelectUI:     mock.NewMockPackageMultiSelectUI,
 		NewTaskSelectorUI:           mock.NewMockTaskSelectUI,
 		NewDependencyMultiSelectUI:  mock.NewMockDependencySelectUI,
+		PackageManagerVersionOutputter: func(string) (string, error) {
+			return "", fmt.Errorf("package managers are not run in tests") // Default to no versions in doctor
+		},
+		RetrySleeper: func(time.Duration) {}, // Retries never wait in tests
 		NewCreateAppSearcher: func() cmd.CreateAppSearcher {
 			searcher := &mock.CreateAppSearcherMock{}
 			searcher.On("SearchCreateApps", tmock.Anything, tmock.Anything).
@@ -389,6 +399,69 @@ func (f *RootCommandFactory) CreateRootCmdWithLockfileDetected(pm string, lockfi
 	return cmd.NewRootCmdForTesting(deps)
 }
 
+// CreateRootCmdWith creates a root command with npm detected from package-lock.json.
+// Each configure function then changes the dependencies a spec needs, in order.
+func (f *RootCommandFactory) CreateRootCmdWith(configure ...func(*cmd.Dependencies)) *cobra.Command {
+	deps := f.baseDependencies()
+	LockfileDetected(detect.NPM, detect.PACKAGE_LOCK_JSON)(&deps)
+	for _, apply := range configure {
+		apply(&deps)
+	}
+	return cmd.NewRootCmdForTesting(deps)
+}
+
+// LockfileDetected makes the root command detect pm from lockfile.
+func LockfileDetected(pm string, lockfile string) func(*cmd.Dependencies) {
+	return func(deps *cmd.Dependencies) {
+		deps.DetectLockfile = func(targetDir string) (string, error) {
+			return lockfile, nil
+		}
+		deps.DetectJSPackageManagerBasedOnLockFile = func(detectedLockFile string) (string, error) {
+			return pm, nil
+		}
+	}
+}
+
+// AncestorLockfileDetection makes the root command detect lock files on the real file system,
+// including ancestor directories, and map them to their package manager.
+// `pathPM` is returned when no lock file is found at all.
+func AncestorLockfileDetection(pathPM string) func(*cmd.Dependencies) {
+	return func(deps *cmd.Dependencies) {
+		deps.DetectLockfile = func(targetDir string) (string, error) {
+			return detect.DetectLockfileIn(targetDir, detect.RealFileSystem{})
+		}
+		deps.DetectLockfileInAncestors = func(startDir string) (string, string, error) {
+			return detect.DetectLockfileInAncestors(startDir, detect.RealFileSystem{})
+		}
+		deps.DetectJSPackageManagerBasedOnLockFile = func(detectedLockFile string) (string, error) {
+			return detect.LockFileToPackageManagerMap[detectedLockFile], nil
+		}
+		deps.DetectJSPackageManager = func() (string, error) {
+			return pathPM, nil
+		}
+	}
+}
+
+// YarnConfigDetection makes the root command detect lock files and yarn config files on the real file system.
+// `pathPM` is returned when neither is found, yarn --version prints `yarnVersion`.
+func YarnConfigDetection(pathPM, yarnVersion string) func(*cmd.Dependencies) {
+	return func(deps *cmd.Dependencies) {
+		deps.DetectLockfile = func(targetDir string) (string, error) {
+			return detect.DetectLockfileIn(targetDir, detect.RealFileSystem{})
+		}
+		deps.DetectJSPackageManagerBasedOnLockFile = func(detectedLockFile string) (string, error) {
+			return detect.LockFileToPackageManagerMap[detectedLockFile], nil
+		}
+		deps.DetectJSPackageManager = func() (string, error) {
+			return pathPM, nil
+		}
+		deps.DetectYarnConfig = func(targetDir string) (string, error) {
+			return detect.DetectYarnConfigIn(targetDir, detect.RealFileSystem{})
+		}
+		deps.YarnCommandVersionOutputter = mock.NewMockYarnCommandVersionOutputer(yarnVersion)
+	}
+}
+
 // CreateRootCmdWithPathDetected creates a root command simulating package manager
 // detection by checking the global PATH (no lockfile found).
 //
@@ -610,3 +683,203 @@ func (f *RootCommandFactory) CreateWithDependencySelectUI(packageManager string)
 	deps.NewDependencyMultiSelectUI = mock.NewMockDependencySelectUI
 	return cmd.NewRootCmdForTesting(deps)
 }
+
+// MockScriptRunners hands out a new mock.MockCommandRunner to every script jpd runs on a runner of its own
+// (run --parallel, --timeout and --capture, outdated --json...) and keeps them in the order they were handed out.
+type MockScriptRunners struct {
+	// Setup, when set, prepares each runner with the writers of its script, ScriptOutput gives Run an output
+	Setup func(runner *mock.MockCommandRunner, stdout, stderr io.Writer)
+
+	mu      sync.Mutex
+	runners []*mock.MockCommandRunner
+}
+
+// NewRunner is the cmd.ParallelCommandRunnerFactory of the runners.
+func (s *MockScriptRunners) NewRunner(_ context.Context, stdout, stderr io.Writer) cmd.CommandRunner {
+	runner := mock.NewMockCommandRunner()
+	if s.Setup != nil {
+		s.Setup(runner, stdout, stderr)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.runners = append(s.runners, runner)
+	return runner
+}
+
+// Runners returns the runners handed out so far.
+func (s *MockScriptRunners) Runners() []*mock.MockCommandRunner {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.runners)
+}
+
+// ScriptOutput returns a MockScriptRunners.Setup whose runners write lines to stdout and stderr in turn,
+// starting with stdout, and then return err like a script with a fixed output.
+func ScriptOutput(err error, lines ...string) func(*mock.MockCommandRunner, io.Writer, io.Writer) {
+	return func(runner *mock.MockCommandRunner, stdout, stderr io.Writer) {
+		runner.On("Run", tmock.Anything, tmock.Anything, tmock.Anything).Run(func(tmock.Arguments) {
+			for i, line := range lines {
+				_, _ = fmt.Fprintln(lo.Ternary(i%2 == 0, stdout, stderr), line)
+			}
+		}).Return(err)
+	}
+}
+
+// BlockingScripts hands out runners that keep running like a long lived script until their context is done,
+// or until FinishIn passed, and records what they ran. They can run at the same time.
+type BlockingScripts struct {
+	// Together is the number of scripts that must all have started before any of them goes on, 0 doesn't wait
+	Together int
+	// FinishIn is how long a script runs before it succeeds, 0 runs it until its context is done
+	FinishIn time.Duration
+	// Output is written to the stdout of each script once it goes on, %s is replaced by the script
+	Output string
+	// Fail makes the named scripts fail with the error once they go on
+	Fail map[string]error
+
+	mu         sync.Mutex
+	allStarted chan struct{}
+	commands   [][]string
+	dirs       []string
+	stopped    []string
+	running    int
+	maxRunning int
+}
+
+// NewRunner is the cmd.ParallelCommandRunnerFactory of the scripts.
+func (s *BlockingScripts) NewRunner(ctx context.Context, stdout, _ io.Writer) cmd.CommandRunner {
+	return &blockingScript{scripts: s, ctx: ctx, stdout: stdout}
+}
+
+// AllStarted is closed once Together scripts started.
+func (s *BlockingScripts) AllStarted() <-chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.allStartedLocked()
+}
+
+// allStartedLocked makes the channel of AllStarted the first time it's asked for, s.mu must be held.
+func (s *BlockingScripts) allStartedLocked() chan struct{} {
+	if s.allStarted == nil {
+		s.allStarted = make(chan struct{})
+	}
+	return s.allStarted
+}
+
+// Commands returns the program and arguments of every script that started, in the order they started.
+func (s *BlockingScripts) Commands() [][]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.commands)
+}
+
+// Scripts returns the last argument, the script, of every command that started.
+func (s *BlockingScripts) Scripts() []string {
+	return lo.Map(s.Commands(), func(command []string, _ int) string {
+		return command[len(command)-1]
+	})
+}
+
+// Dirs returns the target directory of every script that started, "" when none was set.
+func (s *BlockingScripts) Dirs() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.dirs)
+}
+
+// Stopped returns the scripts that were still running when their context was done.
+func (s *BlockingScripts) Stopped() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.stopped)
+}
+
+// MaxRunning returns the most scripts that ran at the same time.
+func (s *BlockingScripts) MaxRunning() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.maxRunning
+}
+
+func (s *BlockingScripts) start(command []string, dir string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.commands = append(s.commands, command)
+	s.dirs = append(s.dirs, dir)
+	s.running++
+	s.maxRunning = max(s.maxRunning, s.running)
+	if len(s.commands) == s.Together {
+		close(s.allStartedLocked())
+	}
+}
+
+func (s *BlockingScripts) finish(script string, stopped bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.running--
+	if stopped {
+		s.stopped = append(s.stopped, script)
+	}
+}
+
+type blockingScript struct {
+	scripts *BlockingScripts
+	ctx     context.Context
+	stdout  io.Writer
+	name    string
+	args    []string
+	dir     string
+}
+
+func (b *blockingScript) Command(name string, args ...string) {
+	b.name, b.args = name, args
+}
+
+func (b *blockingScript) SetTargetDir(dir string) error {
+	b.dir = dir
+	return nil
+}
+
+func (b *blockingScript) SetEnv([]string) {}
+
+func (b *blockingScript) ClearEnv() {}
+
+func (b *blockingScript) Run() error {
+	s := b.scripts
+	script := b.args[len(b.args)-1]
+
+	s.start(append([]string{b.name}, b.args...), b.dir)
+	stopped := false
+	defer func() { s.finish(script, stopped) }()
+
+	if s.Together > 0 {
+		select {
+		case <-s.AllStarted():
+		case <-time.After(2 * time.Second):
+			return fmt.Errorf("%s never ran alongside the other scripts", script)
+		}
+	}
+
+	if s.Output != "" {
+		_, _ = fmt.Fprintf(b.stdout, s.Output, script)
+	}
+	if err, ok := s.Fail[script]; ok {
+		return err
+	}
+
+	// A script that runs until it is stopped gives up after a while, so a spec fails instead of hanging
+	runFor := lo.Ternary(s.FinishIn > 0, s.FinishIn, 2*time.Second)
+	select {
+	case <-b.ctx.Done():
+		stopped = true
+		return b.ctx.Err()
+	case <-time.After(runFor):
+		if s.FinishIn > 0 {
+			return nil
+		}
+		return fmt.Errorf("%s was never stopped", script)
+	}
+}